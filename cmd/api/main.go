@@ -1,10 +1,19 @@
 package main
 
 import (
+	"os"
+
 	"github.com/sirupsen/logrus"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate(os.Args[2:]); err != nil {
+			logrus.Fatalf("Migrate: %+v", err)
+		}
+		return
+	}
+
 	s, err := NewServer()
 	if err != nil {
 		logrus.Fatalf("Create server: %v", err)
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"buddle-server/internal/app/api"
+	"buddle-server/internal/db"
+	"buddle-server/internal/db/migration"
+	"buddle-server/internal/log"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"os"
+	"strconv"
+)
+
+// migrate 는 "api migrate up|down [n]|force <version>|status" 명령을 실행한다.
+func migrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: api migrate up|down [n]|force <version>|status")
+	}
+
+	if err := api.InitConfig(configPath); err != nil {
+		return errors.Wrapf(err, "Load config file path = %s", configPath)
+	}
+
+	conf := api.Config()
+	if err := log.Init(conf.Logger); err != nil {
+		return errors.Wrap(err, "Init logger")
+	}
+
+	conn, err := db.Connect(conf.DB)
+	if err != nil {
+		return errors.Wrap(err, "Init db")
+	}
+
+	migrator, err := migration.New(conn)
+	if err != nil {
+		return errors.Wrap(err, "Init migrator")
+	}
+
+	c := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(c)
+		for _, m := range applied {
+			logrus.Infof("applied migration %06d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			return errors.Wrap(err, "migrate up")
+		}
+		logrus.Infof("%d migrations applied", len(applied))
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				return fmt.Errorf("invalid steps(%s)", args[1])
+			}
+		}
+		reverted, err := migrator.Down(c, steps)
+		for _, m := range reverted {
+			logrus.Infof("reverted migration %06d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			return errors.Wrap(err, "migrate down")
+		}
+		logrus.Infof("%d migrations reverted", len(reverted))
+	case "force":
+		if len(args) < 2 {
+			return errors.New("usage: api migrate force <version>")
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version(%s)", args[1])
+		}
+		if err := migrator.Force(c, version); err != nil {
+			return errors.Wrap(err, "migrate force")
+		}
+		logrus.Infof("forced migration version %06d", version)
+	case "status":
+		statuses, err := migrator.Status(c)
+		if err != nil {
+			return errors.Wrap(err, "migrate status")
+		}
+		for _, s := range statuses {
+			appliedAt := "pending"
+			switch {
+			case s.Dirty:
+				appliedAt = "dirty"
+			case s.Applied:
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(os.Stdout, "%06d  %-40s  %s\n", s.Version, s.Name, appliedAt)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command(%s)", args[0])
+	}
+
+	return nil
+}
+
+// checkSchema 는 적용되지 않은 마이그레이션이 있으면 에러를 반환한다.
+// 모델이 사용하는 컬럼이 없는 스키마로 서버가 뜨지 않도록 서버 시작 전에 확인한다.
+func checkSchema(conn *gorm.DB) error {
+	migrator, err := migration.New(conn)
+	if err != nil {
+		return errors.Wrap(err, "Init migrator")
+	}
+
+	pending, err := migrator.Pending(context.Background())
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%d migrations are pending, run \"api migrate up\" first (next = %06d_%s)", len(pending), pending[0].Version, pending[0].Name)
+	}
+
+	return nil
+}
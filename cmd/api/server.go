@@ -5,6 +5,7 @@ import (
 	"buddle-server/internal/app/api"
 	"buddle-server/internal/db"
 	"buddle-server/internal/log"
+	"buddle-server/internal/ocr"
 	"buddle-server/internal/s3"
 	"buddle-server/middleware"
 	"buddle-server/repository"
@@ -27,24 +28,37 @@ var (
 )
 
 type server struct {
-	echo *echo.Echo
-	db   *gorm.DB
+	echo   *echo.Echo
+	db     *gorm.DB
+	readDB *gorm.DB
 
 	// Handlers
 	productHandler      handler.ProductHandler
 	userHandler         handler.UserHandler
 	afterServiceHandler handler.AfterServiceHandler
+	metaHandler         handler.MetaHandler
+	productModelHandler handler.ProductModelHandler
+	salesChannelHandler handler.SalesChannelHandler
 
 	// Services
 	productService service.ProductService
 	userService    service.UserService
 	afterService   service.AfterService
 
+	productModelService service.ProductModelService
+	salesChannelService service.SalesChannelService
+
 	// Repositories
 	repo repository.Repository
 
 	// S3
-	fileBucket *s3.S3
+	fileBucket s3.Storage
+
+	// 영수증 OCR ( nil 이면 사용하지 않음 )
+	receiptOCR ocr.Provider
+
+	jwtSecret string
+	tempDir   string
 }
 
 func NewServer() (*server, error) {
@@ -56,33 +70,51 @@ func NewServer() (*server, error) {
 	if err := s.initRepositories(); err != nil {
 		return nil, errors.Wrap(err, "failed to init repository")
 	}
+	if err := s.initApp(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// initApp 은 db, repo, fileBucket 등이 준비된 상태에서 서비스, 핸들러, 라우트를 구성한다.
+func (s *server) initApp() error {
 	if err := s.initServices(); err != nil {
-		return nil, errors.Wrap(err, "failed to init service")
+		return errors.Wrap(err, "failed to init service")
 	}
 	if err := s.initHandlers(); err != nil {
-		return nil, errors.Wrap(err, "failed to init handlers")
+		return errors.Wrap(err, "failed to init handlers")
 	}
 
 	s.initRoutes()
 
-	return s, nil
+	return nil
 }
 
 func (s *server) initHandlers() (err error) {
-	if s.productHandler, err = handler.NewProductHandler(s.productService); err != nil {
+	if s.productHandler, err = handler.NewProductHandler(s.productService, s.tempDir); err != nil {
 		return errors.Wrap(err, "failed init product handler")
 	}
-	if s.afterServiceHandler, err = handler.NewAfterHandler(s.afterService); err != nil {
+	if s.afterServiceHandler, err = handler.NewAfterHandler(s.afterService, s.tempDir); err != nil {
 		return errors.Wrap(err, "failed init product handler")
 	}
-	if s.userHandler, err = handler.NewUserHandler(s.userService); err != nil {
+	if s.userHandler, err = handler.NewUserHandler(s.userService, s.jwtSecret); err != nil {
 		return errors.Wrap(err, "failed init login handler")
 	}
+	if s.metaHandler, err = handler.NewMetaHandler(); err != nil {
+		return errors.Wrap(err, "failed init meta handler")
+	}
+	if s.productModelHandler, err = handler.NewProductModelHandler(s.productModelService); err != nil {
+		return errors.Wrap(err, "failed init product model handler")
+	}
+	if s.salesChannelHandler, err = handler.NewSalesChannelHandler(s.salesChannelService); err != nil {
+		return errors.Wrap(err, "failed init sales channel handler")
+	}
 	return
 }
 
 func (s *server) initServices() (err error) {
-	if s.productService, err = service.NewProductService(s.repo, s.fileBucket); err != nil {
+	if s.productService, err = service.NewProductService(s.repo, s.fileBucket, s.receiptOCR); err != nil {
 		return errors.Wrap(err, "failed init product services")
 	}
 	if s.afterService, err = service.NewAfterService(s.repo, s.fileBucket); err != nil {
@@ -91,6 +123,12 @@ func (s *server) initServices() (err error) {
 	if s.userService, err = service.NewUserService(s.repo); err != nil {
 		return errors.Wrap(err, "failed init user services")
 	}
+	if s.productModelService, err = service.NewProductModelService(s.repo); err != nil {
+		return errors.Wrap(err, "failed init product model services")
+	}
+	if s.salesChannelService, err = service.NewSalesChannelService(s.repo); err != nil {
+		return errors.Wrap(err, "failed init sales channel services")
+	}
 	return
 }
 
@@ -103,7 +141,10 @@ func (s *server) initRepositories() (err error) {
 }
 
 func (s *server) initRoutes() {
+	s.echo.HTTPErrorHandler = handler.ErrorHandler
+	s.echo.JSONSerializer = handler.JSONSerializer{}
 	s.echo.Use(md.CORS())
+	s.echo.Use(middleware.Language)
 
 	s.echo.GET("/healthcheck", func(echoCtx echo.Context) error {
 		return echoCtx.String(http.StatusOK, "OK")
@@ -112,11 +153,11 @@ func (s *server) initRoutes() {
 	v1 := s.echo.Group(
 		"/v1",
 		middleware.CustomContext,
-		middleware.WithDB("", s.db),
+		middleware.WithDB(s.db, s.readDB),
 	)
 
 	jwtMiddleWare := md.JWTWithConfig(md.JWTConfig{
-		SigningKey:  []byte(api.Config().Jwt.SecretKey),
+		SigningKey:  []byte(s.jwtSecret),
 		TokenLookup: "header:access-token,query:access-token",
 	})
 
@@ -125,14 +166,47 @@ func (s *server) initRoutes() {
 		v1Product.POST("", s.productHandler.CreateProduct)
 		v1Product.GET("/manage", s.productHandler.FindProductList)
 		v1Product.GET("/receipt", s.productHandler.DownloadReceipt)
+		v1Product.GET("/:product_seq/as", s.afterServiceHandler.FindProductAfterServices)
+		v1Product.POST("/transfers", s.productHandler.StartTransferByAdmin)
+		v1Product.GET("/reviews", s.productHandler.FindProductReviews)
+		v1Product.POST("/reviews/:product_regist_seq/approve", s.productHandler.ApproveProductAuth)
+		v1Product.POST("/reviews/:product_regist_seq/reject", s.productHandler.RejectProductAuth)
+		v1Product.GET("/models", s.productModelHandler.FindProductModels)
+		v1Product.POST("/models", s.productModelHandler.CreateProductModel)
+		v1Product.PUT("/models/:code", s.productModelHandler.UpdateProductModel)
+		v1Product.DELETE("/models/:code", s.productModelHandler.DeleteProductModel)
+	}
+
+	// 고객이 제품 인증 전에 사용하므로 인증 없이 호출한다. ( /product 그룹의 JWT 미적용 )
+	v1.GET("/product/serial/:serial/check", s.productHandler.CheckSerial)
+
+	v1Products := v1.Group("/products")
+	{
+		v1Products.GET("/models", s.productModelHandler.FindActiveProductModels)
+	}
+
+	v1SalesChannel := v1.Group("/sales-channel", jwtMiddleWare)
+	{
+		v1SalesChannel.GET("", s.salesChannelHandler.FindSalesChannels)
+		v1SalesChannel.POST("", s.salesChannelHandler.CreateSalesChannel)
+		v1SalesChannel.PUT("/:code", s.salesChannelHandler.UpdateSalesChannel)
+		v1SalesChannel.DELETE("/:code", s.salesChannelHandler.DeleteSalesChannel)
+	}
+
+	v1SalesChannels := v1.Group("/sales-channels")
+	{
+		v1SalesChannels.GET("", s.salesChannelHandler.FindActiveSalesChannels)
 	}
 
 	v1ProductRegist := v1.Group("/product-regist")
 	{
 		v1ProductRegist.GET("", s.productHandler.GetAuthProduct)
 		v1ProductRegist.POST("", s.productHandler.AuthProduct)
+		v1ProductRegist.GET("/:product_regist_seq", s.productHandler.GetAuthProductBySeq)
 		v1ProductRegist.PUT("/:product_regist_seq", s.productHandler.ModAuthProduct)
 		v1ProductRegist.POST("/:product_regist_seq/cancel", s.productHandler.CancelAuthProduct)
+		v1ProductRegist.POST("/:product_regist_seq/transfer", s.productHandler.StartTransfer)
+		v1ProductRegist.POST("/transfer", s.productHandler.ClaimTransfer)
 	}
 
 	v1AfterService := v1.Group("/as")
@@ -148,6 +222,11 @@ func (s *server) initRoutes() {
 		v1user.POST("", s.userHandler.SignUp)
 		v1user.POST("/login", s.userHandler.SignIn)
 	}
+
+	v1Meta := v1.Group("/meta")
+	{
+		v1Meta.GET("/error-codes", s.metaHandler.FindErrorCodes)
+	}
 }
 
 func (s *server) initConfig() (err error) {
@@ -160,14 +239,33 @@ func (s *server) initConfig() (err error) {
 		return errors.Wrap(err, "Init logger")
 	}
 
+	s.jwtSecret = conf.Jwt.SecretKey
+	s.tempDir = conf.TempDir
+	if s.tempDir == "" {
+		s.tempDir = api.DefaultTempDir
+	}
+
 	if s.db, err = db.Connect(conf.DB); err != nil {
 		return errors.Wrap(err, "Init db")
 	}
+	if err = checkSchema(s.db); err != nil {
+		return errors.Wrap(err, "Check db schema")
+	}
 
-	if s.fileBucket, err = s3.New(conf.FileBucket); err != nil {
+	if conf.ReadDB.Host != "" {
+		if s.readDB, err = db.Connect(conf.ReadDB); err != nil {
+			return errors.Wrap(err, "Init read db")
+		}
+	}
+
+	if s.fileBucket, err = s3.NewStorage(conf.FileBucket); err != nil {
 		return errors.Wrap(err, "Init file bucket")
 	}
 
+	if s.receiptOCR, err = ocr.NewProvider(conf.ReceiptOCR); err != nil {
+		return errors.Wrap(err, "Init receipt ocr")
+	}
+
 	return nil
 }
 
@@ -0,0 +1,1444 @@
+package main
+
+import (
+	"buddle-server/internal/app/api"
+	"buddle-server/internal/db/dbtest"
+	"buddle-server/internal/ocr"
+	"buddle-server/internal/s3/s3test"
+	"buddle-server/model"
+	"buddle-server/repository"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const testConfig = `
+jwt:
+  secret_key: test-secret
+`
+
+type testServer struct {
+	*server
+	storage *s3test.Memory
+}
+
+// newTestServer 는 SQLite 인메모리 DB 와 메모리 저장소를 주입한 서버를 생성한다.
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := ioutil.WriteFile(configFile, []byte(testConfig), 0600); err != nil {
+		t.Fatalf("failed to write test config: %+v", err)
+	}
+	if err := api.InitConfig(configFile); err != nil {
+		t.Fatalf("failed to init test config: %+v", err)
+	}
+
+	_, conn := dbtest.New(t)
+	repo, err := repository.NewRepository()
+	if err != nil {
+		t.Fatalf("failed to create repository: %+v", err)
+	}
+
+	storage := s3test.NewMemory()
+	s := &server{
+		echo:       echo.New(),
+		db:         conn,
+		repo:       repo,
+		fileBucket: storage,
+		receiptOCR: ocr.PlainText{}, // 영수증 파일 내용을 OCR 결과로 사용
+		jwtSecret:  api.Config().Jwt.SecretKey,
+		tempDir:    t.TempDir(),
+	}
+	if err := s.initApp(); err != nil {
+		t.Fatalf("failed to init server: %+v", err)
+	}
+
+	return &testServer{server: s, storage: storage}
+}
+
+func (s *testServer) do(req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+// signIn 은 관리자 계정을 만들고 로그인하여 access token 을 반환한다.
+func (s *testServer) signIn(t *testing.T) string {
+	t.Helper()
+
+	rec := s.do(newJSONRequest(http.MethodPost, "/v1/user", `{"id":"admin","password":"secret"}`))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("sign up: status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	rec = s.do(newJSONRequest(http.MethodPost, "/v1/user/login", `{"id":"admin","password":"secret"}`))
+	var resp struct {
+		Success bool `json:"success"`
+		Data    struct {
+			AccessToken string `json:"access_token"`
+		} `json:"data"`
+	}
+	decodeBody(t, rec, &resp)
+	if !resp.Success || resp.Data.AccessToken == "" {
+		t.Fatalf("sign in: body = %s", rec.Body)
+	}
+
+	return resp.Data.AccessToken
+}
+
+func newJSONRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	return req
+}
+
+type formFile struct {
+	field    string
+	filename string
+	content  string
+}
+
+func newMultipartRequest(t *testing.T, method, target string, fields url.Values, files ...formFile) *http.Request {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+	for key := range fields {
+		if err := w.WriteField(key, fields.Get(key)); err != nil {
+			t.Fatalf("failed to write field(%s): %+v", key, err)
+		}
+	}
+	for _, file := range files {
+		part, err := w.CreateFormFile(file.field, file.filename)
+		if err != nil {
+			t.Fatalf("failed to create form file(%s): %+v", file.field, err)
+		}
+		if _, err := io.WriteString(part, file.content); err != nil {
+			t.Fatalf("failed to write form file(%s): %+v", file.field, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to close multipart writer: %+v", err)
+	}
+
+	req := httptest.NewRequest(method, target, body)
+	req.Header.Set(echo.HeaderContentType, w.FormDataContentType())
+	return req
+}
+
+func withToken(req *http.Request, token string) *http.Request {
+	req.Header.Set("access-token", "Bearer "+token)
+	return req
+}
+
+func decodeBody(t *testing.T, rec *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("failed to decode body(%s): %+v", rec.Body, err)
+	}
+}
+
+func productRegistForm(serialNo string) url.Values {
+	return url.Values{
+		"name":          {"홍길동"},
+		"phone":         {"01012345678"},
+		"addr":          {"서울시 강남구"},
+		"addr_detail":   {"101호"},
+		"serial_no":     {serialNo},
+		"product_type":  {"0"},
+		"market_type":   {"1"},
+		"purchase_date": {"2022-03-01T00:00:00Z"},
+	}
+}
+
+func afterServiceForm() url.Values {
+	return url.Values{
+		"name":          {"김철수"},
+		"phone":         {"01098765432"},
+		"email":         {"test@example.com"},
+		"addr":          {"서울시 서초구"},
+		"addr_detail":   {"202호"},
+		"product_type":  {"2"},
+		"market_type":   {"2"},
+		"purchase_date": {"2022-03-01T00:00:00Z"},
+		"contents":      {"전원이 켜지지 않습니다."},
+	}
+}
+
+// importProducts 는 CSV 로 제품 시리얼을 등록한다.
+func (s *testServer) importProducts(t *testing.T, token, csv string) (success, failure int64) {
+	t.Helper()
+
+	req := newMultipartRequest(t, http.MethodPost, "/v1/product", nil, formFile{field: "csv_file", filename: "products.csv", content: csv})
+	rec := s.do(withToken(req, token))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("import products: status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	var resp struct {
+		Success int64 `json:"success"`
+		Failure int64 `json:"failure"`
+	}
+	decodeBody(t, rec, &resp)
+
+	return resp.Success, resp.Failure
+}
+
+// registProduct 는 제품 인증을 요청하고 상태 코드와 응답을 반환한다.
+func (s *testServer) registProduct(t *testing.T, serialNo, receipt string) (int, model.Response) {
+	t.Helper()
+
+	req := newMultipartRequest(t, http.MethodPost, "/v1/product-regist", productRegistForm(serialNo), formFile{field: "receipt", filename: "receipt.jpg", content: receipt})
+	rec := s.do(req)
+
+	var resp model.Response
+	decodeBody(t, rec, &resp)
+
+	return rec.Code, resp
+}
+
+// assertError 는 에러 응답의 상태 코드와 에러 코드를 확인한다.
+func assertError(t *testing.T, rec *httptest.ResponseRecorder, status int, code model.ResponseErrorCode) {
+	t.Helper()
+
+	var resp model.Response
+	decodeBody(t, rec, &resp)
+	if rec.Code != status || resp.Success || resp.ErrorCode != code {
+		t.Fatalf("status = %d, body = %s, want status %d, error_code %s", rec.Code, rec.Body, status, code)
+	}
+}
+
+type productManageInfo struct {
+	ProductSeq       int64                   `json:"product_seq"`
+	ProductRegistSeq int64                   `json:"product_regist_seq"`
+	Name             string                  `json:"name"`
+	Phone            string                  `json:"phone"`
+	Status           model.ProductAuthStatus `json:"status"`
+	PurchaseDate     string                  `json:"purchase_date"`
+	Regdate          string                  `json:"product_regist_regdate"`
+}
+
+// findProductManageInfos 는 관리자 제품 목록을 조회한다.
+func (s *testServer) findProductManageInfos(t *testing.T, token, query string) []productManageInfo {
+	t.Helper()
+
+	rec := s.do(withToken(httptest.NewRequest(http.MethodGet, "/v1/product/manage?"+query, nil), token))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("find product manage info: status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	var resp struct {
+		Data []productManageInfo `json:"data"`
+	}
+	decodeBody(t, rec, &resp)
+
+	return resp.Data
+}
+
+// approveProduct 는 시리얼 번호의 인증 대기 중인 제품 인증을 승인하고 인증 정보 번호를 반환한다.
+func (s *testServer) approveProduct(t *testing.T, token, serialNo string) int64 {
+	t.Helper()
+
+	infos := s.findProductManageInfos(t, token, fmt.Sprintf("serial_no=%s&status=%d", serialNo, model.ProductAuthStatusPending))
+	if len(infos) != 1 {
+		t.Fatalf("pending product regists of %s = %+v", serialNo, infos)
+	}
+
+	rec := s.do(withToken(httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/product/reviews/%d/approve", infos[0].ProductRegistSeq), nil), token))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("approve %s: status = %d, body = %s", serialNo, rec.Code, rec.Body)
+	}
+
+	return infos[0].ProductRegistSeq
+}
+
+func TestHealthcheck(t *testing.T) {
+	s := newTestServer(t)
+
+	rec := s.do(httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "OK" {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+}
+
+func TestUserRoutes(t *testing.T) {
+	s := newTestServer(t)
+
+	t.Run("sign up requires id and password", func(t *testing.T) {
+		rec := s.do(newJSONRequest(http.MethodPost, "/v1/user", `{"id":"admin"}`))
+		assertError(t, rec, http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+	})
+
+	token := s.signIn(t)
+
+	t.Run("issued token passes jwt middleware", func(t *testing.T) {
+		s.findProductManageInfos(t, token, "")
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		rec := s.do(newJSONRequest(http.MethodPost, "/v1/user/login", `{"id":"admin","password":"wrong"}`))
+		assertError(t, rec, http.StatusUnauthorized, model.ResponseErrorCodeInvalidUserPwd)
+		if strings.Contains(rec.Body.String(), "access_token") {
+			t.Fatalf("body = %s, want no access token", rec.Body)
+		}
+	})
+
+	t.Run("unknown id", func(t *testing.T) {
+		rec := s.do(newJSONRequest(http.MethodPost, "/v1/user/login", `{"id":"nobody","password":"secret"}`))
+		assertError(t, rec, http.StatusUnauthorized, model.ResponseErrorCodeUserIDNotExist)
+	})
+
+	t.Run("duplicated id", func(t *testing.T) {
+		rec := s.do(newJSONRequest(http.MethodPost, "/v1/user", `{"id":"admin","password":"other"}`))
+		assertError(t, rec, http.StatusConflict, model.ResponseErrorCodeDuplUserID)
+	})
+}
+
+func TestProductRoutesRequireToken(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, target := range []string{"/v1/product/manage", "/v1/product/receipt?product_regist_seq=1"} {
+		rec := s.do(httptest.NewRequest(http.MethodGet, target, nil))
+		assertError(t, rec, http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+
+		rec = s.do(withToken(httptest.NewRequest(http.MethodGet, target, nil), "invalid"))
+		assertError(t, rec, http.StatusUnauthorized, model.ResponseErrorCodeUnauthorized)
+	}
+}
+
+func TestProductRoutes(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signIn(t)
+
+	success, failure := s.importProducts(t, token, "SN-001,0\nSN-002,1\nSN-001,0\nSN-003,x\n")
+	if success != 2 || failure != 2 {
+		t.Fatalf("import: success = %d, failure = %d, want 2, 2", success, failure)
+	}
+
+	t.Run("regist with unknown serial", func(t *testing.T) {
+		status, resp := s.registProduct(t, "SN-999", "receipt")
+		if status != http.StatusNotFound || resp.ErrorCode != model.ResponseErrorCodeProductNotExist {
+			t.Fatalf("status = %d, resp = %+v", status, resp)
+		}
+	})
+
+	receipt := "receipt image"
+	if status, resp := s.registProduct(t, "SN-001", receipt); status != http.StatusOK || !resp.Success {
+		t.Fatalf("regist: status = %d, resp = %+v", status, resp)
+	}
+
+	t.Run("regist duplicated product", func(t *testing.T) {
+		status, resp := s.registProduct(t, "SN-001", "another receipt")
+		if status != http.StatusConflict || resp.ErrorCode != model.ResponseErrorCodeDuplProduct {
+			t.Fatalf("status = %d, resp = %+v", status, resp)
+		}
+		if keys := s.storage.Keys(); len(keys) != 1 {
+			t.Fatalf("stored objects = %v, want only the first receipt", keys)
+		}
+	})
+
+	// 관리자가 영수증을 확인할 때까지 인증 대기 상태다.
+	infos := s.findProductManageInfos(t, token, "serial_no=SN-001")
+	if len(infos) != 1 || infos[0].ProductRegistSeq == 0 || infos[0].Status != model.ProductAuthStatusPending {
+		t.Fatalf("manage infos = %+v", infos)
+	}
+	productRegistSeq := infos[0].ProductRegistSeq
+
+	t.Run("review queue", func(t *testing.T) {
+		assertError(t, s.do(httptest.NewRequest(http.MethodGet, "/v1/product/reviews", nil)), http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+
+		rec := s.do(withToken(httptest.NewRequest(http.MethodGet, "/v1/product/reviews", nil), token))
+		var resp struct {
+			Data []struct {
+				ProductRegistSeq int64  `json:"product_regist_seq"`
+				SerialNo         string `json:"serial_no"`
+				ReceiptURL       string `json:"receipt_url"`
+			} `json:"data"`
+		}
+		decodeBody(t, rec, &resp)
+		if rec.Code != http.StatusOK || len(resp.Data) != 1 || resp.Data[0].ProductRegistSeq != productRegistSeq || resp.Data[0].ReceiptURL == "" {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+		}
+
+		// 영수증 미리보기 링크는 브라우저에서 바로 표시한다.
+		rec = s.do(withToken(httptest.NewRequest(http.MethodGet, resp.Data[0].ReceiptURL, nil), token))
+		if rec.Code != http.StatusOK || rec.Body.String() != receipt {
+			t.Fatalf("preview: status = %d, body = %s", rec.Code, rec.Body)
+		}
+		if disposition := rec.Header().Get(echo.HeaderContentDisposition); !strings.HasPrefix(disposition, "inline") {
+			t.Fatalf("Content-Disposition = %q", disposition)
+		}
+	})
+
+	t.Run("approve", func(t *testing.T) {
+		target := fmt.Sprintf("/v1/product/reviews/%d/approve", productRegistSeq)
+		assertError(t, s.do(httptest.NewRequest(http.MethodPost, target, nil)), http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+
+		if rec := s.do(withToken(httptest.NewRequest(http.MethodPost, target, nil), token)); rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+		}
+		if infos := s.findProductManageInfos(t, token, "serial_no=SN-001"); len(infos) != 1 || infos[0].Status != model.ProductAuthStatusOK {
+			t.Fatalf("manage infos = %+v", infos)
+		}
+
+		// 인증 대기 상태만 승인할 수 있다.
+		assertError(t, s.do(withToken(httptest.NewRequest(http.MethodPost, target, nil), token)), http.StatusConflict, model.ResponseErrorCodeInvalidStatusTransition)
+	})
+
+	// 같은 고객이 다른 제품도 인증한다.
+	form := productRegistForm("SN-002")
+	form.Set("product_type", "1")
+	if rec := s.do(newMultipartRequest(t, http.MethodPost, "/v1/product-regist", form, formFile{field: "receipt", filename: "receipt.jpg", content: "second receipt"})); rec.Code != http.StatusOK {
+		t.Fatalf("regist SN-002: status = %d, body = %s", rec.Code, rec.Body)
+	}
+	customer := "name=" + url.QueryEscape("홍길동") + "&phone=01012345678"
+
+	t.Run("get auth products", func(t *testing.T) {
+		rec := s.do(httptest.NewRequest(http.MethodGet, "/v1/product-regist?"+customer, nil))
+
+		var resp struct {
+			Success bool `json:"success"`
+			Data    []struct {
+				ProductRegistSeq int64  `json:"product_regist_seq"`
+				SerialNo         string `json:"serial_no"`
+			} `json:"data"`
+		}
+		decodeBody(t, rec, &resp)
+		if !resp.Success || len(resp.Data) != 2 || resp.Data[0].SerialNo != "SN-002" || resp.Data[1].SerialNo != "SN-001" || resp.Data[1].ProductRegistSeq != productRegistSeq {
+			t.Fatalf("body = %s", rec.Body)
+		}
+	})
+
+	t.Run("get auth product by seq", func(t *testing.T) {
+		target := fmt.Sprintf("/v1/product-regist/%d?", productRegistSeq)
+		rec := s.do(httptest.NewRequest(http.MethodGet, target+customer, nil))
+
+		var resp struct {
+			Data struct {
+				SerialNo string `json:"serial_no"`
+			} `json:"data"`
+		}
+		decodeBody(t, rec, &resp)
+		if rec.Code != http.StatusOK || resp.Data.SerialNo != "SN-001" {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+		}
+
+		// 다른 고객의 인증 정보는 조회할 수 없다.
+		rec = s.do(httptest.NewRequest(http.MethodGet, target+"name="+url.QueryEscape("홍길동")+"&phone=01099999999", nil))
+		assertError(t, rec, http.StatusNotFound, model.ResponseErrorCodeProductAuthNotMatched)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		pending := s.findProductManageInfos(t, token, "serial_no=SN-002")
+		if len(pending) != 1 || pending[0].Status != model.ProductAuthStatusPending {
+			t.Fatalf("manage infos = %+v", pending)
+		}
+		target := fmt.Sprintf("/v1/product/reviews/%d/reject", pending[0].ProductRegistSeq)
+
+		assertError(t, s.do(withToken(newJSONRequest(http.MethodPost, target, `{}`), token)), http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+		if rec := s.do(withToken(newJSONRequest(http.MethodPost, target, `{"reason":"영수증의 제품명이 다릅니다."}`), token)); rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+		}
+
+		// 고객은 반려 사유를 확인할 수 있다.
+		rec := s.do(httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/product-regist/%d?%s", pending[0].ProductRegistSeq, customer), nil))
+		var resp struct {
+			Data struct {
+				Status       model.ProductAuthStatus `json:"status"`
+				RejectReason string                  `json:"reject_reason"`
+			} `json:"data"`
+		}
+		decodeBody(t, rec, &resp)
+		if rec.Code != http.StatusOK || resp.Data.Status != model.ProductAuthStatusRejected || resp.Data.RejectReason != "영수증의 제품명이 다릅니다." {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+		}
+	})
+
+	t.Run("download receipt", func(t *testing.T) {
+		target := fmt.Sprintf("/v1/product/receipt?product_regist_seq=%d", productRegistSeq)
+		rec := s.do(withToken(httptest.NewRequest(http.MethodGet, target, nil), token))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+		}
+		if rec.Body.String() != receipt {
+			t.Fatalf("body = %q, want %q", rec.Body, receipt)
+		}
+		if disposition := rec.Header().Get(echo.HeaderContentDisposition); !strings.HasPrefix(disposition, "attachment") {
+			t.Fatalf("Content-Disposition = %q", disposition)
+		}
+
+		entries, err := os.ReadDir(s.tempDir)
+		if err != nil {
+			t.Fatalf("failed to read temp dir: %+v", err)
+		}
+		if len(entries) != 0 {
+			t.Fatalf("temp files were not removed: %d", len(entries))
+		}
+	})
+
+	t.Run("download tampered receipt", func(t *testing.T) {
+		for _, key := range s.storage.Keys() {
+			body, _ := s.storage.Object(key)
+			s.storage.Put(key, append(body, '!'), time.Now())
+		}
+
+		target := fmt.Sprintf("/v1/product/receipt?product_regist_seq=%d", productRegistSeq)
+		rec := s.do(withToken(httptest.NewRequest(http.MethodGet, target, nil), token))
+
+		assertError(t, rec, http.StatusInternalServerError, model.ResponseErrorCodeDownloadFailed)
+	})
+
+	t.Run("mod auth product", func(t *testing.T) {
+		body := `{"name":"홍길순","phone":"01011112222","addr":"부산시","addr_detail":"303호","purchase_date":"2022-03-01T00:00:00Z"}`
+		target := fmt.Sprintf("/v1/product-regist/%d?", productRegistSeq)
+		assertError(t, s.do(newJSONRequest(http.MethodPut, target, body)), http.StatusNotFound, model.ResponseErrorCodeProductAuthNotMatched)
+
+		before := s.findProductManageInfos(t, token, "serial_no=SN-001")
+		if len(before) != 1 || before[0].Regdate == "" {
+			t.Fatalf("manage infos = %+v", before)
+		}
+
+		rec := s.do(newJSONRequest(http.MethodPut, target+"name="+url.QueryEscape("홍길동")+"&phone=010-1234-5678", body))
+
+		var resp model.Response
+		decodeBody(t, rec, &resp)
+		if !resp.Success {
+			t.Fatalf("body = %s", rec.Body)
+		}
+
+		infos := s.findProductManageInfos(t, token, "serial_no=SN-001")
+		if len(infos) != 1 || infos[0].Name != "홍길순" || infos[0].Phone != "01011112222" || infos[0].Regdate != before[0].Regdate || infos[0].Status != model.ProductAuthStatusOK {
+			t.Fatalf("manage infos = %+v", infos)
+		}
+
+		// 인증 완료 후 구매일을 바꾸면 다시 인증 대기 상태가 된다.
+		body = `{"name":"홍길순","phone":"01011112222","addr":"부산시","addr_detail":"303호","purchase_date":"2022-04-01T00:00:00Z"}`
+		if rec := s.do(newJSONRequest(http.MethodPut, target+"name="+url.QueryEscape("홍길순")+"&phone=01011112222", body)); rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+		}
+		infos = s.findProductManageInfos(t, token, "serial_no=SN-001")
+		if len(infos) != 1 || infos[0].PurchaseDate != "2022-04-01" || infos[0].Status != model.ProductAuthStatusPending {
+			t.Fatalf("manage infos = %+v", infos)
+		}
+	})
+
+	t.Run("cancel auth product", func(t *testing.T) {
+		// 변경된 고객 정보로 취소한다.
+		target := fmt.Sprintf("/v1/product-regist/%d/cancel?name=%s&phone=01011112222", productRegistSeq, url.QueryEscape("홍길순"))
+		rec := s.do(httptest.NewRequest(http.MethodPost, target, nil))
+
+		var resp model.Response
+		decodeBody(t, rec, &resp)
+		if !resp.Success {
+			t.Fatalf("body = %s", rec.Body)
+		}
+
+		rec = s.do(httptest.NewRequest(http.MethodPost, target, nil))
+		assertError(t, rec, http.StatusConflict, model.ResponseErrorCodeInvalidStatusTransition)
+
+		// 취소된 인증 정보는 변경할 수 없다.
+		body := `{"name":"홍길순","phone":"01011112222","addr":"부산시","addr_detail":"404호","purchase_date":"2022-04-01T00:00:00Z"}`
+		modTarget := fmt.Sprintf("/v1/product-regist/%d?name=%s&phone=01011112222", productRegistSeq, url.QueryEscape("홍길순"))
+		assertError(t, s.do(newJSONRequest(http.MethodPut, modTarget, body)), http.StatusConflict, model.ResponseErrorCodeInvalidStatusTransition)
+
+		if status, resp := s.registProduct(t, "SN-001", "new receipt"); status != http.StatusOK || !resp.Success {
+			t.Fatalf("regist after cancel: status = %d, resp = %+v", status, resp)
+		}
+	})
+
+	t.Run("cancel unknown product regist", func(t *testing.T) {
+		rec := s.do(httptest.NewRequest(http.MethodPost, "/v1/product-regist/999/cancel", nil))
+		assertError(t, rec, http.StatusNotFound, model.ResponseErrorCodeProductRegistNotExist)
+	})
+}
+
+func TestReceiptOCR(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signIn(t)
+
+	if success, _ := s.importProducts(t, token, "SN-001,0\nSN-002,0\n"); success != 2 {
+		t.Fatalf("import: success = %d, want 2", success)
+	}
+
+	// 테스트 서버의 OCR 은 영수증 파일 내용을 그대로 텍스트로 사용한다.
+	// 고객은 쿠팡, 2022-03-01 에 구매했다고 입력한다. ( productRegistForm )
+	matched := "쿠팡 주문 영수증\n상호: 쿠팡(주)\n주문일 2022-03-01\n합계 39,000원"
+	different := "네이버 스마트스토어\n주문일 2022.03.05\n결제금액 42,000원"
+	for serialNo, receipt := range map[string]string{"SN-001": matched, "SN-002": different} {
+		if status, resp := s.registProduct(t, serialNo, receipt); status != http.StatusOK || !resp.Success {
+			t.Fatalf("regist %s: status = %d, resp = %+v", serialNo, status, resp)
+		}
+	}
+
+	rec := s.do(withToken(httptest.NewRequest(http.MethodGet, "/v1/product/reviews", nil), token))
+	var resp struct {
+		Data []struct {
+			SerialNo   string `json:"serial_no"`
+			ReceiptOCR struct {
+				Provider      string             `json:"provider"`
+				PurchaseDates []string           `json:"purchase_dates"`
+				Merchants     []string           `json:"merchants"`
+				Amounts       []int64            `json:"amounts"`
+				MarketTypes   []model.MarketType `json:"market_types"`
+			} `json:"receipt_ocr"`
+			PurchaseDateMismatch bool `json:"purchase_date_mismatch"`
+			MarketTypeMismatch   bool `json:"market_type_mismatch"`
+		} `json:"data"`
+	}
+	decodeBody(t, rec, &resp)
+	if rec.Code != http.StatusOK || len(resp.Data) != 2 {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	for _, info := range resp.Data {
+		ocrResult := info.ReceiptOCR
+		switch info.SerialNo {
+		case "SN-001":
+			if ocrResult.Provider != ocr.PlainTextProviderName || len(ocrResult.PurchaseDates) != 1 || ocrResult.PurchaseDates[0] != "2022-03-01" ||
+				len(ocrResult.Merchants) != 1 || ocrResult.Merchants[0] != "쿠팡(주)" || len(ocrResult.Amounts) != 1 || ocrResult.Amounts[0] != 39000 ||
+				len(ocrResult.MarketTypes) != 1 || ocrResult.MarketTypes[0] != model.MarketTypeCoupang {
+				t.Errorf("SN-001 receipt_ocr = %+v", ocrResult)
+			}
+			if info.PurchaseDateMismatch || info.MarketTypeMismatch {
+				t.Errorf("SN-001 mismatch = %v, %v, want false", info.PurchaseDateMismatch, info.MarketTypeMismatch)
+			}
+		case "SN-002":
+			if !info.PurchaseDateMismatch || !info.MarketTypeMismatch {
+				t.Errorf("SN-002 mismatch = %v, %v, want true (receipt_ocr = %+v)", info.PurchaseDateMismatch, info.MarketTypeMismatch, ocrResult)
+			}
+		}
+	}
+}
+
+func TestSerialCheck(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signIn(t)
+
+	// 형식을 정하기 전에 등록된 시리얼
+	if success, _ := s.importProducts(t, token, "LEGACY01,0\n"); success != 1 {
+		t.Fatalf("import legacy: success = %d, want 1", success)
+	}
+
+	// 분유제조기 플러스( 0 )의 시리얼은 BM 으로 시작하는 8자리, 마지막 문자는 체크 디지트
+	rule := `{"name_ko": "버들맘마 분유제조기 플러스", "name_en": "Buddle Mamma Formula Maker Plus", "active": true,
+		"serial_prefix": "BM", "serial_length": 8, "serial_charset": "alphanumeric", "serial_check_digit": true}`
+	if rec := s.do(withToken(newJSONRequest(http.MethodPut, "/v1/product/models/0", rule), token)); rec.Code != http.StatusOK {
+		t.Fatalf("update rule: status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	// 형식이 맞지 않는 시리얼은 등록하지 않는다.
+	if success, failure := s.importProducts(t, token, "BM123454,0\nBM123455,0\nSN-001,1\n"); success != 2 || failure != 1 {
+		t.Fatalf("import: success = %d, failure = %d", success, failure)
+	}
+
+	type checkResult struct {
+		Valid      bool   `json:"valid"`
+		Registered bool   `json:"registered"`
+		Reason     string `json:"reason"`
+		Products   []struct {
+			ProductType model.ProductType `json:"product_type"`
+			ProductName string            `json:"product_name"`
+		} `json:"products"`
+	}
+	check := func(t *testing.T, path, lang string) checkResult {
+		t.Helper()
+
+		// 제품 인증 전에 호출하므로 토큰이 필요 없다.
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Language", lang)
+		rec := s.do(req)
+		var resp struct {
+			Data checkResult `json:"data"`
+		}
+		decodeBody(t, rec, &resp)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("check %s: status = %d, body = %s", path, rec.Code, rec.Body)
+		}
+		return resp.Data
+	}
+
+	t.Run("registered serial", func(t *testing.T) {
+		got := check(t, "/v1/product/serial/BM123454/check", "en")
+		if !got.Valid || !got.Registered || got.Reason != "" || len(got.Products) != 1 ||
+			got.Products[0].ProductType != model.ProductTypePowderMilkMaker || got.Products[0].ProductName != "Buddle Mamma Formula Maker Plus" {
+			t.Errorf("check = %+v", got)
+		}
+	})
+
+	t.Run("registered before the rule", func(t *testing.T) {
+		// 형식은 등록되지 않은 시리얼의 사유로만 사용한다.
+		got := check(t, "/v1/product/serial/LEGACY%2001/check", "en")
+		if !got.Valid || !got.Registered || got.Reason != "" || len(got.Products) != 1 || got.Products[0].ProductType != model.ProductTypePowderMilkMaker {
+			t.Errorf("check = %+v", got)
+		}
+	})
+
+	t.Run("typo", func(t *testing.T) {
+		got := check(t, "/v1/product/serial/BM123455/check", "en")
+		if got.Valid || got.Registered || got.Reason != "The serial number is incorrect. Please check it again." || len(got.Products) != 0 {
+			t.Errorf("check = %+v", got)
+		}
+	})
+
+	t.Run("models without rules", func(t *testing.T) {
+		// 형식이 정의되지 않은 모델은 모든 시리얼을 허용한다.
+		got := check(t, "/v1/product/serial/SN-002/check", "ko")
+		if !got.Valid || got.Registered || len(got.Products) != 3 {
+			t.Errorf("check = %+v", got)
+		}
+
+		got = check(t, "/v1/product/serial/SN-002/check?product_type=0", "ko")
+		if got.Valid || got.Reason != "시리얼 번호는 BM(으)로 시작해야 합니다." {
+			t.Errorf("check(product_type=0) = %+v", got)
+		}
+	})
+
+	t.Run("unknown product type", func(t *testing.T) {
+		rec := s.do(httptest.NewRequest(http.MethodGet, "/v1/product/serial/BM123454/check?product_type=9", nil))
+		assertError(t, rec, http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+	})
+
+	t.Run("registration", func(t *testing.T) {
+		status, resp := s.registProduct(t, "BM123455", "receipt image")
+		if status != http.StatusBadRequest || resp.ErrorCode != model.ResponseErrorCodeInvalidRequest ||
+			len(resp.Errors) != 1 || resp.Errors[0].Field != "serial_no" || resp.Errors[0].Rule != model.ValidationRuleFormat {
+			t.Fatalf("regist typo: status = %d, resp = %+v", status, resp)
+		}
+
+		if status, resp := s.registProduct(t, "BM123454", "receipt image"); status != http.StatusOK || !resp.Success {
+			t.Fatalf("regist: status = %d, resp = %+v", status, resp)
+		}
+
+		// 형식을 정하기 전에 등록된 제품도 인증할 수 있고, 공백은 무시한다.
+		if status, resp := s.registProduct(t, "LEGACY 01", "receipt image"); status != http.StatusOK || !resp.Success {
+			t.Fatalf("regist legacy: status = %d, resp = %+v", status, resp)
+		}
+	})
+}
+
+func TestRegistBySerial(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signIn(t)
+
+	if success, _ := s.importProducts(t, token, "SN-001,2\nSN-002,2\nSN-002,3\n"); success != 3 {
+		t.Fatalf("import: success = %d, want 3", success)
+	}
+
+	regist := func(t *testing.T, serialNo, productType string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		form := productRegistForm(serialNo)
+		form.Del("product_type")
+		if productType != "" {
+			form.Set("product_type", productType)
+		}
+		return s.do(newMultipartRequest(t, http.MethodPost, "/v1/product-regist", form, formFile{field: "receipt", filename: "receipt.jpg", content: "receipt image"}))
+	}
+
+	t.Run("product type from serial", func(t *testing.T) {
+		if rec := regist(t, "SN-001", ""); rec.Code != http.StatusOK {
+			t.Fatalf("regist: status = %d, body = %s", rec.Code, rec.Body)
+		}
+
+		target := "/v1/product-regist?name=" + url.QueryEscape("홍길동") + "&phone=01012345678"
+		var resp struct {
+			Data []struct {
+				SerialNo    string `json:"serial_no"`
+				ProductType string `json:"product_type"`
+			} `json:"data"`
+		}
+		decodeBody(t, s.do(httptest.NewRequest(http.MethodGet, target, nil)), &resp)
+		if len(resp.Data) != 1 || resp.Data[0].SerialNo != "SN-001" || resp.Data[0].ProductType != "버들아이 젖병세척기" {
+			t.Fatalf("registrations = %+v", resp.Data)
+		}
+	})
+
+	t.Run("serial collides across types", func(t *testing.T) {
+		rec := regist(t, "SN-002", "")
+		assertError(t, rec, http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+
+		var resp model.Response
+		decodeBody(t, rec, &resp)
+		if len(resp.Errors) != 1 || resp.Errors[0].Field != "product_type" || resp.Errors[0].Rule != model.ValidationRuleRequired {
+			t.Fatalf("errors = %+v", resp.Errors)
+		}
+
+		// 시리얼 확인으로 선택할 제품 종류를 알 수 있다.
+		var check struct {
+			Data struct {
+				Products []struct {
+					ProductType model.ProductType `json:"product_type"`
+				} `json:"products"`
+			} `json:"data"`
+		}
+		decodeBody(t, s.do(httptest.NewRequest(http.MethodGet, "/v1/product/serial/SN-002/check", nil)), &check)
+		if len(check.Data.Products) != 2 {
+			t.Fatalf("check = %+v", check.Data)
+		}
+
+		if rec := regist(t, "SN-002", "3"); rec.Code != http.StatusOK {
+			t.Fatalf("regist with product_type: status = %d, body = %s", rec.Code, rec.Body)
+		}
+	})
+
+	t.Run("unknown serial", func(t *testing.T) {
+		assertError(t, regist(t, "SN-404", ""), http.StatusNotFound, model.ResponseErrorCodeProductNotExist)
+		// 선택한 제품 종류와 시리얼 번호가 맞지 않는 경우
+		assertError(t, regist(t, "SN-001", "0"), http.StatusNotFound, model.ResponseErrorCodeProductNotExist)
+	})
+}
+
+func TestAfterServiceRoutes(t *testing.T) {
+	s := newTestServer(t)
+
+	files := []formFile{
+		{field: "file1", filename: "front.jpg", content: "front image"},
+		{field: "file3", filename: "back.jpg", content: "back image"},
+	}
+	rec := s.do(newMultipartRequest(t, http.MethodPost, "/v1/as", afterServiceForm(), files...))
+
+	var created model.Response
+	decodeBody(t, rec, &created)
+	if !created.Success {
+		t.Fatalf("create: body = %s", rec.Body)
+	}
+	if keys := s.storage.Keys(); len(keys) != len(files) {
+		t.Fatalf("stored objects = %v, want %d", keys, len(files))
+	}
+
+	t.Run("create requires params", func(t *testing.T) {
+		form := afterServiceForm()
+		form.Del("name")
+		form.Set("phone", "1234")
+		form.Set("email", "invalid")
+		rec := s.do(newMultipartRequest(t, http.MethodPost, "/v1/as", form))
+		assertError(t, rec, http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+
+		var resp model.Response
+		decodeBody(t, rec, &resp)
+		fields := make([]string, 0, len(resp.Errors))
+		for _, fe := range resp.Errors {
+			fields = append(fields, fe.Field+":"+fe.Rule)
+		}
+		if got, want := strings.Join(fields, ","), "name:required,phone:format,email:format"; got != want {
+			t.Fatalf("errors = %s, want %s", got, want)
+		}
+	})
+
+	query := "?name=" + url.QueryEscape("김철수") + "&phone=01098765432"
+
+	t.Run("find after service info", func(t *testing.T) {
+		rec := s.do(httptest.NewRequest(http.MethodGet, "/v1/as"+query, nil))
+
+		var resp model.Response
+		decodeBody(t, rec, &resp)
+		if !resp.Success || resp.Data == nil {
+			t.Fatalf("body = %s", rec.Body)
+		}
+	})
+
+	var afterServiceSeq int64
+	t.Run("find after service manager info", func(t *testing.T) {
+		rec := s.do(httptest.NewRequest(http.MethodGet, "/v1/as/manage"+query, nil))
+
+		var resp struct {
+			Success bool `json:"success"`
+			Data    []struct {
+				AfterServiceSeq int64                `json:"after_service_seq"`
+				WarrantyStatus  model.WarrantyStatus `json:"warranty_status"`
+			} `json:"data"`
+		}
+		decodeBody(t, rec, &resp)
+		if !resp.Success || len(resp.Data) != 1 {
+			t.Fatalf("body = %s", rec.Body)
+		}
+		// 2022-03-01 구매, 보증 기간 12개월
+		if resp.Data[0].WarrantyStatus != model.WarrantyStatusOut {
+			t.Fatalf("warranty_status = %d, want %d", resp.Data[0].WarrantyStatus, model.WarrantyStatusOut)
+		}
+		afterServiceSeq = resp.Data[0].AfterServiceSeq
+	})
+
+	t.Run("download file", func(t *testing.T) {
+		for idx, want := range map[int]string{1: "front image", 3: "back image"} {
+			target := fmt.Sprintf("/v1/as/file?after_service_seq=%d&file_idx=%d", afterServiceSeq, idx)
+			rec := s.do(httptest.NewRequest(http.MethodGet, target, nil))
+			if rec.Code != http.StatusOK || rec.Body.String() != want {
+				t.Errorf("file%d: status = %d, body = %q, want %q", idx, rec.Code, rec.Body, want)
+			}
+		}
+	})
+
+	t.Run("download missing file", func(t *testing.T) {
+		target := fmt.Sprintf("/v1/as/file?after_service_seq=%d&file_idx=2", afterServiceSeq)
+		assertError(t, s.do(httptest.NewRequest(http.MethodGet, target, nil)), http.StatusNotFound, model.ResponseErrorCodeFileNotFound)
+
+		target = fmt.Sprintf("/v1/as/file?after_service_seq=%d&file_idx=6", afterServiceSeq)
+		assertError(t, s.do(httptest.NewRequest(http.MethodGet, target, nil)), http.StatusBadRequest, model.ResponseErrorCodeInvalidFileIndex)
+	})
+}
+
+func TestAfterServiceWithRegisteredProduct(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signIn(t)
+
+	if success, _ := s.importProducts(t, token, "SN-001,0\n"); success != 1 {
+		t.Fatalf("import: success = %d, want 1", success)
+	}
+	if status, resp := s.registProduct(t, "SN-001", "receipt"); status != http.StatusOK || !resp.Success {
+		t.Fatalf("regist: status = %d, resp = %+v", status, resp)
+	}
+
+	// 시리얼 번호로 신청하면 주소, 구매 정보를 입력하지 않아도 된다.
+	form := url.Values{
+		"name":         {"홍길동"},
+		"phone":        {"010-1234-5678"},
+		"serial_no":    {"SN-001"},
+		"product_type": {"0"},
+		"contents":     {"전원이 켜지지 않습니다."},
+	}
+
+	t.Run("serial registered by someone else", func(t *testing.T) {
+		other := url.Values{}
+		for key, values := range form {
+			other[key] = values
+		}
+		other.Set("phone", "01099999999")
+		assertError(t, s.do(newMultipartRequest(t, http.MethodPost, "/v1/as", other)), http.StatusNotFound, model.ResponseErrorCodeProductAuthNotMatched)
+	})
+
+	t.Run("registration waiting for review", func(t *testing.T) {
+		assertError(t, s.do(newMultipartRequest(t, http.MethodPost, "/v1/as", form)), http.StatusConflict, model.ResponseErrorCodeProductRegistPending)
+	})
+
+	s.approveProduct(t, token, "SN-001")
+	if rec := s.do(newMultipartRequest(t, http.MethodPost, "/v1/as", form)); rec.Code != http.StatusOK {
+		t.Fatalf("create: status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	infos := s.findProductManageInfos(t, token, "serial_no=SN-001")
+	if len(infos) != 1 {
+		t.Fatalf("product manage infos = %+v", infos)
+	}
+	target := fmt.Sprintf("/v1/product/%d/as", infos[0].ProductSeq)
+
+	t.Run("history requires token", func(t *testing.T) {
+		assertError(t, s.do(httptest.NewRequest(http.MethodGet, target, nil)), http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+	})
+
+	t.Run("product after service history", func(t *testing.T) {
+		rec := s.do(withToken(httptest.NewRequest(http.MethodGet, target, nil), token))
+
+		var resp struct {
+			Data []struct {
+				SerialNo     string `json:"serial_no"`
+				Addr         string `json:"addr"`
+				MarketType   string `json:"market_type"`
+				PurchaseDate string `json:"purchase_date"`
+			} `json:"data"`
+		}
+		decodeBody(t, rec, &resp)
+		if rec.Code != http.StatusOK || len(resp.Data) != 1 {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+		}
+		// 주소, 구매 정보는 제품 인증 정보( productRegistForm )로 채운다.
+		if got := resp.Data[0]; got.SerialNo != "SN-001" || got.Addr != "서울시 강남구" || got.MarketType != "쿠팡" || got.PurchaseDate != "2022-03-01" {
+			t.Fatalf("after service = %+v", got)
+		}
+	})
+}
+
+func TestProductTransfer(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signIn(t)
+
+	if success, _ := s.importProducts(t, token, "SN-001,0\n"); success != 1 {
+		t.Fatalf("import: success = %d, want 1", success)
+	}
+	if status, resp := s.registProduct(t, "SN-001", "receipt"); status != http.StatusOK || !resp.Success {
+		t.Fatalf("regist: status = %d, resp = %+v", status, resp)
+	}
+	fromSeq := s.approveProduct(t, token, "SN-001")
+	owner := "name=" + url.QueryEscape("홍길동") + "&phone=01012345678"
+
+	startTransfer := func(t *testing.T, query string) string {
+		t.Helper()
+
+		rec := s.do(httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/product-regist/%d/transfer?%s", fromSeq, query), nil))
+		var resp struct {
+			Data struct {
+				TransferCode string `json:"transfer_code"`
+				ExpireDate   string `json:"expire_date"`
+			} `json:"data"`
+		}
+		decodeBody(t, rec, &resp)
+		if rec.Code != http.StatusOK || resp.Data.TransferCode == "" || resp.Data.ExpireDate == "" {
+			t.Fatalf("start transfer: status = %d, body = %s", rec.Code, rec.Body)
+		}
+		return resp.Data.TransferCode
+	}
+
+	// 시리얼 번호의 공백은 무시한다.
+	claimForm := func(code string) url.Values {
+		return url.Values{
+			"serial_no":     {" SN-0 01"},
+			"product_type":  {"0"},
+			"transfer_code": {code},
+			"name":          {"김철수"},
+			"phone":         {"01098765432"},
+			"addr":          {"부산시 해운대구"},
+			"addr_detail":   {"202호"},
+		}
+	}
+
+	t.Run("only the owner can start a transfer", func(t *testing.T) {
+		rec := s.do(httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/product-regist/%d/transfer?name=%s&phone=01099999999", fromSeq, url.QueryEscape("홍길동")), nil))
+		assertError(t, rec, http.StatusNotFound, model.ResponseErrorCodeProductAuthNotMatched)
+	})
+
+	t.Run("admin transfer requires token", func(t *testing.T) {
+		rec := s.do(newJSONRequest(http.MethodPost, "/v1/product/transfers", fmt.Sprintf(`{"product_regist_seq": %d}`, fromSeq)))
+		assertError(t, rec, http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+	})
+
+	t.Run("claim requires transfer code", func(t *testing.T) {
+		assertError(t, s.do(newMultipartRequest(t, http.MethodPost, "/v1/product-regist/transfer", claimForm(""))), http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+	})
+
+	// 새 이전 요청을 하면 이전 코드는 사용할 수 없다.
+	oldCode := startTransfer(t, owner)
+	code := startTransfer(t, owner)
+
+	t.Run("wrong or replaced code", func(t *testing.T) {
+		for _, c := range []string{"WRONG000", oldCode} {
+			assertError(t, s.do(newMultipartRequest(t, http.MethodPost, "/v1/product-regist/transfer", claimForm(c))), http.StatusNotFound, model.ResponseErrorCodeProductTransferNotExist)
+		}
+	})
+
+	t.Run("unknown serial", func(t *testing.T) {
+		form := claimForm(code)
+		form.Set("serial_no", "SN-999")
+		assertError(t, s.do(newMultipartRequest(t, http.MethodPost, "/v1/product-regist/transfer", form)), http.StatusNotFound, model.ResponseErrorCodeProductNotExist)
+	})
+
+	// 제품 종류를 선택하지 않으면 시리얼 번호로 찾는다.
+	form := claimForm(code)
+	form.Del("product_type")
+	if rec := s.do(newMultipartRequest(t, http.MethodPost, "/v1/product-regist/transfer", form)); rec.Code != http.StatusOK {
+		t.Fatalf("claim: status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	t.Run("code can be used once", func(t *testing.T) {
+		assertError(t, s.do(newMultipartRequest(t, http.MethodPost, "/v1/product-regist/transfer", claimForm(code))), http.StatusNotFound, model.ResponseErrorCodeProductTransferNotExist)
+	})
+
+	t.Run("previous registration is kept as transferred", func(t *testing.T) {
+		transferred := s.findProductManageInfos(t, token, fmt.Sprintf("serial_no=SN-001&status=%d", model.ProductAuthStatusTransferred))
+		if len(transferred) != 1 || transferred[0].ProductRegistSeq != fromSeq || transferred[0].Name != "홍길동" {
+			t.Fatalf("transferred = %+v", transferred)
+		}
+		active := s.findProductManageInfos(t, token, fmt.Sprintf("serial_no=SN-001&status=%d", model.ProductAuthStatusOK))
+		if len(active) != 1 || active[0].Name != "김철수" {
+			t.Fatalf("active = %+v", active)
+		}
+
+		// 이전된 인증 정보는 다시 이전하거나 취소할 수 없다.
+		rec := s.do(httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/product-regist/%d/transfer?%s", fromSeq, owner), nil))
+		assertError(t, rec, http.StatusConflict, model.ResponseErrorCodeInvalidStatusTransition)
+		rec = s.do(httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/product-regist/%d/cancel?%s", fromSeq, owner), nil))
+		assertError(t, rec, http.StatusConflict, model.ResponseErrorCodeInvalidStatusTransition)
+	})
+
+	t.Run("new owner registration", func(t *testing.T) {
+		rec := s.do(httptest.NewRequest(http.MethodGet, "/v1/product-regist?name="+url.QueryEscape("김철수")+"&phone=01098765432", nil))
+		var resp struct {
+			Data []struct {
+				SerialNo     string `json:"serial_no"`
+				PurchaseDate string `json:"purchase_date"`
+			} `json:"data"`
+		}
+		decodeBody(t, rec, &resp)
+		// 구매 정보는 양도인의 인증 정보를 사용한다.
+		if rec.Code != http.StatusOK || len(resp.Data) != 1 || resp.Data[0].SerialNo != "SN-001" || resp.Data[0].PurchaseDate != "2022-03-01" {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+		}
+
+		// 이전 전 소유자의 목록에는 더 이상 표시되지 않는다.
+		assertError(t, s.do(httptest.NewRequest(http.MethodGet, "/v1/product-regist?"+owner, nil)), http.StatusNotFound, model.ResponseErrorCodeProductAuthNotMatched)
+	})
+
+	t.Run("admin transfer", func(t *testing.T) {
+		active := s.findProductManageInfos(t, token, fmt.Sprintf("serial_no=SN-001&status=%d", model.ProductAuthStatusOK))
+		rec := s.do(withToken(newJSONRequest(http.MethodPost, "/v1/product/transfers", fmt.Sprintf(`{"product_regist_seq": %d}`, active[0].ProductRegistSeq)), token))
+
+		var resp struct {
+			Data struct {
+				TransferCode string `json:"transfer_code"`
+			} `json:"data"`
+		}
+		decodeBody(t, rec, &resp)
+		if rec.Code != http.StatusOK || resp.Data.TransferCode == "" {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+		}
+
+		// 인증을 취소하면 발급한 이전 코드는 사용할 수 없다.
+		newOwner := "name=" + url.QueryEscape("김철수") + "&phone=01098765432"
+		if rec := s.do(httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v1/product-regist/%d/cancel?%s", active[0].ProductRegistSeq, newOwner), nil)); rec.Code != http.StatusOK {
+			t.Fatalf("cancel: status = %d, body = %s", rec.Code, rec.Body)
+		}
+		form := claimForm(resp.Data.TransferCode)
+		form.Set("name", "이영희")
+		assertError(t, s.do(newMultipartRequest(t, http.MethodPost, "/v1/product-regist/transfer", form)), http.StatusNotFound, model.ResponseErrorCodeProductTransferNotExist)
+	})
+}
+
+func TestMetaRoutes(t *testing.T) {
+	s := newTestServer(t)
+
+	rec := s.do(httptest.NewRequest(http.MethodGet, "/v1/meta/error-codes", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	var resp struct {
+		Success bool `json:"success"`
+		Data    []struct {
+			Code    model.ResponseErrorCode `json:"code"`
+			Status  int                     `json:"status"`
+			Message string                  `json:"message"`
+		} `json:"data"`
+	}
+	decodeBody(t, rec, &resp)
+	if !resp.Success || len(resp.Data) != len(model.ErrorCodes()) {
+		t.Fatalf("body = %s", rec.Body)
+	}
+	for _, info := range resp.Data {
+		if info.Code == model.ResponseErrorCodeDuplProduct && (info.Status != http.StatusConflict || info.Message == "") {
+			t.Fatalf("%s = %+v", info.Code, info)
+		}
+	}
+}
+
+func TestAcceptLanguage(t *testing.T) {
+	s := newTestServer(t)
+
+	english := func(req *http.Request) *http.Request {
+		req.Header.Set("Accept-Language", "en-US,en;q=0.9,ko;q=0.8")
+		return req
+	}
+
+	t.Run("error message", func(t *testing.T) {
+		rec := s.do(english(newMultipartRequest(t, http.MethodPost, "/v1/product-regist", productRegistForm("NOT-EXIST"), formFile{field: "receipt", filename: "receipt.jpg", content: "receipt"})))
+		assertError(t, rec, http.StatusNotFound, model.ResponseErrorCodeProductNotExist)
+
+		var resp model.Response
+		decodeBody(t, rec, &resp)
+		if resp.Message != "The serial number is invalid." || rec.Header().Get("Content-Language") != "en" {
+			t.Fatalf("headers = %v, body = %s", rec.Header(), rec.Body)
+		}
+	})
+
+	t.Run("field errors", func(t *testing.T) {
+		form := afterServiceForm()
+		form.Del("name")
+		rec := s.do(english(newMultipartRequest(t, http.MethodPost, "/v1/as", form)))
+		assertError(t, rec, http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+
+		var resp model.Response
+		decodeBody(t, rec, &resp)
+		if len(resp.Errors) != 1 || resp.Errors[0].Message != "Please enter the name." {
+			t.Fatalf("body = %s", rec.Body)
+		}
+	})
+
+	t.Run("success message and labels", func(t *testing.T) {
+		if rec := s.do(newMultipartRequest(t, http.MethodPost, "/v1/as", afterServiceForm())); rec.Code != http.StatusOK {
+			t.Fatalf("create: status = %d, body = %s", rec.Code, rec.Body)
+		}
+
+		target := "/v1/as?name=" + url.QueryEscape("김철수") + "&phone=01098765432"
+		var resp struct {
+			Message string `json:"message"`
+			Data    []struct {
+				ProductType string `json:"product_type"`
+				MarketType  string `json:"market_type"`
+			} `json:"data"`
+		}
+
+		decodeBody(t, s.do(english(httptest.NewRequest(http.MethodGet, target, nil))), &resp)
+		if resp.Message != "Success." || len(resp.Data) != 1 || resp.Data[0].ProductType != "Buddle Kids Baby Bottle Washer" || resp.Data[0].MarketType != "Offline store" {
+			t.Fatalf("en: resp = %+v", resp)
+		}
+
+		decodeBody(t, s.do(httptest.NewRequest(http.MethodGet, target, nil)), &resp)
+		if resp.Message != "성공하였습니다." || resp.Data[0].ProductType != "버들아이 젖병세척기" || resp.Data[0].MarketType != "오프라인 매장" {
+			t.Fatalf("default: resp = %+v", resp)
+		}
+	})
+
+	t.Run("error code catalogue", func(t *testing.T) {
+		var resp struct {
+			Data []struct {
+				Code     model.ResponseErrorCode `json:"code"`
+				Message  string                  `json:"message"`
+				Messages map[string]string       `json:"messages"`
+			} `json:"data"`
+		}
+		decodeBody(t, s.do(english(httptest.NewRequest(http.MethodGet, "/v1/meta/error-codes", nil))), &resp)
+		for _, info := range resp.Data {
+			if info.Code == model.ResponseErrorCodeDuplProduct && (info.Message != info.Messages["en"] || info.Messages["ko"] != "이미 등록된 제품입니다.") {
+				t.Fatalf("%s = %+v", info.Code, info)
+			}
+		}
+	})
+}
+
+func TestProductModelRoutes(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signIn(t)
+
+	type productModel struct {
+		Code           model.ProductType `json:"code"`
+		Name           string            `json:"name"`
+		ModelNo        string            `json:"model_no"`
+		ReleaseDate    string            `json:"release_date"`
+		WarrantyMonths int               `json:"warranty_months"`
+		Active         bool              `json:"active"`
+	}
+	findModels := func(t *testing.T, req *http.Request) []productModel {
+		t.Helper()
+
+		rec := s.do(req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("find product models: status = %d, body = %s", rec.Code, rec.Body)
+		}
+		var resp struct {
+			Data []productModel `json:"data"`
+		}
+		decodeBody(t, rec, &resp)
+		return resp.Data
+	}
+
+	t.Run("public list", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/products/models", nil)
+		req.Header.Set("Accept-Language", "en")
+		models := findModels(t, req)
+		if len(models) != 4 || models[0].Name != "Buddle Mamma Formula Maker Plus" || !models[0].Active {
+			t.Fatalf("models = %+v", models)
+		}
+	})
+
+	t.Run("admin routes require token", func(t *testing.T) {
+		rec := s.do(newJSONRequest(http.MethodPost, "/v1/product/models", `{"code": 10}`))
+		assertError(t, rec, http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+	})
+
+	body := `{"code": 10, "name_ko": "버들 분유포트", "name_en": "Buddle Formula Kettle", "model_no": "BD-K100", "release_date": "2022-05-01T00:00:00Z", "warranty_months": 24, "active": true}`
+	if rec := s.do(withToken(newJSONRequest(http.MethodPost, "/v1/product/models", body), token)); rec.Code != http.StatusOK {
+		t.Fatalf("create: status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	t.Run("create validates fields", func(t *testing.T) {
+		rec := s.do(withToken(newJSONRequest(http.MethodPost, "/v1/product/models", `{"code": 11, "warranty_months": -1, "image_url": "ftp://images"}`), token))
+		assertError(t, rec, http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+
+		var resp model.Response
+		decodeBody(t, rec, &resp)
+		if len(resp.Errors) != 4 {
+			t.Fatalf("errors = %+v", resp.Errors)
+		}
+	})
+
+	t.Run("create duplicated code", func(t *testing.T) {
+		rec := s.do(withToken(newJSONRequest(http.MethodPost, "/v1/product/models", body), token))
+		assertError(t, rec, http.StatusConflict, model.ResponseErrorCodeDuplProductModel)
+	})
+
+	// 등록한 모델의 시리얼만 등록, 인증할 수 있다.
+	if success, failure := s.importProducts(t, token, "SN-K01,10\nSN-K02,11\n"); success != 1 || failure != 1 {
+		t.Fatalf("import: success = %d, failure = %d, want 1, 1", success, failure)
+	}
+
+	t.Run("regist with unknown product type", func(t *testing.T) {
+		form := productRegistForm("SN-K02")
+		form.Set("product_type", "11")
+		rec := s.do(newMultipartRequest(t, http.MethodPost, "/v1/product-regist", form, formFile{field: "receipt", filename: "receipt.jpg", content: "receipt"}))
+		assertError(t, rec, http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+	})
+
+	t.Run("regist and show model name", func(t *testing.T) {
+		form := productRegistForm("SN-K01")
+		form.Set("product_type", "10")
+		rec := s.do(newMultipartRequest(t, http.MethodPost, "/v1/product-regist", form, formFile{field: "receipt", filename: "receipt.jpg", content: "receipt"}))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("regist: status = %d, body = %s", rec.Code, rec.Body)
+		}
+
+		var resp struct {
+			Data []struct {
+				ProductType    string               `json:"product_type"`
+				WarrantyStart  string               `json:"warranty_start"`
+				WarrantyEnd    string               `json:"warranty_end"`
+				WarrantyStatus model.WarrantyStatus `json:"warranty_status"`
+			} `json:"data"`
+		}
+		decodeBody(t, s.do(httptest.NewRequest(http.MethodGet, "/v1/product-regist?name="+url.QueryEscape("홍길동")+"&phone=01012345678", nil)), &resp)
+		if len(resp.Data) != 1 || resp.Data[0].ProductType != "버들 분유포트" {
+			t.Fatalf("product auth infos = %+v", resp.Data)
+		}
+		// 2022-03-01 구매, 보증 기간 24개월
+		if info := resp.Data[0]; info.WarrantyStart != "2022-03-01" || info.WarrantyEnd != "2024-02-29" || info.WarrantyStatus != model.WarrantyStatusOut {
+			t.Fatalf("warranty = %+v", info)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		update := `{"name_ko": "버들 분유포트", "name_en": "Buddle Formula Kettle", "model_no": "BD-K200", "warranty_months": 12, "active": false}`
+		if rec := s.do(withToken(newJSONRequest(http.MethodPut, "/v1/product/models/10", update), token)); rec.Code != http.StatusOK {
+			t.Fatalf("update: status = %d, body = %s", rec.Code, rec.Body)
+		}
+		assertError(t, s.do(withToken(newJSONRequest(http.MethodPut, "/v1/product/models/99", update), token)), http.StatusNotFound, model.ResponseErrorCodeProductModelNotExist)
+
+		// 판매 중지된 모델은 공개 목록에서 제외되고 관리자 목록에서만 조회된다.
+		if models := findModels(t, httptest.NewRequest(http.MethodGet, "/v1/products/models", nil)); len(models) != 4 {
+			t.Fatalf("public models = %+v", models)
+		}
+		models := findModels(t, withToken(httptest.NewRequest(http.MethodGet, "/v1/product/models", nil), token))
+		if len(models) != 5 || models[4].ModelNo != "BD-K200" || models[4].Active || models[4].ReleaseDate != "" {
+			t.Fatalf("admin models = %+v", models)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		assertError(t, s.do(withToken(httptest.NewRequest(http.MethodDelete, "/v1/product/models/10", nil), token)), http.StatusConflict, model.ResponseErrorCodeProductModelInUse)
+
+		if rec := s.do(withToken(httptest.NewRequest(http.MethodDelete, "/v1/product/models/3", nil), token)); rec.Code != http.StatusOK {
+			t.Fatalf("delete: status = %d, body = %s", rec.Code, rec.Body)
+		}
+		assertError(t, s.do(withToken(httptest.NewRequest(http.MethodDelete, "/v1/product/models/3", nil), token)), http.StatusNotFound, model.ResponseErrorCodeProductModelNotExist)
+	})
+}
+
+func TestSalesChannelRoutes(t *testing.T) {
+	s := newTestServer(t)
+	token := s.signIn(t)
+
+	type salesChannel struct {
+		Code            model.MarketType `json:"code"`
+		Name            string           `json:"name"`
+		Active          bool             `json:"active"`
+		OrderNoRequired bool             `json:"order_no_required"`
+	}
+	findChannels := func(t *testing.T, req *http.Request) []salesChannel {
+		t.Helper()
+
+		rec := s.do(req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("find sales channels: status = %d, body = %s", rec.Code, rec.Body)
+		}
+		var resp struct {
+			Data []salesChannel `json:"data"`
+		}
+		decodeBody(t, rec, &resp)
+		return resp.Data
+	}
+
+	t.Run("public list", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/sales-channels", nil)
+		req.Header.Set("Accept-Language", "en")
+		channels := findChannels(t, req)
+		if len(channels) != 4 || channels[1].Name != "Coupang" || channels[1].OrderNoRequired {
+			t.Fatalf("channels = %+v", channels)
+		}
+	})
+
+	t.Run("admin routes require token", func(t *testing.T) {
+		rec := s.do(newJSONRequest(http.MethodPost, "/v1/sales-channel", `{"code": 10}`))
+		assertError(t, rec, http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+	})
+
+	body := `{"code": 10, "name_ko": "자사몰", "name_en": "Official store", "active": true, "order_no_required": true}`
+	if rec := s.do(withToken(newJSONRequest(http.MethodPost, "/v1/sales-channel", body), token)); rec.Code != http.StatusOK {
+		t.Fatalf("create: status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	t.Run("create duplicated code", func(t *testing.T) {
+		rec := s.do(withToken(newJSONRequest(http.MethodPost, "/v1/sales-channel", body), token))
+		assertError(t, rec, http.StatusConflict, model.ResponseErrorCodeDuplSalesChannel)
+	})
+
+	if success, failure := s.importProducts(t, token, "SN-C01,0\n"); success != 1 || failure != 0 {
+		t.Fatalf("import: success = %d, failure = %d, want 1, 0", success, failure)
+	}
+
+	t.Run("regist with unknown market type", func(t *testing.T) {
+		form := productRegistForm("SN-C01")
+		form.Set("market_type", "11")
+		rec := s.do(newMultipartRequest(t, http.MethodPost, "/v1/product-regist", form, formFile{field: "receipt", filename: "receipt.jpg", content: "receipt"}))
+		assertError(t, rec, http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+	})
+
+	t.Run("regist requires order number", func(t *testing.T) {
+		form := productRegistForm("SN-C01")
+		form.Set("market_type", "10")
+		rec := s.do(newMultipartRequest(t, http.MethodPost, "/v1/product-regist", form, formFile{field: "receipt", filename: "receipt.jpg", content: "receipt"}))
+		assertError(t, rec, http.StatusBadRequest, model.ResponseErrorCodeInvalidRequest)
+
+		var resp model.Response
+		decodeBody(t, rec, &resp)
+		if len(resp.Errors) != 1 || resp.Errors[0].Field != "order_no" {
+			t.Fatalf("errors = %+v", resp.Errors)
+		}
+
+		form.Set("order_no", "2022030112345")
+		rec = s.do(newMultipartRequest(t, http.MethodPost, "/v1/product-regist", form, formFile{field: "receipt", filename: "receipt.jpg", content: "receipt"}))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("regist: status = %d, body = %s", rec.Code, rec.Body)
+		}
+
+		var info struct {
+			Data []struct {
+				MarketType string `json:"market_type"`
+				OrderNo    string `json:"order_no"`
+			} `json:"data"`
+		}
+		decodeBody(t, s.do(httptest.NewRequest(http.MethodGet, "/v1/product-regist?name="+url.QueryEscape("홍길동")+"&phone=01012345678", nil)), &info)
+		if len(info.Data) != 1 || info.Data[0].MarketType != "자사몰" || info.Data[0].OrderNo != "2022030112345" {
+			t.Fatalf("product auth infos = %+v", info.Data)
+		}
+	})
+
+	t.Run("update and delete", func(t *testing.T) {
+		update := `{"name_ko": "자사몰", "name_en": "Official store", "active": false, "order_no_required": true}`
+		if rec := s.do(withToken(newJSONRequest(http.MethodPut, "/v1/sales-channel/10", update), token)); rec.Code != http.StatusOK {
+			t.Fatalf("update: status = %d, body = %s", rec.Code, rec.Body)
+		}
+		if channels := findChannels(t, httptest.NewRequest(http.MethodGet, "/v1/sales-channels", nil)); len(channels) != 4 {
+			t.Fatalf("public channels = %+v", channels)
+		}
+		if channels := findChannels(t, withToken(httptest.NewRequest(http.MethodGet, "/v1/sales-channel", nil), token)); len(channels) != 5 || channels[4].Active {
+			t.Fatalf("admin channels = %+v", channels)
+		}
+
+		assertError(t, s.do(withToken(httptest.NewRequest(http.MethodDelete, "/v1/sales-channel/10", nil), token)), http.StatusConflict, model.ResponseErrorCodeSalesChannelInUse)
+		if rec := s.do(withToken(httptest.NewRequest(http.MethodDelete, "/v1/sales-channel/3", nil), token)); rec.Code != http.StatusOK {
+			t.Fatalf("delete: status = %d, body = %s", rec.Code, rec.Body)
+		}
+		assertError(t, s.do(withToken(httptest.NewRequest(http.MethodDelete, "/v1/sales-channel/3", nil), token)), http.StatusNotFound, model.ResponseErrorCodeSalesChannelNotExist)
+	})
+}
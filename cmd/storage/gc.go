@@ -0,0 +1,99 @@
+package main
+
+import (
+	"buddle-server/internal/s3"
+	"context"
+	"flag"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// gcBatchSize 는 한번의 DB 조회에서 확인하는 객체 키의 개수
+const gcBatchSize = 500
+
+// gc 는 버킷의 객체 중 DB 에서 참조하지 않는 객체(영수증, A/S 첨부파일)를 찾아
+// 보고하거나 삭제한다. 업로드 직후 DB 저장 전의 객체를 지우지 않도록
+// grace 기간 보다 오래된 객체만 대상으로 한다.
+func gc(c context.Context, env *environment, args []string) error {
+	fs := flag.NewFlagSet("gc", flag.ExitOnError)
+	prefix := fs.String("prefix", "", "object key prefix to scan")
+	grace := fs.Duration("grace", 72*time.Hour, "only objects older than this are considered orphaned")
+	dryRun := fs.Bool("dry-run", true, "report orphaned objects without deleting them")
+	if err := fs.Parse(args); err != nil {
+		return errors.Wrap(err, "failed to parse flags")
+	}
+
+	list, err := env.fileBucket.List(c, *prefix)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list objects [ prefix = %s ]", *prefix)
+	}
+
+	threshold := time.Now().Add(-*grace)
+	candidates := make(s3.Objects, 0)
+	for _, obj := range list.Objects {
+		if obj.LastModified.Before(threshold) {
+			candidates = append(candidates, obj)
+		}
+	}
+
+	orphans, err := findOrphans(c, env, candidates)
+	if err != nil {
+		return errors.Wrap(err, "failed to find orphaned objects")
+	}
+
+	var orphanSize, deleted int64
+	for _, obj := range orphans {
+		orphanSize += obj.Size
+		logrus.Infof("orphaned object: key=%s, size=%d, lastModified=%s", obj.Key, obj.Size, obj.LastModified.Format(time.RFC3339))
+
+		if *dryRun {
+			continue
+		}
+		if err := env.fileBucket.Delete(c, obj.Key); err != nil {
+			logrus.Errorf("failed to delete orphaned object: key=%s err:%+v", obj.Key, err)
+			continue
+		}
+		deleted++
+	}
+
+	logrus.Infof("gc finished: bucket=%s, prefix=%q, scanned=%d, candidates=%d, orphans=%d (%d bytes), deleted=%d, dryRun=%t",
+		env.fileBucket.BucketName(), *prefix, list.ObjectCount, len(candidates), len(orphans), orphanSize, deleted, *dryRun)
+
+	return nil
+}
+
+func findOrphans(c context.Context, env *environment, objects s3.Objects) (s3.Objects, error) {
+	orphans := make(s3.Objects, 0)
+
+	for start := 0; start < len(objects); start += gcBatchSize {
+		end := start + gcBatchSize
+		if end > len(objects) {
+			end = len(objects)
+		}
+		batch := objects[start:end]
+		keys := batch.Keys()
+
+		receipts, err := env.repo.Product().FindReceiptLocations(c, keys)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find receipt locations")
+		}
+		files, err := env.repo.AfterService().FindFileLocations(c, keys)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find after service file locations")
+		}
+
+		referenced := make(map[string]struct{}, len(receipts)+len(files))
+		for _, key := range append(receipts, files...) {
+			referenced[key] = struct{}{}
+		}
+
+		for _, obj := range batch {
+			if _, ok := referenced[obj.Key]; !ok {
+				orphans = append(orphans, obj)
+			}
+		}
+	}
+
+	return orphans, nil
+}
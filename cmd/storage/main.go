@@ -0,0 +1,89 @@
+package main
+
+import (
+	"buddle-server/internal/app/api"
+	"buddle-server/internal/db"
+	"buddle-server/internal/log"
+	"buddle-server/internal/s3"
+	"buddle-server/repository"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"os"
+)
+
+var (
+	configPath = os.Getenv("BD_CONFIG")
+)
+
+type command func(c context.Context, env *environment, args []string) error
+
+var commands = map[string]command{
+	"gc":           gc,
+	"migrate-keys": migrateKeys,
+}
+
+// environment 는 저장소 관리 명령에서 공통으로 사용하는 DB, S3 연결 정보
+type environment struct {
+	repo       repository.Repository
+	fileBucket s3.Storage
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	c, env, err := newEnvironment()
+	if err != nil {
+		logrus.Fatalf("Create environment: %v", err)
+	}
+
+	if err := cmd(c, env, os.Args[2:]); err != nil {
+		logrus.Fatalf("Run %s: %+v", os.Args[1], err)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s <command> [flags]\n\ncommands:\n", os.Args[0])
+	fmt.Fprintln(os.Stderr, "  gc              report or delete storage objects not referenced by the database")
+	fmt.Fprintln(os.Stderr, "  migrate-keys    move legacy date/phone based objects to opaque keys")
+}
+
+func newEnvironment() (context.Context, *environment, error) {
+	if err := api.InitConfig(configPath); err != nil {
+		return nil, nil, errors.Wrapf(err, "Load config file path = %s", configPath)
+	}
+
+	conf := api.Config()
+	if err := log.Init(conf.Logger); err != nil {
+		return nil, nil, errors.Wrap(err, "Init logger")
+	}
+
+	conn, err := db.Connect(conf.DB)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Init db")
+	}
+
+	fileBucket, err := s3.NewStorage(conf.FileBucket)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Init file bucket")
+	}
+
+	repo, err := repository.NewRepository()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Init repository")
+	}
+
+	c := db.ContextWithConn(context.Background(), db.WriteDBKey, conn)
+
+	return c, &environment{repo: repo, fileBucket: fileBucket}, nil
+}
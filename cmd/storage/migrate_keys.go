@@ -0,0 +1,150 @@
+package main
+
+import (
+	"buddle-server/internal/s3"
+	"buddle-server/service"
+	"context"
+	"flag"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const migrateKeysBatchSize = 100
+
+type migrateKeysResult struct {
+	migrated int
+	failed   int
+}
+
+// migrateKeys 는 "<날짜>/<제품번호>", "after-service/<날짜>/<전화번호>/fileN" 형식의
+// 기존 객체를 추측할 수 없는 키로 복사하고 DB 의 위치 정보를 변경한 뒤 기존 객체를 삭제한다.
+// DB 변경 전까지는 기존 객체가 남아있으므로 마이그레이션 중에도 다운로드가 가능하다.
+// 기존 키는 여러 행이 공유할 수 있으므로 마지막으로 참조하던 행이 변경된 후에 삭제한다.
+func migrateKeys(c context.Context, env *environment, args []string) error {
+	fs := flag.NewFlagSet("migrate-keys", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", true, "report objects to migrate without changing them")
+	if err := fs.Parse(args); err != nil {
+		return errors.Wrap(err, "failed to parse flags")
+	}
+
+	receipts, err := migrateReceiptKeys(c, env, *dryRun)
+	if err != nil {
+		return errors.Wrap(err, "failed to migrate receipt keys")
+	}
+
+	files, err := migrateAfterServiceFileKeys(c, env, *dryRun)
+	if err != nil {
+		return errors.Wrap(err, "failed to migrate after service file keys")
+	}
+
+	logrus.Infof("migrate-keys finished: receipts(migrated=%d, failed=%d), afterServiceFiles(migrated=%d, failed=%d), dryRun=%t",
+		receipts.migrated, receipts.failed, files.migrated, files.failed, *dryRun)
+
+	return nil
+}
+
+func migrateReceiptKeys(c context.Context, env *environment, dryRun bool) (migrateKeysResult, error) {
+	var result migrateKeysResult
+	var lastSeq int64
+	for {
+		regists, err := env.repo.Product().FindLegacyReceiptProductRegists(c, service.ReceiptKeyPrefix, lastSeq, migrateKeysBatchSize)
+		if err != nil {
+			return result, errors.Wrap(err, "failed to find legacy receipts")
+		}
+		if len(regists) == 0 {
+			return result, nil
+		}
+
+		for _, regist := range regists {
+			lastSeq = regist.ProductRegistSeq
+			oldKey := regist.ReceiptS3Location
+
+			if err := migrateKey(c, env, service.ReceiptKeyPrefix, oldKey, dryRun, func(newKey string) error {
+				return env.repo.Product().UpdateReceiptLocation(c, regist.ProductRegistSeq, oldKey, newKey)
+			}); err != nil {
+				logrus.Errorf("failed to migrate receipt key: product_regist_seq=%d, key=%s err:%+v", regist.ProductRegistSeq, oldKey, err)
+				result.failed++
+				continue
+			}
+			result.migrated++
+		}
+	}
+}
+
+func migrateAfterServiceFileKeys(c context.Context, env *environment, dryRun bool) (migrateKeysResult, error) {
+	var result migrateKeysResult
+	var lastSeq int64
+	for {
+		afterServices, err := env.repo.AfterService().FindLegacyFileAfterServices(c, service.AfterServiceFileKeyPrefix, lastSeq, migrateKeysBatchSize)
+		if err != nil {
+			return result, errors.Wrap(err, "failed to find legacy after service files")
+		}
+		if len(afterServices) == 0 {
+			return result, nil
+		}
+
+		for _, as := range afterServices {
+			lastSeq = as.AfterServiceSeq
+
+			for i, location := range as.FileS3Locations() {
+				oldKey, fileIdx := *location, i+1
+				if oldKey == "" || s3.HasPrefix(oldKey, service.AfterServiceFileKeyPrefix) {
+					continue
+				}
+
+				if err := migrateKey(c, env, service.AfterServiceFileKeyPrefix, oldKey, dryRun, func(newKey string) error {
+					return env.repo.AfterService().UpdateFileLocation(c, as.AfterServiceSeq, fileIdx, oldKey, newKey)
+				}); err != nil {
+					logrus.Errorf("failed to migrate after service file key: after_service_seq=%d, file%d, key=%s err:%+v", as.AfterServiceSeq, fileIdx, oldKey, err)
+					result.failed++
+					continue
+				}
+				result.migrated++
+			}
+		}
+	}
+}
+
+// migrateKey 는 oldKey 객체를 prefix 하위의 새 키로 복사하고 update 로 DB 를 변경한 뒤,
+// oldKey 를 참조하는 행이 더 이상 없으면 oldKey 객체를 삭제한다.
+func migrateKey(c context.Context, env *environment, prefix, oldKey string, dryRun bool, update func(newKey string) error) error {
+	if dryRun {
+		logrus.Infof("object to migrate: key=%s", oldKey)
+		return nil
+	}
+
+	newKey, err := s3.NewObjectKey(prefix)
+	if err != nil {
+		return errors.Wrap(err, "failed to create object key")
+	}
+
+	if err := env.fileBucket.Copy(c, oldKey, newKey, map[string]string{s3.MetadataLegacyKey: oldKey}); err != nil {
+		return errors.Wrapf(err, "failed to copy object [ newKey = %s ]", newKey)
+	}
+
+	if err := update(newKey); err != nil {
+		if err := env.fileBucket.Delete(c, newKey); err != nil {
+			logrus.Errorf("failed to delete copied object: key=%s err:%+v", newKey, err)
+		}
+		return errors.Wrap(err, "failed to update object location")
+	}
+
+	logrus.Infof("object migrated: %s -> %s", oldKey, newKey)
+
+	// 같은 키를 참조하는 다른 행( 같은 날짜의 같은 제품, 전화번호 )이 남아 있으면 해당 행의 마이그레이션에서 삭제한다.
+	// 확인, 삭제에 실패해도 DB 는 이미 새 키를 가리키므로 기존 객체는 gc 명령으로 정리된다.
+	orphans, err := findOrphans(c, env, s3.Objects{{Key: oldKey}})
+	if err != nil {
+		logrus.Errorf("failed to check legacy object references: key=%s err:%+v", oldKey, err)
+		return nil
+	}
+	if len(orphans) == 0 {
+		logrus.Infof("legacy object is still referenced: key=%s", oldKey)
+		return nil
+	}
+	if err := env.fileBucket.Delete(c, oldKey); err != nil {
+		logrus.Errorf("failed to delete legacy object: key=%s err:%+v", oldKey, err)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"buddle-server/internal/i18n"
 	"buddle-server/middleware"
 	"buddle-server/model"
 	"buddle-server/service"
@@ -8,7 +9,6 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"mime/multipart"
 	"net/http"
 	"os"
 )
@@ -17,20 +17,26 @@ type AfterServiceHandler interface {
 	Create(c echo.Context) error                      // A/S 신청
 	FindAfterServiceInfo(c echo.Context) error        // A/S 신청정보 조회
 	FindAfterServiceManagerInfo(c echo.Context) error // A/S 신청정보 조회 ( 관리자용 )
+	FindProductAfterServices(c echo.Context) error    // 제품의 A/S 이력 조회 ( 관리자용 )
 	DownloadFile(c echo.Context) error                // 첨부파일 다운로드
 }
 
 type afterServiceHandler struct {
 	afterService service.AfterService
+	tempDir      string // 다운로드 임시 파일 디렉터리
 }
 
-func NewAfterHandler(afterService service.AfterService) (AfterServiceHandler, error) {
-	if afterService == nil {
+func NewAfterHandler(afterService service.AfterService, tempDir string) (AfterServiceHandler, error) {
+	switch {
+	case afterService == nil:
 		return nil, errors.New("after service is nil")
+	case tempDir == "":
+		return nil, errors.New("empty temp dir")
 	}
 
 	return &afterServiceHandler{
 		afterService: afterService,
+		tempDir:      tempDir,
 	}, nil
 }
 
@@ -42,95 +48,33 @@ func (h afterServiceHandler) Create(c echo.Context) error {
 
 	afterService := new(model.AfterService)
 	if err := ctx.Bind(afterService); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{
-			Message: "failed to bind request parameter",
-		})
+		return invalidRequest(err)
 	}
 
 	if err := afterService.Validate(); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{
-			Message: "request params were invalid",
-		})
+		return invalidRequest(err)
 	}
 
-	var src, src2, src3, src4, src5 multipart.File
-
-	file1, err := c.FormFile("file1")
-	if err != nil && !errors.Is(err, http.ErrMissingFile) {
-		return c.JSON(http.StatusInternalServerError, model.Response{
-			Message: fmt.Sprintf("failed to get receipt param err : %+v", err),
-		})
-	}
-	if file1 != nil {
-		if src, err = file1.Open(); err != nil {
-			return c.JSON(http.StatusInternalServerError, model.Response{
-				Message: "failed to open upload receipt file",
-			})
-		}
-		defer src.Close()
-	}
-
-	file2, err := c.FormFile("file2")
-	if err != nil && !errors.Is(err, http.ErrMissingFile) {
-		return c.JSON(http.StatusInternalServerError, model.Response{
-			Message: fmt.Sprintf("failed to get receipt param err : %+v", err),
-		})
-	}
-	if file2 != nil {
-		if src2, err = file2.Open(); err != nil {
-			return c.JSON(http.StatusInternalServerError, model.Response{
-				Message: "failed to open upload receipt file",
-			})
+	files := make([]*service.UploadFile, model.AfterServiceMaxFiles)
+	for i := range files {
+		fileHeader, err := c.FormFile(fmt.Sprintf("file%d", i+1))
+		if err != nil {
+			if errors.Is(err, http.ErrMissingFile) {
+				continue
+			}
+			return invalidRequest(err)
 		}
-		defer src2.Close()
-	}
 
-	file3, err := c.FormFile("file3")
-	if err != nil && !errors.Is(err, http.ErrMissingFile) {
-		return c.JSON(http.StatusInternalServerError, model.Response{
-			Message: fmt.Sprintf("failed to get receipt param err : %+v", err),
-		})
-	}
-	if file3 != nil {
-		if src3, err = file3.Open(); err != nil && !errors.Is(err, http.ErrMissingFile) {
-			return c.JSON(http.StatusInternalServerError, model.Response{
-				Message: "failed to open upload receipt file",
-			})
+		src, err := fileHeader.Open()
+		if err != nil {
+			return errors.Wrapf(err, "failed to open upload file%d", i+1)
 		}
-		defer src3.Close()
-	}
+		defer src.Close()
 
-	file4, err := c.FormFile("file4")
-	if err != nil && !errors.Is(err, http.ErrMissingFile) {
-		return c.JSON(http.StatusInternalServerError, model.Response{
-			Message: fmt.Sprintf("failed to get receipt param err : %+v", err),
-		})
-	}
-	if file4 != nil {
-		if src4, err = file4.Open(); err != nil {
-			return c.JSON(http.StatusInternalServerError, model.Response{
-				Message: "failed to open upload receipt file",
-			})
-		}
-		defer src4.Close()
+		files[i] = &service.UploadFile{Filename: fileHeader.Filename, Body: src}
 	}
 
-	file5, err := c.FormFile("file5")
-	if err != nil && !errors.Is(err, http.ErrMissingFile) {
-		return c.JSON(http.StatusInternalServerError, model.Response{
-			Message: fmt.Sprintf("failed to get receipt param err : %+v", err),
-		})
-	}
-	if file5 != nil {
-		if src5, err = file5.Open(); err != nil {
-			return c.JSON(http.StatusInternalServerError, model.Response{
-				Message: "failed to open upload receipt file",
-			})
-		}
-		defer src5.Close()
-	}
-
-	resp, err := h.afterService.Create(ctx.GoContext(), afterService, src, src2, src3, src4, src5)
+	resp, err := h.afterService.Create(ctx.GoContext(), afterService, files)
 	if err != nil {
 		return errors.Wrapf(err, "failed to auth product [ req = %+v ]", *afterService)
 	}
@@ -150,16 +94,12 @@ func (h afterServiceHandler) DownloadFile(c echo.Context) error {
 	}
 
 	if err := ctx.Bind(&req); err != nil {
-		return errors.Wrap(err, "failed to bind request parameter")
+		return invalidRequest(err)
 	}
 
-	tmpFile, err := os.CreateTemp("/root/.buddle", "*")
+	tmpFile, err := os.CreateTemp(h.tempDir, "*")
 	if err != nil {
-		logrus.Errorf("failed to create temp file err:%+v", err)
-		return c.JSON(http.StatusOK, model.Response{
-			Success: false,
-			Message: "임시 파일 생성 실패",
-		})
+		return errors.Wrap(err, "failed to create temp file")
 	}
 	defer func() {
 		if err := tmpFile.Close(); err != nil {
@@ -172,20 +112,10 @@ func (h afterServiceHandler) DownloadFile(c echo.Context) error {
 
 	afterServiceInfo, err := h.afterService.DownloadFile(ctx.GoContext(), req.AfterServiceSeq, req.FileIdx, tmpFile)
 	if err != nil {
-		logrus.Errorf("failed to download from s3 err:%+v", err)
-		return c.JSON(http.StatusOK, model.Response{
-			Success: false,
-			Message: "파일 다운로드 실패",
-		})
-	}
-	if afterServiceInfo == nil {
-		return c.JSON(http.StatusOK, model.Response{
-			Success: false,
-			Message: "다운로드 받을 파일이 존재하지 않습니다",
-		})
+		return errors.Wrapf(err, "failed to download after service file [ after_service_seq = %d, file_idx = %d ]", req.AfterServiceSeq, req.FileIdx)
 	}
 
-	return c.Attachment(tmpFile.Name(), fmt.Sprintf("%s(%s) 첨부파일(%d)", afterServiceInfo.Name, afterServiceInfo.Phone, req.FileIdx))
+	return c.Attachment(tmpFile.Name(), model.AfterServiceFilename(afterServiceInfo.Name, afterServiceInfo.Phone, req.FileIdx, i18n.FromContext(ctx.GoContext())))
 }
 
 func (h afterServiceHandler) FindAfterServiceInfo(c echo.Context) error {
@@ -196,7 +126,7 @@ func (h afterServiceHandler) FindAfterServiceInfo(c echo.Context) error {
 
 	req := new(model.AfterServiceRequest)
 	if err := ctx.Bind(req); err != nil {
-		return errors.Wrap(err, "failed to bind request parameter")
+		return invalidRequest(err)
 	}
 
 	data, err := h.afterService.FindAfterServiceInfo(ctx.GoContext(), *req)
@@ -215,7 +145,7 @@ func (h afterServiceHandler) FindAfterServiceManagerInfo(c echo.Context) error {
 
 	req := new(model.AfterServiceRequest)
 	if err := ctx.Bind(req); err != nil {
-		return errors.Wrap(err, "failed to bind request parameter")
+		return invalidRequest(err)
 	}
 
 	data, err := h.afterService.FindAfterServiceManagerInfo(ctx.GoContext(), *req)
@@ -225,3 +155,22 @@ func (h afterServiceHandler) FindAfterServiceManagerInfo(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, data)
 }
+
+func (h afterServiceHandler) FindProductAfterServices(c echo.Context) error {
+	ctx, err := middleware.UpgradeContext(c)
+	if err != nil {
+		return errors.Wrap(err, "upgrade context")
+	}
+
+	var productSeq int64
+	if err := echo.PathParamsBinder(ctx).MustInt64("product_seq", &productSeq).BindError(); err != nil {
+		return invalidRequest(err)
+	}
+
+	data, err := h.afterService.FindProductAfterServices(ctx.GoContext(), productSeq)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find after services of product(%d)", productSeq)
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"buddle-server/middleware"
+	"buddle-server/model"
+	"buddle-server/service"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+// catalogHandler 는 카탈로그( 제품 모델, 판매 채널 ) 핸들러의 공통 등록, 수정, 삭제.
+type catalogHandler struct {
+	name     string // 에러 메시지에 사용하는 이름 ( product model )
+	service  service.CatalogService
+	newEntry func() model.CatalogEntry // 요청을 바인딩할 빈 카탈로그 행
+}
+
+func (h catalogHandler) create(c echo.Context) error {
+	ctx, err := middleware.UpgradeContext(c)
+	if err != nil {
+		return errors.Wrap(err, "upgrade context")
+	}
+
+	entry := h.newEntry()
+	if err := ctx.Bind(entry); err != nil {
+		return invalidRequest(err)
+	}
+
+	if err := entry.Validate(); err != nil {
+		return invalidRequest(err)
+	}
+
+	if err := h.service.Create(ctx.GoContext(), entry); err != nil {
+		return errors.Wrapf(err, "failed to create %s [ req = %+v ]", h.name, entry)
+	}
+
+	return c.JSON(http.StatusOK, model.SimpleSuccess())
+}
+
+func (h catalogHandler) update(c echo.Context) error {
+	ctx, err := middleware.UpgradeContext(c)
+	if err != nil {
+		return errors.Wrap(err, "upgrade context")
+	}
+
+	code, err := catalogCode(ctx)
+	if err != nil {
+		return invalidRequest(err)
+	}
+
+	entry := h.newEntry()
+	if err := ctx.Bind(entry); err != nil {
+		return invalidRequest(err)
+	}
+	entry.SetCatalogCode(code)
+
+	if err := entry.Validate(); err != nil {
+		return invalidRequest(err)
+	}
+
+	if err := h.service.Update(ctx.GoContext(), entry); err != nil {
+		return errors.Wrapf(err, "failed to update %s [ code = %d ]", h.name, code)
+	}
+
+	return c.JSON(http.StatusOK, model.NewSuccess(model.ResponseMessageModified, nil))
+}
+
+func (h catalogHandler) delete(c echo.Context) error {
+	ctx, err := middleware.UpgradeContext(c)
+	if err != nil {
+		return errors.Wrap(err, "upgrade context")
+	}
+
+	code, err := catalogCode(ctx)
+	if err != nil {
+		return invalidRequest(err)
+	}
+
+	if err := h.service.Delete(ctx.GoContext(), code); err != nil {
+		return errors.Wrapf(err, "failed to delete %s [ code = %d ]", h.name, code)
+	}
+
+	return c.JSON(http.StatusOK, model.SimpleSuccess())
+}
+
+// catalogCode 는 경로의 code 를 읽는다.
+func catalogCode(c echo.Context) (int, error) {
+	var code int
+	err := echo.PathParamsBinder(c).MustInt("code", &code).BindError()
+
+	return code, err
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"buddle-server/internal/i18n"
+	"buddle-server/model"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// ErrorHandler 는 핸들러가 반환한 에러를 HTTP 상태 코드와 model.Response 로 변환한다. ( echo.HTTPErrorHandler )
+//   - model.Error : 에러 종류에 따른 상태 코드, 에러 코드와 메시지
+//   - echo.HTTPError : 상태 코드 그대로 ( 라우팅, JWT 미들웨어 등 )
+//   - 그 외 : 500, 내부 에러 내용은 로그로만 남긴다.
+func ErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status, resp := errorResponse(err)
+	if status >= http.StatusInternalServerError {
+		logrus.Errorf("%s %s failed [ status = %d ] err:%+v", c.Request().Method, c.Request().URL.Path, status, err)
+	} else {
+		logrus.Debugf("%s %s failed [ status = %d ] err:%v", c.Request().Method, c.Request().URL.Path, status, err)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = c.JSON(status, resp)
+	}
+	if err != nil {
+		logrus.Errorf("failed to send error response: %+v", err)
+	}
+}
+
+func errorResponse(err error) (int, *model.Response) {
+	var domainErr *model.Error
+	if errors.As(err, &domainErr) {
+		return statusOf(domainErr.Kind()), &model.Response{
+			Message:   domainErr.Message(i18n.DefaultLanguage),
+			ErrorCode: domainErr.ErrorCode,
+			Errors:    domainErr.Fields,
+		}
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		resp := &model.Response{ErrorCode: errorCodeOf(httpErr.Code)}
+		if httpErr.Code < http.StatusInternalServerError {
+			resp.Message = fmt.Sprint(httpErr.Message)
+		}
+		return httpErr.Code, resp
+	}
+
+	return http.StatusInternalServerError, model.SimpleFail()
+}
+
+// statusOf 는 도메인 에러 종류의 HTTP 상태 코드
+func statusOf(kind error) int {
+	switch kind {
+	case model.ErrNotFound:
+		return http.StatusNotFound
+	case model.ErrConflict:
+		return http.StatusConflict
+	case model.ErrValidation:
+		return http.StatusBadRequest
+	case model.ErrUnauthorized:
+		return http.StatusUnauthorized
+	case model.ErrForbidden:
+		return http.StatusForbidden
+	}
+
+	return http.StatusInternalServerError
+}
+
+// errorCodeOf 는 HTTP 상태 코드의 기본 에러 코드
+func errorCodeOf(status int) model.ResponseErrorCode {
+	switch status {
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return model.ResponseErrorCodeNotFound
+	case http.StatusConflict:
+		return model.ResponseErrorCodeConflict
+	case http.StatusUnauthorized:
+		return model.ResponseErrorCodeUnauthorized
+	case http.StatusForbidden:
+		return model.ResponseErrorCodeForbidden
+	}
+
+	if status < http.StatusInternalServerError {
+		return model.ResponseErrorCodeInvalidRequest
+	}
+	return model.ResponseErrorCodeInternal
+}
+
+// invalidRequest 는 요청 파라미터 바인딩, 검증 실패 에러. cause 가 model.FieldErrors 이면 응답에 포함한다.
+func invalidRequest(cause error) error {
+	var fields model.FieldErrors
+	if errors.As(cause, &fields) {
+		return model.NewFieldValidationError(fields).WithCause(cause)
+	}
+	return model.NewError(model.ResponseErrorCodeInvalidRequest).WithCause(cause)
+}
@@ -0,0 +1,50 @@
+package handler_test
+
+import (
+	"buddle-server/handler"
+	"buddle-server/internal/i18n"
+	"buddle-server/model"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    model.ResponseErrorCode
+		message string
+	}{
+		{"not found", model.NewError(model.ResponseErrorCodeProductNotExist), http.StatusNotFound, model.ResponseErrorCodeProductNotExist, model.ResponseErrorCodeProductNotExist.Message(i18n.DefaultLanguage)},
+		{"conflict", model.NewError(model.ResponseErrorCodeDuplProduct), http.StatusConflict, model.ResponseErrorCodeDuplProduct, model.ResponseErrorCodeDuplProduct.Message(i18n.DefaultLanguage)},
+		{"validation", model.NewError(model.ResponseErrorCodeInvalidFileIndex), http.StatusBadRequest, model.ResponseErrorCodeInvalidFileIndex, model.ResponseErrorCodeInvalidFileIndex.Message(i18n.DefaultLanguage)},
+		{"unauthorized", model.NewError(model.ResponseErrorCodeInvalidUserPwd), http.StatusUnauthorized, model.ResponseErrorCodeInvalidUserPwd, model.ResponseErrorCodeInvalidUserPwd.Message(i18n.DefaultLanguage)},
+		{"forbidden", model.NewError(model.ResponseErrorCodeForbidden), http.StatusForbidden, model.ResponseErrorCodeForbidden, model.ResponseErrorCodeForbidden.Message(i18n.DefaultLanguage)},
+		{"internal with code", model.NewError(model.ResponseErrorCodeUploadFailed).WithCause(errors.New("s3 timeout")), http.StatusInternalServerError, model.ResponseErrorCodeUploadFailed, model.ResponseErrorCodeUploadFailed.Message(i18n.DefaultLanguage)},
+		{"wrapped domain error", errors.Wrap(model.NewError(model.ResponseErrorCodeFileNotFound).WithCause(errors.New("cause")), "handler"), http.StatusNotFound, model.ResponseErrorCodeFileNotFound, model.ResponseErrorCodeFileNotFound.Message(i18n.DefaultLanguage)},
+		{"echo http error", echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired jwt"), http.StatusUnauthorized, model.ResponseErrorCodeUnauthorized, "invalid or expired jwt"},
+		{"route not found", echo.ErrNotFound, http.StatusNotFound, model.ResponseErrorCodeNotFound, "Not Found"},
+		{"internal error hides detail", errors.New("dial tcp: connection refused"), http.StatusInternalServerError, model.ResponseErrorCodeInternal, "서버 오류가 발생하였습니다."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ErrorHandler(tt.err, echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec))
+
+			var resp model.Response
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode body(%s): %+v", rec.Body, err)
+			}
+			if rec.Code != tt.status || resp.Success || resp.ErrorCode != tt.code || resp.Message != tt.message {
+				t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"buddle-server/internal/i18n"
+	"buddle-server/model"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type MetaHandler interface {
+	FindErrorCodes(c echo.Context) error // 에러 코드 목록
+}
+
+type metaHandler struct {
+}
+
+func NewMetaHandler() (MetaHandler, error) {
+	return &metaHandler{}, nil
+}
+
+type errorCodeResponse struct {
+	Code     model.ResponseErrorCode `json:"code"`
+	Status   int                     `json:"status"`   // HTTP 상태 코드
+	Message  string                  `json:"message"`  // 요청 언어( Accept-Language )의 메시지
+	Messages i18n.Messages           `json:"messages"` // 언어별 메시지
+}
+
+func (h metaHandler) FindErrorCodes(c echo.Context) error {
+	codes, lang := model.ErrorCodes(), i18n.FromContext(c.Request().Context())
+
+	data := make([]errorCodeResponse, 0, len(codes))
+	for _, info := range codes {
+		data = append(data, errorCodeResponse{
+			Code:     info.Code,
+			Status:   statusOf(info.Kind),
+			Message:  info.Messages.In(lang),
+			Messages: info.Messages,
+		})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{
+		Success: true,
+		Data:    data,
+	})
+}
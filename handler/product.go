@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"buddle-server/internal/i18n"
 	"buddle-server/middleware"
 	"buddle-server/model"
 	"buddle-server/service"
@@ -15,28 +16,41 @@ import (
 )
 
 type ProductHandler interface {
-	CreateProduct(c echo.Context) error     // 제품시리얼 정보 등록 ( CSV )
-	AuthProduct(c echo.Context) error       // 사용자 제품 인증 ( 정품 인증 )
-	ModAuthProduct(c echo.Context) error    // 사용자 제품 인증 정보 변경
-	CancelAuthProduct(c echo.Context) error // 사용자 제품 인증 취소 ( 정품 인증 취소 )
-	GetAuthProduct(c echo.Context) error    // 사용자 제품 인증 정보 조회( 정품 인증 )
-	DownloadReceipt(c echo.Context) error   // 영수증 이미지 다운로드
-	FindProductList(c echo.Context) error   // 제품 정보 리스트 ( 인증 정보 포함 )
-	UpdateProduct(c echo.Context) error     // 제품 정보 수정
-	DeleteProduct(c echo.Context) error     // 제품 정보 수정
+	CreateProduct(c echo.Context) error        // 제품시리얼 정보 등록 ( CSV )
+	AuthProduct(c echo.Context) error          // 사용자 제품 인증 ( 정품 인증 )
+	ModAuthProduct(c echo.Context) error       // 사용자 제품 인증 정보 변경
+	CancelAuthProduct(c echo.Context) error    // 사용자 제품 인증 취소 ( 정품 인증 취소 )
+	GetAuthProduct(c echo.Context) error       // 사용자 제품 인증 정보 목록 조회( 정품 인증 )
+	GetAuthProductBySeq(c echo.Context) error  // 사용자 제품 인증 정보 조회
+	DownloadReceipt(c echo.Context) error      // 영수증 이미지 다운로드
+	FindProductList(c echo.Context) error      // 제품 정보 리스트 ( 인증 정보 포함 )
+	UpdateProduct(c echo.Context) error        // 제품 정보 수정
+	DeleteProduct(c echo.Context) error        // 제품 정보 수정
+	FindProductReviews(c echo.Context) error   // 영수증 확인 대기 중인 제품 인증 목록
+	ApproveProductAuth(c echo.Context) error   // 제품 인증 승인 ( 영수증 확인 완료 )
+	RejectProductAuth(c echo.Context) error    // 제품 인증 반려 ( 반려 사유 )
+	StartTransfer(c echo.Context) error        // 사용자 제품 소유권 이전 요청 ( 이전 코드 발급 )
+	StartTransferByAdmin(c echo.Context) error // 관리자 제품 소유권 이전 요청 ( 이전 코드 발급 )
+	ClaimTransfer(c echo.Context) error        // 새 소유자의 제품 인증 ( 시리얼 번호, 이전 코드 )
+	CheckSerial(c echo.Context) error          // 시리얼 번호 형식 확인 ( 제품 인증 화면 )
 }
 
 type productHandler struct {
 	productService service.ProductService
+	tempDir        string // 다운로드 임시 파일 디렉터리
 }
 
-func NewProductHandler(productService service.ProductService) (ProductHandler, error) {
-	if productService == nil {
+func NewProductHandler(productService service.ProductService, tempDir string) (ProductHandler, error) {
+	switch {
+	case productService == nil:
 		return nil, errors.New("product service is nil")
+	case tempDir == "":
+		return nil, errors.New("empty temp dir")
 	}
 
 	return &productHandler{
 		productService: productService,
+		tempDir:        tempDir,
 	}, nil
 }
 
@@ -49,7 +63,7 @@ func (h productHandler) CreateProduct(c echo.Context) error {
 	// Source
 	file, err := c.FormFile("csv_file")
 	if err != nil {
-		return errors.Wrap(err, "failed to get form_file param")
+		return invalidRequest(err)
 	}
 	src, err := file.Open()
 	if err != nil {
@@ -79,32 +93,24 @@ func (h productHandler) AuthProduct(c echo.Context) error {
 
 	productRegist := new(model.ProductRegist)
 	if err := ctx.Bind(productRegist); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{
-			Message: "failed to bind request parameter",
-		})
+		return invalidRequest(err)
 	}
 
 	if err := productRegist.Validate(); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{
-			Message: "request params were invalid",
-		})
+		return invalidRequest(err)
 	}
 
 	file, err := c.FormFile("receipt")
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{
-			Message: fmt.Sprintf("failed to get receipt param err : %+v", err),
-		})
+		return invalidRequest(err)
 	}
 	src, err := file.Open()
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{
-			Message: "failed to open upload receipt file",
-		})
+		return errors.Wrap(err, "failed to open upload receipt file")
 	}
 	defer src.Close()
 
-	resp, err := h.productService.AuthProduct(ctx.GoContext(), productRegist, src)
+	resp, err := h.productService.AuthProduct(ctx.GoContext(), productRegist, &service.UploadFile{Filename: file.Filename, Body: src})
 	if err != nil {
 		return errors.Wrapf(err, "failed to auth product [ req = %+v ]", *productRegist)
 	}
@@ -120,26 +126,33 @@ func (h productHandler) ModAuthProduct(c echo.Context) error {
 
 	var productRegistSeq int64
 	if err := echo.PathParamsBinder(ctx).Int64("product_regist_seq", &productRegistSeq).BindError(); err != nil {
-		return errors.Wrap(err, "failed to bind product_regist_seq param")
+		return invalidRequest(err)
 	}
 
 	if productRegistSeq <= 0 {
-		return fmt.Errorf("invalid product_regist_seq param (%d)", productRegistSeq)
+		return invalidRequest(fmt.Errorf("invalid product_regist_seq param (%d)", productRegistSeq))
+	}
+
+	req, err := bindProductAuthRequest(ctx)
+	if err != nil {
+		return invalidRequest(err)
 	}
 
 	productRegist := new(model.ProductRegist)
 	if err := ctx.Bind(productRegist); err != nil {
-		return c.JSON(http.StatusInternalServerError, model.Response{
-			Message: "failed to bind request parameter",
-		})
+		return invalidRequest(err)
 	}
 	productRegist.ProductRegistSeq = productRegistSeq
 
-	if err := h.productService.ModAuthProduct(ctx.GoContext(), productRegist); err != nil {
+	if err := productRegist.ValidateUpdate(); err != nil {
+		return invalidRequest(err)
+	}
+
+	if err := h.productService.ModAuthProduct(ctx.GoContext(), req, productRegist); err != nil {
 		return errors.Wrapf(err, "failed to cancel product auth [ product_regist_seq = %d ]", productRegistSeq)
 	}
 
-	return c.JSON(http.StatusOK, model.Response{Success: true, Message: "수정 되었습니다."})
+	return c.JSON(http.StatusOK, model.NewSuccess(model.ResponseMessageModified, nil))
 }
 
 func (h productHandler) CancelAuthProduct(c echo.Context) error {
@@ -150,14 +163,19 @@ func (h productHandler) CancelAuthProduct(c echo.Context) error {
 
 	var productRegistSeq int64
 	if err := echo.PathParamsBinder(ctx).Int64("product_regist_seq", &productRegistSeq).BindError(); err != nil {
-		return errors.Wrap(err, "failed to bind product_regist_seq param")
+		return invalidRequest(err)
 	}
 
 	if productRegistSeq <= 0 {
-		return fmt.Errorf("invalid product_regist_seq param (%d)", productRegistSeq)
+		return invalidRequest(fmt.Errorf("invalid product_regist_seq param (%d)", productRegistSeq))
+	}
+
+	req, err := bindProductAuthRequest(ctx)
+	if err != nil {
+		return invalidRequest(err)
 	}
 
-	if err := h.productService.CancelAuthProduct(ctx.GoContext(), productRegistSeq); err != nil {
+	if err := h.productService.CancelAuthProduct(ctx.GoContext(), req, productRegistSeq); err != nil {
 		return errors.Wrapf(err, "failed to cancel product auth [ product_regist_seq = %d ]", productRegistSeq)
 	}
 
@@ -172,7 +190,7 @@ func (h productHandler) GetAuthProduct(c echo.Context) error {
 
 	req := new(model.ProductAuthRequest)
 	if err := ctx.Bind(req); err != nil {
-		return errors.Wrap(err, "failed to bind request parameter")
+		return invalidRequest(err)
 	}
 
 	data, err := h.productService.GetAuthProductInfo(ctx.GoContext(), *req)
@@ -183,6 +201,40 @@ func (h productHandler) GetAuthProduct(c echo.Context) error {
 	return c.JSON(http.StatusOK, data)
 }
 
+func (h productHandler) GetAuthProductBySeq(c echo.Context) error {
+	ctx, err := middleware.UpgradeContext(c)
+	if err != nil {
+		return errors.Wrap(err, "upgrade context")
+	}
+
+	var productRegistSeq int64
+	if err := echo.PathParamsBinder(ctx).Int64("product_regist_seq", &productRegistSeq).BindError(); err != nil {
+		return invalidRequest(err)
+	}
+
+	req, err := bindProductAuthRequest(ctx)
+	if err != nil {
+		return invalidRequest(err)
+	}
+
+	data, err := h.productService.GetAuthProductInfoBySeq(ctx.GoContext(), req, productRegistSeq)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get product auth info [ product_regist_seq = %d ]", productRegistSeq)
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// bindProductAuthRequest 는 제품 인증 정보를 조회, 변경하는 고객의 이름, 휴대폰 번호( query )를 바인딩한다.
+func bindProductAuthRequest(c echo.Context) (model.ProductAuthRequest, error) {
+	var req model.ProductAuthRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
+
 func (h productHandler) FindProductList(c echo.Context) error {
 	ctx, err := middleware.UpgradeContext(c)
 	if err != nil {
@@ -191,7 +243,7 @@ func (h productHandler) FindProductList(c echo.Context) error {
 
 	req := new(model.ProductManageRequest)
 	if err := ctx.Bind(req); err != nil {
-		return errors.Wrap(err, "failed to bind request parameter")
+		return invalidRequest(err)
 	}
 
 	data, err := h.productService.FindProductManageInfo(ctx.GoContext(), *req)
@@ -213,19 +265,16 @@ func (h productHandler) DownloadReceipt(c echo.Context) error {
 
 	var req struct {
 		ProductRegistSeq int64 `query:"product_regist_seq"`
+		Inline           bool  `query:"inline"` // 브라우저에서 미리보기
 	}
 
 	if err := ctx.Bind(&req); err != nil {
-		return errors.Wrap(err, "failed to bind request parameter")
+		return invalidRequest(err)
 	}
 
-	tmpFile, err := os.CreateTemp("/root/.buddle", "*")
+	tmpFile, err := os.CreateTemp(h.tempDir, "*")
 	if err != nil {
-		logrus.Errorf("failed to create temp file err:%+v", err)
-		return c.JSON(http.StatusOK, model.Response{
-			Success: false,
-			Message: "임시 파일 생성 실패",
-		})
+		return errors.Wrap(err, "failed to create temp file")
 	}
 	defer func() {
 		if err := tmpFile.Close(); err != nil {
@@ -238,14 +287,14 @@ func (h productHandler) DownloadReceipt(c echo.Context) error {
 
 	productRegistInfo, err := h.productService.DownloadReceipt(ctx.GoContext(), req.ProductRegistSeq, tmpFile)
 	if err != nil {
-		logrus.Errorf("failed to download from s3 err:%+v", err)
-		return c.JSON(http.StatusOK, model.Response{
-			Success: false,
-			Message: "파일 다운로드 실패",
-		})
+		return errors.Wrapf(err, "failed to download receipt [ product_regist_seq = %d ]", req.ProductRegistSeq)
 	}
 
-	return c.Attachment(tmpFile.Name(), fmt.Sprintf("%s(%s) 영수증", productRegistInfo.Name, productRegistInfo.Phone))
+	filename := model.ReceiptFilename(productRegistInfo.Name, productRegistInfo.Phone, i18n.FromContext(ctx.GoContext()))
+	if req.Inline {
+		return c.Inline(tmpFile.Name(), filename)
+	}
+	return c.Attachment(tmpFile.Name(), filename)
 }
 
 func (h productHandler) UpdateProduct(c echo.Context) error {
@@ -255,3 +304,28 @@ func (h productHandler) UpdateProduct(c echo.Context) error {
 func (h productHandler) DeleteProduct(c echo.Context) error {
 	panic("implement me")
 }
+
+func (h productHandler) CheckSerial(c echo.Context) error {
+	ctx, err := middleware.UpgradeContext(c)
+	if err != nil {
+		return errors.Wrap(err, "upgrade context")
+	}
+
+	// 제품 종류는 선택 사항
+	var productType *model.ProductType
+	if ctx.QueryParam("product_type") != "" {
+		var code int
+		if err := echo.QueryParamsBinder(ctx).Int("product_type", &code).BindError(); err != nil {
+			return invalidRequest(err)
+		}
+		productType = (*model.ProductType)(&code)
+	}
+
+	serial := ctx.Param("serial")
+	resp, err := h.productService.CheckSerial(ctx.GoContext(), serial, productType)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check serial [ serial_no = %s ]", serial)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
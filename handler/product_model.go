@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"buddle-server/middleware"
+	"buddle-server/model"
+	"buddle-server/service"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+type ProductModelHandler interface {
+	FindActiveProductModels(c echo.Context) error // 판매 중인 제품 모델 목록 ( 제품 인증, A/S 신청 화면 )
+	FindProductModels(c echo.Context) error       // 제품 모델 목록 ( 관리자용, 판매 중지 포함 )
+	CreateProductModel(c echo.Context) error      // 제품 모델 등록
+	UpdateProductModel(c echo.Context) error      // 제품 모델 수정
+	DeleteProductModel(c echo.Context) error      // 제품 모델 삭제
+}
+
+type productModelHandler struct {
+	catalogHandler
+	productModelService service.ProductModelService
+}
+
+func NewProductModelHandler(productModelService service.ProductModelService) (ProductModelHandler, error) {
+	if productModelService == nil {
+		return nil, errors.New("product model service is nil")
+	}
+
+	return &productModelHandler{
+		catalogHandler: catalogHandler{
+			name:     "product model",
+			service:  productModelService,
+			newEntry: func() model.CatalogEntry { return new(model.ProductModel) },
+		},
+		productModelService: productModelService,
+	}, nil
+}
+
+func (h productModelHandler) FindActiveProductModels(c echo.Context) error {
+	return h.findProductModels(c, true)
+}
+
+func (h productModelHandler) FindProductModels(c echo.Context) error {
+	return h.findProductModels(c, false)
+}
+
+func (h productModelHandler) findProductModels(c echo.Context, activeOnly bool) error {
+	ctx, err := middleware.UpgradeContext(c)
+	if err != nil {
+		return errors.Wrap(err, "upgrade context")
+	}
+
+	resp, err := h.productModelService.FindProductModels(ctx.GoContext(), activeOnly)
+	if err != nil {
+		return errors.Wrap(err, "failed to find product models")
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h productModelHandler) CreateProductModel(c echo.Context) error {
+	return h.create(c)
+}
+
+func (h productModelHandler) UpdateProductModel(c echo.Context) error {
+	return h.update(c)
+}
+
+func (h productModelHandler) DeleteProductModel(c echo.Context) error {
+	return h.delete(c)
+}
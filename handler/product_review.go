@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"buddle-server/middleware"
+	"buddle-server/model"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h productHandler) FindProductReviews(c echo.Context) error {
+	ctx, err := middleware.UpgradeContext(c)
+	if err != nil {
+		return errors.Wrap(err, "upgrade context")
+	}
+
+	req := new(model.ProductManageRequest)
+	if err := ctx.Bind(req); err != nil {
+		return invalidRequest(err)
+	}
+
+	data, err := h.productService.FindProductReviews(ctx.GoContext(), *req)
+	if err != nil {
+		return errors.Wrap(err, "failed to find product reviews")
+	}
+
+	return c.JSON(http.StatusOK, model.Response{
+		Success: true,
+		Data:    data,
+	})
+}
+
+func (h productHandler) ApproveProductAuth(c echo.Context) error {
+	ctx, err := middleware.UpgradeContext(c)
+	if err != nil {
+		return errors.Wrap(err, "upgrade context")
+	}
+
+	productRegistSeq, err := bindProductRegistSeq(ctx)
+	if err != nil {
+		return invalidRequest(err)
+	}
+
+	if err := h.productService.ApproveProductAuth(ctx.GoContext(), productRegistSeq); err != nil {
+		return errors.Wrapf(err, "failed to approve product auth [ product_regist_seq = %d ]", productRegistSeq)
+	}
+
+	return c.JSON(http.StatusOK, model.SimpleSuccess())
+}
+
+func (h productHandler) RejectProductAuth(c echo.Context) error {
+	ctx, err := middleware.UpgradeContext(c)
+	if err != nil {
+		return errors.Wrap(err, "upgrade context")
+	}
+
+	productRegistSeq, err := bindProductRegistSeq(ctx)
+	if err != nil {
+		return invalidRequest(err)
+	}
+
+	req := new(model.ProductRejectRequest)
+	if err := (&echo.DefaultBinder{}).BindBody(ctx, req); err != nil {
+		return invalidRequest(err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return invalidRequest(err)
+	}
+
+	if err := h.productService.RejectProductAuth(ctx.GoContext(), productRegistSeq, *req); err != nil {
+		return errors.Wrapf(err, "failed to reject product auth [ product_regist_seq = %d ]", productRegistSeq)
+	}
+
+	return c.JSON(http.StatusOK, model.SimpleSuccess())
+}
+
+// bindProductRegistSeq 는 경로의 product_regist_seq 를 읽는다.
+func bindProductRegistSeq(c echo.Context) (int64, error) {
+	var productRegistSeq int64
+	if err := echo.PathParamsBinder(c).Int64("product_regist_seq", &productRegistSeq).BindError(); err != nil {
+		return 0, err
+	}
+
+	if productRegistSeq <= 0 {
+		return 0, fmt.Errorf("invalid product_regist_seq param (%d)", productRegistSeq)
+	}
+
+	return productRegistSeq, nil
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"buddle-server/middleware"
+	"buddle-server/model"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h productHandler) StartTransfer(c echo.Context) error {
+	ctx, err := middleware.UpgradeContext(c)
+	if err != nil {
+		return errors.Wrap(err, "upgrade context")
+	}
+
+	var productRegistSeq int64
+	if err := echo.PathParamsBinder(ctx).Int64("product_regist_seq", &productRegistSeq).BindError(); err != nil {
+		return invalidRequest(err)
+	}
+
+	if productRegistSeq <= 0 {
+		return invalidRequest(fmt.Errorf("invalid product_regist_seq param (%d)", productRegistSeq))
+	}
+
+	req, err := bindProductAuthRequest(ctx)
+	if err != nil {
+		return invalidRequest(err)
+	}
+
+	resp, err := h.productService.StartTransfer(ctx.GoContext(), req, productRegistSeq)
+	if err != nil {
+		return errors.Wrapf(err, "failed to start product transfer [ product_regist_seq = %d ]", productRegistSeq)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h productHandler) StartTransferByAdmin(c echo.Context) error {
+	ctx, err := middleware.UpgradeContext(c)
+	if err != nil {
+		return errors.Wrap(err, "upgrade context")
+	}
+
+	req := new(model.ProductTransferRequest)
+	if err := ctx.Bind(req); err != nil {
+		return invalidRequest(err)
+	}
+
+	if req.ProductRegistSeq <= 0 {
+		return invalidRequest(fmt.Errorf("invalid product_regist_seq (%d)", req.ProductRegistSeq))
+	}
+
+	resp, err := h.productService.StartTransferByAdmin(ctx.GoContext(), req.ProductRegistSeq)
+	if err != nil {
+		return errors.Wrapf(err, "failed to start product transfer by admin [ product_regist_seq = %d ]", req.ProductRegistSeq)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h productHandler) ClaimTransfer(c echo.Context) error {
+	ctx, err := middleware.UpgradeContext(c)
+	if err != nil {
+		return errors.Wrap(err, "upgrade context")
+	}
+
+	claim := new(model.ProductTransferClaim)
+	if err := ctx.Bind(claim); err != nil {
+		return invalidRequest(err)
+	}
+
+	if err := claim.Validate(); err != nil {
+		return invalidRequest(err)
+	}
+
+	resp, err := h.productService.ClaimTransfer(ctx.GoContext(), claim)
+	if err != nil {
+		return errors.Wrapf(err, "failed to claim product transfer [ serial_no = %s ]", claim.SerialNo)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
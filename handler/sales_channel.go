@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"buddle-server/middleware"
+	"buddle-server/model"
+	"buddle-server/service"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+type SalesChannelHandler interface {
+	FindActiveSalesChannels(c echo.Context) error // 판매 중인 판매 채널 목록 ( 제품 인증, A/S 신청 화면 )
+	FindSalesChannels(c echo.Context) error       // 판매 채널 목록 ( 관리자용, 판매 중지 포함 )
+	CreateSalesChannel(c echo.Context) error      // 판매 채널 등록
+	UpdateSalesChannel(c echo.Context) error      // 판매 채널 수정
+	DeleteSalesChannel(c echo.Context) error      // 판매 채널 삭제
+}
+
+type salesChannelHandler struct {
+	catalogHandler
+	salesChannelService service.SalesChannelService
+}
+
+func NewSalesChannelHandler(salesChannelService service.SalesChannelService) (SalesChannelHandler, error) {
+	if salesChannelService == nil {
+		return nil, errors.New("sales channel service is nil")
+	}
+
+	return &salesChannelHandler{
+		catalogHandler: catalogHandler{
+			name:     "sales channel",
+			service:  salesChannelService,
+			newEntry: func() model.CatalogEntry { return new(model.SalesChannel) },
+		},
+		salesChannelService: salesChannelService,
+	}, nil
+}
+
+func (h salesChannelHandler) FindActiveSalesChannels(c echo.Context) error {
+	return h.findSalesChannels(c, true)
+}
+
+func (h salesChannelHandler) FindSalesChannels(c echo.Context) error {
+	return h.findSalesChannels(c, false)
+}
+
+func (h salesChannelHandler) findSalesChannels(c echo.Context, activeOnly bool) error {
+	ctx, err := middleware.UpgradeContext(c)
+	if err != nil {
+		return errors.Wrap(err, "upgrade context")
+	}
+
+	resp, err := h.salesChannelService.FindSalesChannels(ctx.GoContext(), activeOnly)
+	if err != nil {
+		return errors.Wrap(err, "failed to find sales channels")
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h salesChannelHandler) CreateSalesChannel(c echo.Context) error {
+	return h.create(c)
+}
+
+func (h salesChannelHandler) UpdateSalesChannel(c echo.Context) error {
+	return h.update(c)
+}
+
+func (h salesChannelHandler) DeleteSalesChannel(c echo.Context) error {
+	return h.delete(c)
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"buddle-server/internal/i18n"
+	"buddle-server/model"
+	"github.com/labstack/echo/v4"
+)
+
+// JSONSerializer 는 model.Response 를 요청 언어( middleware.Language )로 번역하여 응답한다. ( echo.JSONSerializer )
+type JSONSerializer struct {
+	echo.DefaultJSONSerializer
+}
+
+func (s JSONSerializer) Serialize(c echo.Context, i interface{}, indent string) error {
+	lang := i18n.FromContext(c.Request().Context())
+
+	switch resp := i.(type) {
+	case *model.Response:
+		if resp != nil {
+			i = resp.Localize(lang)
+		}
+	case model.Response:
+		i = resp.Localize(lang)
+	}
+
+	return s.DefaultJSONSerializer.Serialize(c, i, indent)
+}
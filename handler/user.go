@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"buddle-server/internal/app/api"
 	"buddle-server/internal/jwt"
 	"buddle-server/middleware"
 	"buddle-server/model"
@@ -18,15 +17,20 @@ type UserHandler interface {
 
 type userHandler struct {
 	userService service.UserService
+	jwtSecret   string // access token 서명 키
 }
 
-func NewUserHandler(userService service.UserService) (UserHandler, error) {
-	if userService == nil {
+func NewUserHandler(userService service.UserService, jwtSecret string) (UserHandler, error) {
+	switch {
+	case userService == nil:
 		return nil, errors.New("user service is nil")
+	case jwtSecret == "":
+		return nil, errors.New("empty jwt secret")
 	}
 
 	return &userHandler{
 		userService: userService,
+		jwtSecret:   jwtSecret,
 	}, nil
 }
 
@@ -39,24 +43,18 @@ func (l userHandler) SignUp(c echo.Context) error {
 	user := new(model.User)
 
 	if err := ctx.Bind(user); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"message": "bad request",
-		})
+		return invalidRequest(err)
 	}
 
 	if err := user.SignUpCheck(); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"message": "bad request",
-		})
+		return invalidRequest(err)
 	}
 
 	if err := l.userService.SignUp(ctx.GoContext(), user); err != nil {
 		return errors.Wrap(err, "failed to sign up user")
 	}
 
-	return c.JSON(http.StatusOK, map[string]string{
-		"message": "success",
-	})
+	return c.JSON(http.StatusOK, model.SimpleSuccess())
 }
 
 func (l userHandler) SignIn(c echo.Context) error {
@@ -68,20 +66,18 @@ func (l userHandler) SignIn(c echo.Context) error {
 	user := new(model.User)
 
 	if err := ctx.Bind(user); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"message": "bad request",
-		})
+		return invalidRequest(err)
 	}
 
 	resp, err := l.userService.SignIn(ctx.GoContext(), user)
 	if err != nil {
-		return errors.Wrap(err, "failed to sign up user")
+		return errors.Wrap(err, "failed to sign in user")
 	}
 
 	// 토큰 발행
-	accessToken, err := jwt.CreateJWT(user.Id, api.Config().Jwt.SecretKey)
+	accessToken, err := jwt.CreateJWT(user.Id, l.jwtSecret)
 	if err != nil {
-		return echo.ErrInternalServerError
+		return errors.Wrap(err, "failed to create access token")
 	}
 
 	resp.Data = struct {
@@ -0,0 +1,92 @@
+package handler_test
+
+import (
+	"buddle-server/handler"
+	"buddle-server/middleware"
+	"buddle-server/model"
+	"buddle-server/service/servicetest"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+)
+
+// serve 는 middleware.CustomContext 를 거쳐 h 를 호출한다.
+func serve(h echo.HandlerFunc, req *http.Request) (*httptest.ResponseRecorder, error) {
+	rec := httptest.NewRecorder()
+	err := middleware.CustomContext(h)(echo.New().NewContext(req, rec))
+	return rec, err
+}
+
+func newSignInRequest(id, password string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/user/login", strings.NewReader(`{"id":"`+id+`","password":"`+password+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	return req
+}
+
+func TestNewUserHandler(t *testing.T) {
+	if _, err := handler.NewUserHandler(nil, "secret"); err == nil {
+		t.Error("nil user service: expected error")
+	}
+	if _, err := handler.NewUserHandler(new(servicetest.UserService), ""); err == nil {
+		t.Error("empty jwt secret: expected error")
+	}
+}
+
+func TestUserHandler_SignIn(t *testing.T) {
+	userService := &servicetest.UserService{
+		SignInFunc: func(c context.Context, user *model.User) (*model.Response, error) {
+			if user.Password != "secret" {
+				return nil, model.NewError(model.ResponseErrorCodeInvalidUserPwd)
+			}
+			return model.SimpleSuccess(), nil
+		},
+	}
+	h, err := handler.NewUserHandler(userService, "test-secret")
+	if err != nil {
+		t.Fatalf("failed to create user handler: %+v", err)
+	}
+
+	t.Run("token is signed with injected secret", func(t *testing.T) {
+		rec, err := serve(h.SignIn, newSignInRequest("admin", "secret"))
+		if err != nil {
+			t.Fatalf("SignIn: %+v", err)
+		}
+
+		var resp struct {
+			Success bool `json:"success"`
+			Data    struct {
+				AccessToken string `json:"access_token"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode body(%s): %+v", rec.Body, err)
+		}
+
+		claims := jwt.MapClaims{}
+		if _, err := jwt.ParseWithClaims(resp.Data.AccessToken, claims, func(*jwt.Token) (interface{}, error) {
+			return []byte("test-secret"), nil
+		}); err != nil {
+			t.Fatalf("failed to parse access token: %+v", err)
+		}
+		if claims["Id"] != "admin" {
+			t.Fatalf("Id claim = %v, want admin", claims["Id"])
+		}
+	})
+
+	t.Run("no token on failure", func(t *testing.T) {
+		rec, err := serve(h.SignIn, newSignInRequest("admin", "wrong"))
+		if !errors.Is(err, model.ErrUnauthorized) {
+			t.Fatalf("err = %v, want unauthorized error", err)
+		}
+		if rec.Body.Len() != 0 {
+			t.Fatalf("body = %s, want empty", rec.Body)
+		}
+	})
+}
@@ -4,19 +4,26 @@ import (
 	"buddle-server/internal/db"
 	"buddle-server/internal/jwt"
 	"buddle-server/internal/log"
+	"buddle-server/internal/ocr"
 	"buddle-server/internal/s3"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 	"io/ioutil"
 )
 
+// DefaultTempDir 는 temp_dir 설정이 없을 때 사용하는 다운로드 임시 파일 디렉터리
+const DefaultTempDir = "/root/.buddle"
+
 var c configure
 
 type configure struct {
 	Logger     log.Config `yaml:"logger"`
 	DB         db.Config  `yaml:"db"`
+	ReadDB     db.Config  `yaml:"read_db"` // 읽기 전용 복제본, host 가 없으면 db 를 사용
 	FileBucket s3.Config  `yaml:"file_bucket"`
 	Jwt        jwt.Jwt    `yaml:"jwt"`
+	TempDir    string     `yaml:"temp_dir"`    // 다운로드 임시 파일 디렉터리, 없으면 DefaultTempDir
+	ReceiptOCR ocr.Config `yaml:"receipt_ocr"` // 영수증 OCR, provider 가 없으면 사용하지 않음
 }
 
 func InitConfig(p string) error {
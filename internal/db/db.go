@@ -15,19 +15,31 @@ import (
 
 	"github.com/go-sql-driver/mysql"
 	gorm_mysql "gorm.io/driver/mysql"
+	gorm_sqlite "gorm.io/driver/sqlite"
 )
 
-type dbKey struct{}
+type dbKey struct {
+	name string
+}
 
 var (
-	WriteDBKey = dbKey{}
-	ReadDBKey  = dbKey{}
+	WriteDBKey = dbKey{name: "write"}
+	ReadDBKey  = dbKey{name: "read"} // 읽기 전용 복제본, 설정되지 않은 경우 WriteDBKey 의 연결을 사용
+)
+
+const (
+	DriverMySQL  = "mysql"
+	DriverSQLite = "sqlite"
 )
 
+// SQLiteMemory 를 database 로 설정하면 SQLite 인메모리 DB 를 사용한다.
+const SQLiteMemory = ":memory:"
+
 type Config struct {
+	Driver          string `json:"driver" yaml:"driver"` // mysql(기본값), sqlite
 	Host            string `json:"host" yaml:"host"`
 	Port            int    `json:"port" yaml:"port"`
-	Database        string `json:"database" yaml:"database"`
+	Database        string `json:"database" yaml:"database"` // sqlite 인 경우 DB 파일 경로 또는 :memory:
 	Username        string `json:"username" yaml:"username"`
 	Password        string `json:"password" yaml:"password"`
 	Verbose         bool   `json:"verbose" yaml:"verbose"`
@@ -39,21 +51,15 @@ type Config struct {
 }
 
 func Connect(c Config) (*gorm.DB, error) {
-	dsnConfig := mysql.NewConfig()
-	dsnConfig.User = c.Username
-	dsnConfig.Passwd = c.Password
-	dsnConfig.Addr = net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
-	dsnConfig.DBName = c.Database
-	dsnConfig.ParseTime = true
-	dsnConfig.InterpolateParams = true
-	dsnConfig.Collation = "utf8mb4_unicode_ci"
-	dsnConfig.Net = "tcp"
-	dsnConfig.Params = map[string]string{
-		"charset": "utf8mb4",
+	var dialect gorm.Dialector
+	switch c.Driver {
+	case "", DriverMySQL:
+		dialect = mysqlDialector(c)
+	case DriverSQLite:
+		dialect = sqliteDialector(c)
+	default:
+		return nil, fmt.Errorf("unsupported db driver(%s)", c.Driver)
 	}
-	dialect := gorm_mysql.Open(dsnConfig.FormatDSN())
-
-	logrus.Debugf("DSN string : %s", dsnConfig.FormatDSN())
 
 	db, err := gorm.Open(dialect, &gorm.Config{DisableNestedTransaction: true})
 	if err != nil {
@@ -90,6 +96,11 @@ func Connect(c Config) (*gorm.DB, error) {
 		connMaxIdletime = time.Duration(c.ConnMaxIdleTime) * time.Second
 	}
 
+	if c.Driver == DriverSQLite && c.Database == SQLiteMemory {
+		// 인메모리 DB 는 연결마다 별도로 생성되므로 하나의 연결만 유지한다.
+		maxOpenConn, maxIdleConn, connMaxLifetime, connMaxIdletime = 1, 1, 0, 0
+	}
+
 	stdDB.SetMaxOpenConns(maxOpenConn)
 	stdDB.SetConnMaxLifetime(connMaxLifetime)
 	stdDB.SetMaxIdleConns(maxIdleConn)
@@ -98,12 +109,42 @@ func Connect(c Config) (*gorm.DB, error) {
 	return db, nil
 }
 
+func mysqlDialector(c Config) gorm.Dialector {
+	dsnConfig := mysql.NewConfig()
+	dsnConfig.User = c.Username
+	dsnConfig.Passwd = c.Password
+	dsnConfig.Addr = net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+	dsnConfig.DBName = c.Database
+	dsnConfig.ParseTime = true
+	dsnConfig.InterpolateParams = true
+	dsnConfig.Collation = "utf8mb4_unicode_ci"
+	dsnConfig.Net = "tcp"
+	dsnConfig.Params = map[string]string{
+		"charset": "utf8mb4",
+	}
+
+	logrus.Debugf("DSN string : %s", dsnConfig.FormatDSN())
+
+	return gorm_mysql.Open(dsnConfig.FormatDSN())
+}
+
+func sqliteDialector(c Config) gorm.Dialector {
+	dsn := fmt.Sprintf("file:%s?_foreign_keys=1&_busy_timeout=5000", c.Database)
+
+	logrus.Debugf("DSN string : %s", dsn)
+
+	return gorm_sqlite.Open(dsn)
+}
+
 func ConnFromContext(c context.Context, key dbKey) (*gorm.DB, error) {
 	if c == nil {
 		return nil, fmt.Errorf("nil Context")
 	}
 
 	v, ok := c.Value(key).(*gorm.DB)
+	if !ok && key == ReadDBKey {
+		v, ok = c.Value(WriteDBKey).(*gorm.DB)
+	}
 	if !ok {
 		return nil, fmt.Errorf("nil db")
 	}
@@ -131,7 +172,9 @@ func Transaction(c context.Context, fn func(c context.Context) error, opts ...*s
 		}
 	}()
 
-	if err := fn(ContextWithConn(c, WriteDBKey, tx)); err != nil {
+	// 트랜잭션 내의 조회는 커밋 전 변경 내용을 볼 수 있도록 복제본 대신 트랜잭션을 사용
+	txCtx := ContextWithConn(ContextWithConn(c, WriteDBKey, tx), ReadDBKey, tx)
+	if err := fn(txCtx); err != nil {
 		return errors.WithStack(err)
 	}
 
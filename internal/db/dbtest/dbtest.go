@@ -0,0 +1,41 @@
+// Package dbtest 는 테스트용 DB 를 제공한다.
+package dbtest
+
+import (
+	"buddle-server/internal/db"
+	"buddle-server/internal/db/migration"
+	"context"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// New 는 전체 스키마가 적용된 SQLite 인메모리 DB 를 생성하고, 쓰기/읽기 연결이 설정된 context 를 반환한다.
+// DB 는 테스트마다 독립적이며 테스트가 끝나면 닫힌다.
+func New(t testing.TB) (context.Context, *gorm.DB) {
+	t.Helper()
+
+	conn, err := db.Connect(db.Config{Driver: db.DriverSQLite, Database: db.SQLiteMemory})
+	if err != nil {
+		t.Fatalf("failed to connect test db: %+v", err)
+	}
+
+	stdDB, err := conn.DB()
+	if err != nil {
+		t.Fatalf("failed to get standard db object: %+v", err)
+	}
+	t.Cleanup(func() { stdDB.Close() })
+
+	migrator, err := migration.New(conn)
+	if err != nil {
+		t.Fatalf("failed to create migrator: %+v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to migrate test db: %+v", err)
+	}
+
+	c := db.ContextWithConn(context.Background(), db.WriteDBKey, conn)
+	c = db.ContextWithConn(c, db.ReadDBKey, conn)
+
+	return c, conn
+}
@@ -0,0 +1,346 @@
+package migration
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 마이그레이션 파일은 DB 드라이버별 디렉토리( sql/<dialect> )에 위치한다.
+//
+//go:embed sql/*/*.sql
+var sqlFiles embed.FS
+
+// 마이그레이션 파일명 형식: <version>_<name>.(up|down).sql
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+const schemaMigrationsTable = "schema_migrations"
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Migration
+	Applied   bool
+	Dirty     bool
+	AppliedAt time.Time
+}
+
+// appliedMigration 은 적용된 마이그레이션 기록.
+// MySQL 의 DDL 은 자동으로 커밋되어 트랜잭션으로 묶을 수 없으므로 실행 전에 dirty 로 기록하고,
+// 모든 구문이 성공하면 dirty 를 해제한다. dirty 인 기록이 있으면 수동으로 정리하기 전까지 실행하지 않는다.
+type appliedMigration struct {
+	Version   int64     `gorm:"Column:version;PRIMARY_KEY"`
+	Name      string    `gorm:"Column:name"`
+	Dirty     bool      `gorm:"Column:dirty"`
+	AppliedAt time.Time `gorm:"Column:applied_at"`
+}
+
+func (appliedMigration) TableName() string {
+	return schemaMigrationsTable
+}
+
+type Migrator struct {
+	conn       *gorm.DB
+	migrations []Migration
+}
+
+func New(conn *gorm.DB) (*Migrator, error) {
+	if conn == nil {
+		return nil, errors.New("nil db conn")
+	}
+
+	dialect := conn.Dialector.Name()
+	migrations, err := load(sqlFiles, path.Join("sql", dialect))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load migrations [ dialect = %s ]", dialect)
+	}
+
+	return &Migrator{conn: conn, migrations: migrations}, nil
+}
+
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read migration directory")
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		matches := fileNamePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("invalid migration file name(%s)", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid migration version(%s)", entry.Name())
+		}
+
+		body, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read migration file(%s)", entry.Name())
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		}
+		if m.Name != matches[2] {
+			return nil, fmt.Errorf("migration version(%d) has different names (%s, %s)", version, m.Name, matches[2])
+		}
+
+		switch matches[3] {
+		case "up":
+			m.Up = string(body)
+		case "down":
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration version(%d) requires both up and down files", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+func (m *Migrator) init(c context.Context) error {
+	conn := m.conn.WithContext(c)
+	if err := conn.Exec("CREATE TABLE IF NOT EXISTS " + schemaMigrationsTable + " (" +
+		"version BIGINT NOT NULL PRIMARY KEY, " +
+		"name VARCHAR(255) NOT NULL, " +
+		"dirty BOOLEAN NOT NULL DEFAULT FALSE, " +
+		"applied_at DATETIME NOT NULL)").Error; err != nil {
+		return err
+	}
+
+	// dirty 컬럼이 추가되기 전에 만들어진 테이블
+	if !conn.Migrator().HasColumn(&appliedMigration{}, "dirty") {
+		return conn.Exec("ALTER TABLE " + schemaMigrationsTable + " ADD COLUMN dirty BOOLEAN NOT NULL DEFAULT FALSE").Error
+	}
+
+	return nil
+}
+
+func (m *Migrator) applied(c context.Context) (map[int64]appliedMigration, error) {
+	if err := m.init(c); err != nil {
+		return nil, errors.Wrap(err, "failed to create schema migrations table")
+	}
+
+	rows := make([]appliedMigration, 0)
+	if err := m.conn.WithContext(c).Order("version").Find(&rows).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to get applied migrations")
+	}
+
+	result := make(map[int64]appliedMigration, len(rows))
+	for _, row := range rows {
+		result[row.Version] = row
+	}
+
+	return result, nil
+}
+
+// clean 은 dirty 인 마이그레이션이 있으면 에러를 반환한다.
+func clean(applied map[int64]appliedMigration) error {
+	for _, row := range applied {
+		if row.Dirty {
+			return fmt.Errorf("migration %06d_%s is dirty, fix the schema manually and run \"migrate force %d\"", row.Version, row.Name, row.Version)
+		}
+	}
+	return nil
+}
+
+// Up 은 적용되지 않은 마이그레이션을 버전 순서대로 모두 적용한다.
+func (m *Migrator) Up(c context.Context) ([]Migration, error) {
+	applied, err := m.applied(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := clean(applied); err != nil {
+		return nil, err
+	}
+
+	result := make([]Migration, 0)
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		// 사용자 변수( SET @... )를 사용하는 구문이 있으므로 하나의 연결에서 실행한다.
+		if err := m.conn.WithContext(c).Connection(func(conn *gorm.DB) error {
+			// 구문마다 같은 연결을 사용하는 새 Statement 로 실행
+			conn = conn.Session(&gorm.Session{})
+			row := &appliedMigration{Version: migration.Version, Name: migration.Name, Dirty: true, AppliedAt: time.Now()}
+			if err := conn.Create(row).Error; err != nil {
+				return errors.Wrap(err, "failed to mark migration dirty")
+			}
+			if err := exec(conn, migration.Up); err != nil {
+				return err
+			}
+			return conn.Model(row).Update("dirty", false).Error
+		}); err != nil {
+			return result, errors.Wrapf(err, "failed to apply migration %06d_%s", migration.Version, migration.Name)
+		}
+		result = append(result, migration)
+	}
+
+	return result, nil
+}
+
+// Down 은 마지막으로 적용된 마이그레이션부터 steps 개를 되돌린다.
+func (m *Migrator) Down(c context.Context, steps int) ([]Migration, error) {
+	applied, err := m.applied(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := clean(applied); err != nil {
+		return nil, err
+	}
+
+	result := make([]Migration, 0)
+	for i := len(m.migrations) - 1; i >= 0 && len(result) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		if err := m.conn.WithContext(c).Connection(func(conn *gorm.DB) error {
+			conn = conn.Session(&gorm.Session{})
+			row := &appliedMigration{Version: migration.Version}
+			if err := conn.Model(row).Update("dirty", true).Error; err != nil {
+				return errors.Wrap(err, "failed to mark migration dirty")
+			}
+			if err := exec(conn, migration.Down); err != nil {
+				return err
+			}
+			return conn.Delete(row).Error
+		}); err != nil {
+			return result, errors.Wrapf(err, "failed to revert migration %06d_%s", migration.Version, migration.Name)
+		}
+		result = append(result, migration)
+	}
+
+	return result, nil
+}
+
+// Force 는 수동으로 정리한 결과에 맞춰 version 까지 적용된 것으로 기록하고 dirty 를 해제한다.
+// version 보다 큰 마이그레이션 기록은 삭제한다.
+func (m *Migrator) Force(c context.Context, version int64) error {
+	applied, err := m.applied(c)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, migration := range m.migrations {
+		found = found || migration.Version == version
+	}
+	if !found {
+		return fmt.Errorf("unknown migration version(%d)", version)
+	}
+
+	return m.conn.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("version > ?", version).Delete(&appliedMigration{}).Error; err != nil {
+			return errors.Wrap(err, "failed to delete migrations")
+		}
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			if row, ok := applied[migration.Version]; ok && !row.Dirty {
+				continue
+			}
+			row := &appliedMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}
+			if err := tx.Save(row).Error; err != nil {
+				return errors.Wrapf(err, "failed to force migration %06d_%s", migration.Version, migration.Name)
+			}
+		}
+		return nil
+	})
+}
+
+func (m *Migrator) Status(c context.Context) ([]Status, error) {
+	applied, err := m.applied(c)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		row, ok := applied[migration.Version]
+		result = append(result, Status{Migration: migration, Applied: ok && !row.Dirty, Dirty: row.Dirty, AppliedAt: row.AppliedAt})
+	}
+
+	return result, nil
+}
+
+// Pending 은 아직 적용되지 않은 마이그레이션을 버전 순서대로 반환한다.
+func (m *Migrator) Pending(c context.Context) ([]Migration, error) {
+	statuses, err := m.Status(c)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Migration, 0)
+	for _, s := range statuses {
+		if !s.Applied {
+			result = append(result, s.Migration)
+		}
+	}
+
+	return result, nil
+}
+
+// exec 는 ';' 로 끝나는 줄을 기준으로 구문을 나누어 실행한다.
+// ( MySQL 드라이버는 기본적으로 한번에 여러 구문을 실행하지 않는다 )
+func exec(tx *gorm.DB, script string) error {
+	for _, stmt := range split(script) {
+		if err := tx.Exec(stmt).Error; err != nil {
+			return errors.Wrapf(err, "failed to execute statement [ %s ]", stmt)
+		}
+	}
+	return nil
+}
+
+func split(script string) []string {
+	stmts := make([]string, 0)
+	var b strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+
+		b.WriteString(line)
+		b.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			stmts = append(stmts, strings.TrimSuffix(strings.TrimSpace(b.String()), ";"))
+			b.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(b.String()); rest != "" {
+		stmts = append(stmts, rest)
+	}
+
+	return stmts
+}
@@ -0,0 +1,136 @@
+package migration_test
+
+import (
+	"buddle-server/internal/db"
+	"buddle-server/internal/db/migration"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestMigrator(t *testing.T) {
+	conn, err := db.Connect(db.Config{Driver: db.DriverSQLite, Database: db.SQLiteMemory})
+	if err != nil {
+		t.Fatalf("Connect() error = %+v", err)
+	}
+	migrator, err := migration.New(conn)
+	if err != nil {
+		t.Fatalf("New() error = %+v", err)
+	}
+	c := context.Background()
+
+	pending, err := migrator.Pending(c)
+	if err != nil {
+		t.Fatalf("Pending() error = %+v", err)
+	}
+
+	applied, err := migrator.Up(c)
+	if err != nil {
+		t.Fatalf("Up() error = %+v", err)
+	}
+	if len(applied) == 0 || len(applied) != len(pending) {
+		t.Fatalf("Up() applied %d migrations, want %d pending", len(applied), len(pending))
+	}
+	if pending, err := migrator.Pending(c); err != nil || len(pending) != 0 {
+		t.Errorf("Pending() after Up() = %d migrations, %v, want none", len(pending), err)
+	}
+	for _, table := range []string{"product", "product_regist", "after_service", "user"} {
+		if !conn.Migrator().HasTable(table) {
+			t.Errorf("table %s does not exist after Up()", table)
+		}
+	}
+
+	if again, err := migrator.Up(c); err != nil || len(again) != 0 {
+		t.Errorf("second Up() = %d migrations, %v, want none", len(again), err)
+	}
+
+	statuses, err := migrator.Status(c)
+	if err != nil {
+		t.Fatalf("Status() error = %+v", err)
+	}
+	for _, s := range statuses {
+		if !s.Applied || s.AppliedAt.IsZero() {
+			t.Errorf("Status() %06d_%s is not applied", s.Version, s.Name)
+		}
+	}
+
+	reverted, err := migrator.Down(c, len(applied))
+	if err != nil {
+		t.Fatalf("Down() error = %+v", err)
+	}
+	if len(reverted) != len(applied) || reverted[0].Version != applied[len(applied)-1].Version {
+		t.Errorf("Down() reverted %d migrations starting from %d", len(reverted), reverted[0].Version)
+	}
+	if conn.Migrator().HasTable("product") {
+		t.Errorf("table product exists after Down()")
+	}
+
+	if _, err := migrator.Up(c); err != nil {
+		t.Fatalf("Up() after Down() error = %+v", err)
+	}
+}
+
+func TestMigrator_Dirty(t *testing.T) {
+	conn, err := db.Connect(db.Config{Driver: db.DriverSQLite, Database: db.SQLiteMemory})
+	if err != nil {
+		t.Fatalf("Connect() error = %+v", err)
+	}
+	migrator, err := migration.New(conn)
+	if err != nil {
+		t.Fatalf("New() error = %+v", err)
+	}
+	c := context.Background()
+
+	if _, err := migrator.Up(c); err != nil {
+		t.Fatalf("Up() error = %+v", err)
+	}
+	reverted, err := migrator.Down(c, 1)
+	if err != nil || len(reverted) != 1 {
+		t.Fatalf("Down(1) = %d migrations, %+v", len(reverted), err)
+	}
+	last := reverted[0]
+
+	// 두 번째 구문에서 실패하도록 컬럼을 미리 추가한다.
+	if err := conn.Exec("ALTER TABLE `product_model` ADD COLUMN `serial_length` INTEGER NOT NULL DEFAULT 0").Error; err != nil {
+		t.Fatalf("add column error = %+v", err)
+	}
+	if _, err := migrator.Up(c); err == nil {
+		t.Fatalf("Up() error = nil, want failure")
+	}
+
+	statuses, err := migrator.Status(c)
+	if err != nil {
+		t.Fatalf("Status() error = %+v", err)
+	}
+	if s := statuses[len(statuses)-1]; s.Version != last.Version || !s.Dirty || s.Applied {
+		t.Errorf("Status() last = %+v, want dirty", s)
+	}
+	if _, err := migrator.Up(c); err == nil || !strings.Contains(err.Error(), "dirty") {
+		t.Errorf("Up() with dirty migration error = %v", err)
+	}
+	if _, err := migrator.Down(c, 1); err == nil || !strings.Contains(err.Error(), "dirty") {
+		t.Errorf("Down() with dirty migration error = %v", err)
+	}
+
+	// 남은 구문을 수동으로 적용한 뒤 강제로 적용 완료 처리한다.
+	for _, stmt := range []string{
+		"ALTER TABLE `product_model` ADD COLUMN `serial_charset` TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE `product_model` ADD COLUMN `serial_check_digit` INTEGER NOT NULL DEFAULT 0",
+	} {
+		if err := conn.Exec(stmt).Error; err != nil {
+			t.Fatalf("exec error = %+v", err)
+		}
+	}
+	if err := migrator.Force(c, 0); err == nil {
+		t.Errorf("Force(0) error = nil")
+	}
+	if err := migrator.Force(c, last.Version); err != nil {
+		t.Fatalf("Force() error = %+v", err)
+	}
+	if pending, err := migrator.Pending(c); err != nil || len(pending) != 0 {
+		t.Errorf("Pending() after Force() = %d migrations, %v, want none", len(pending), err)
+	}
+	if _, err := migrator.Up(c); err != nil {
+		t.Errorf("Up() after Force() error = %+v", err)
+	}
+}
@@ -0,0 +1,118 @@
+-- 기존 운영 환경에 이미 테이블이 있는 경우에도 적용할 수 있도록 IF NOT EXISTS 를 사용한다.
+-- 이미 있는 테이블에는 아래 인덱스가 만들어지지 않으므로 파일 끝에서 없는 인덱스와 제약을 추가한다.
+CREATE TABLE IF NOT EXISTS `product` (
+    `product_seq`  BIGINT      NOT NULL AUTO_INCREMENT,
+    `serial_no`    VARCHAR(64) NOT NULL,
+    `product_type` INT         NOT NULL DEFAULT 0,
+    `regdate`      DATETIME    NOT NULL,
+    `modified`     DATETIME    NOT NULL,
+    PRIMARY KEY (`product_seq`),
+    KEY `idx_product_serial_no` (`serial_no`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `product_regist` (
+    `product_regist_seq`  BIGINT       NOT NULL AUTO_INCREMENT,
+    `product_seq`         BIGINT       NOT NULL,
+    `name`                VARCHAR(64)  NOT NULL DEFAULT '',
+    `phone`               VARCHAR(32)  NOT NULL DEFAULT '',
+    `addr`                VARCHAR(255) NOT NULL DEFAULT '',
+    `addr_detail`         VARCHAR(255) NOT NULL DEFAULT '',
+    `market_type`         INT          NOT NULL DEFAULT 0,
+    `status`              INT          NOT NULL DEFAULT 0,
+    `purchase_date`       DATETIME     NOT NULL,
+    `receipt_s3_location` VARCHAR(255) NOT NULL DEFAULT '',
+    `regdate`             DATETIME     NOT NULL,
+    `modified`            DATETIME     NOT NULL,
+    PRIMARY KEY (`product_regist_seq`),
+    KEY `idx_product_regist_product_seq_status` (`product_seq`, `status`),
+    KEY `idx_product_regist_name_phone` (`name`, `phone`),
+    KEY `idx_product_regist_receipt_s3_location` (`receipt_s3_location`),
+    CONSTRAINT `fk_product_regist_product` FOREIGN KEY (`product_seq`) REFERENCES `product` (`product_seq`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `after_service` (
+    `after_service_seq` BIGINT       NOT NULL AUTO_INCREMENT,
+    `name`              VARCHAR(64)  NOT NULL DEFAULT '',
+    `phone`             VARCHAR(32)  NOT NULL DEFAULT '',
+    `email`             VARCHAR(255) NOT NULL DEFAULT '',
+    `addr`              VARCHAR(255) NOT NULL DEFAULT '',
+    `addr_detail`       VARCHAR(255) NOT NULL DEFAULT '',
+    `product_type`      INT          NOT NULL DEFAULT 0,
+    `market_type`       INT          NOT NULL DEFAULT 0,
+    `purchase_date`     DATETIME     NOT NULL,
+    `file1_s3_location` VARCHAR(255) NOT NULL DEFAULT '',
+    `file2_s3_location` VARCHAR(255) NOT NULL DEFAULT '',
+    `file3_s3_location` VARCHAR(255) NOT NULL DEFAULT '',
+    `file4_s3_location` VARCHAR(255) NOT NULL DEFAULT '',
+    `file5_s3_location` VARCHAR(255) NOT NULL DEFAULT '',
+    `contents`          TEXT         NOT NULL,
+    `regdate`           DATETIME     NOT NULL,
+    `modified`          DATETIME     NOT NULL,
+    PRIMARY KEY (`after_service_seq`),
+    KEY `idx_after_service_name_phone` (`name`, `phone`),
+    KEY `idx_after_service_regdate` (`regdate`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `user` (
+    `user_seq`  BIGINT       NOT NULL AUTO_INCREMENT,
+    `id`        VARCHAR(64)  NOT NULL,
+    `password`  VARCHAR(255) NOT NULL,
+    `user_type` INT          NOT NULL DEFAULT 0,
+    `name`      VARCHAR(64)  NOT NULL DEFAULT '',
+    `phone`     VARCHAR(32)  NOT NULL DEFAULT '',
+    `birth`     VARCHAR(16)  NOT NULL DEFAULT '',
+    `regdate`   DATETIME     NOT NULL,
+    `modified`  DATETIME     NOT NULL,
+    PRIMARY KEY (`user_seq`),
+    UNIQUE KEY `uk_user_id` (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+-- 기존 테이블 기준선: 위 CREATE TABLE 에 선언된 인덱스와 제약 중 없는 것을 추가한다.
+-- ( MySQL 은 ADD KEY IF NOT EXISTS 를 지원하지 않으므로 information_schema 로 확인한 뒤 실행 )
+SET @stmt = IF((SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'product' AND index_name = 'idx_product_serial_no') = 0,
+    'ALTER TABLE `product` ADD KEY `idx_product_serial_no` (`serial_no`)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF((SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'product_regist' AND index_name = 'idx_product_regist_product_seq_status') = 0,
+    'ALTER TABLE `product_regist` ADD KEY `idx_product_regist_product_seq_status` (`product_seq`, `status`)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF((SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'product_regist' AND index_name = 'idx_product_regist_name_phone') = 0,
+    'ALTER TABLE `product_regist` ADD KEY `idx_product_regist_name_phone` (`name`, `phone`)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF((SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'product_regist' AND index_name = 'idx_product_regist_receipt_s3_location') = 0,
+    'ALTER TABLE `product_regist` ADD KEY `idx_product_regist_receipt_s3_location` (`receipt_s3_location`)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF((SELECT COUNT(*) FROM information_schema.table_constraints WHERE table_schema = DATABASE() AND table_name = 'product_regist' AND constraint_name = 'fk_product_regist_product' AND constraint_type = 'FOREIGN KEY') = 0,
+    'ALTER TABLE `product_regist` ADD CONSTRAINT `fk_product_regist_product` FOREIGN KEY (`product_seq`) REFERENCES `product` (`product_seq`)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF((SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'after_service' AND index_name = 'idx_after_service_name_phone') = 0,
+    'ALTER TABLE `after_service` ADD KEY `idx_after_service_name_phone` (`name`, `phone`)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF((SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'after_service' AND index_name = 'idx_after_service_regdate') = 0,
+    'ALTER TABLE `after_service` ADD KEY `idx_after_service_regdate` (`regdate`)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF((SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'user' AND index_name = 'uk_user_id') = 0,
+    'ALTER TABLE `user` ADD UNIQUE KEY `uk_user_id` (`id`)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
@@ -0,0 +1,9 @@
+ALTER TABLE `after_service`
+    DROP COLUMN `file5_sha256`,
+    DROP COLUMN `file4_sha256`,
+    DROP COLUMN `file3_sha256`,
+    DROP COLUMN `file2_sha256`,
+    DROP COLUMN `file1_sha256`;
+
+ALTER TABLE `product_regist`
+    DROP COLUMN `receipt_sha256`;
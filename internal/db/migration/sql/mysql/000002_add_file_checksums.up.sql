@@ -0,0 +1,9 @@
+ALTER TABLE `product_regist`
+    ADD COLUMN `receipt_sha256` CHAR(64) NOT NULL DEFAULT '' AFTER `receipt_s3_location`;
+
+ALTER TABLE `after_service`
+    ADD COLUMN `file1_sha256` CHAR(64) NOT NULL DEFAULT '' AFTER `file5_s3_location`,
+    ADD COLUMN `file2_sha256` CHAR(64) NOT NULL DEFAULT '' AFTER `file1_sha256`,
+    ADD COLUMN `file3_sha256` CHAR(64) NOT NULL DEFAULT '' AFTER `file2_sha256`,
+    ADD COLUMN `file4_sha256` CHAR(64) NOT NULL DEFAULT '' AFTER `file3_sha256`,
+    ADD COLUMN `file5_sha256` CHAR(64) NOT NULL DEFAULT '' AFTER `file4_sha256`;
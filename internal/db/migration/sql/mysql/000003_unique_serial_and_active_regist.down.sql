@@ -0,0 +1,7 @@
+ALTER TABLE `product_regist`
+    DROP INDEX `uk_product_regist_active_product_seq`,
+    DROP COLUMN `active_product_seq`;
+
+ALTER TABLE `product`
+    DROP INDEX `uk_product_serial_no_product_type`,
+    ADD KEY `idx_product_serial_no` (`serial_no`);
@@ -0,0 +1,17 @@
+-- 적용 전에 중복된 시리얼과 제품당 2건 이상의 인증 완료(status = 2) 정보를 정리해야 한다.
+ALTER TABLE `product`
+    ADD UNIQUE KEY `uk_product_serial_no_product_type` (`serial_no`, `product_type`);
+
+-- 000001 이전부터 있던 테이블에는 idx_product_serial_no 가 없을 수 있으므로 있을 때만 삭제한다.
+-- ( serial_no 조회는 uk_product_serial_no_product_type 이 대신한다 )
+SET @stmt = IF((SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'product' AND index_name = 'idx_product_serial_no') > 0,
+    'ALTER TABLE `product` DROP INDEX `idx_product_serial_no`', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+-- MySQL 은 부분 인덱스를 지원하지 않으므로 인증 완료 상태일 때만 값을 가지는 컬럼으로
+-- 제품당 하나의 인증 완료 정보만 존재하도록 한다. ( NULL 은 unique 제약에서 중복을 허용 )
+ALTER TABLE `product_regist`
+    ADD COLUMN `active_product_seq` BIGINT GENERATED ALWAYS AS (IF(`status` = 2, `product_seq`, NULL)) STORED,
+    ADD UNIQUE KEY `uk_product_regist_active_product_seq` (`active_product_seq`);
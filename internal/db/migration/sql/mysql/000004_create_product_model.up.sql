@@ -0,0 +1,22 @@
+-- 제품 모델 카탈로그. product.product_type, after_service.product_type 은 code 를 참조한다.
+-- ( 기존 데이터 정리 전에도 적용할 수 있도록 외래 키는 두지 않는다 )
+CREATE TABLE IF NOT EXISTS `product_model` (
+    `code`            INT          NOT NULL,
+    `name_ko`         VARCHAR(128) NOT NULL,
+    `name_en`         VARCHAR(128) NOT NULL,
+    `model_no`        VARCHAR(64)  NOT NULL DEFAULT '',
+    `release_date`    DATE         NULL,
+    `warranty_months` INT          NOT NULL DEFAULT 12,
+    `active`          TINYINT(1)   NOT NULL DEFAULT 1,
+    `image_url`       VARCHAR(255) NOT NULL DEFAULT '',
+    `regdate`         DATETIME     NOT NULL,
+    `modified`        DATETIME     NOT NULL,
+    PRIMARY KEY (`code`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+-- 기존 ProductType 상수
+INSERT INTO `product_model` (`code`, `name_ko`, `name_en`, `regdate`, `modified`) VALUES
+    (0, '버들맘마 분유제조기 플러스', 'Buddle Mamma Formula Maker Plus', NOW(), NOW()),
+    (1, '버들맘마 분유제조기 스마트', 'Buddle Mamma Formula Maker Smart', NOW(), NOW()),
+    (2, '버들아이 젖병세척기', 'Buddle Kids Baby Bottle Washer', NOW(), NOW()),
+    (3, '버들 스마트 차퍼', 'Buddle Smart Chopper', NOW(), NOW());
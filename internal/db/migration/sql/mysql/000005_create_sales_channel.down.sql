@@ -0,0 +1,7 @@
+ALTER TABLE `after_service`
+    DROP COLUMN `order_no`;
+
+ALTER TABLE `product_regist`
+    DROP COLUMN `order_no`;
+
+DROP TABLE IF EXISTS `sales_channel`;
@@ -0,0 +1,24 @@
+-- 판매 채널 카탈로그. product_regist.market_type, after_service.market_type 은 code 를 참조한다.
+CREATE TABLE IF NOT EXISTS `sales_channel` (
+    `code`              INT          NOT NULL,
+    `name_ko`           VARCHAR(128) NOT NULL,
+    `name_en`           VARCHAR(128) NOT NULL,
+    `active`            TINYINT(1)   NOT NULL DEFAULT 1,
+    `order_no_required` TINYINT(1)   NOT NULL DEFAULT 0,
+    `regdate`           DATETIME     NOT NULL,
+    `modified`          DATETIME     NOT NULL,
+    PRIMARY KEY (`code`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
+
+-- 기존 MarketType 상수
+INSERT INTO `sales_channel` (`code`, `name_ko`, `name_en`, `regdate`, `modified`) VALUES
+    (0, '네이버', 'Naver', NOW(), NOW()),
+    (1, '쿠팡', 'Coupang', NOW(), NOW()),
+    (2, '오프라인 매장', 'Offline store', NOW(), NOW()),
+    (3, '기타', 'Other', NOW(), NOW());
+
+ALTER TABLE `product_regist`
+    ADD COLUMN `order_no` VARCHAR(64) NOT NULL DEFAULT '' AFTER `market_type`;
+
+ALTER TABLE `after_service`
+    ADD COLUMN `order_no` VARCHAR(64) NOT NULL DEFAULT '' AFTER `market_type`;
@@ -0,0 +1 @@
+ALTER TABLE `after_service` DROP COLUMN `warranty_status`;
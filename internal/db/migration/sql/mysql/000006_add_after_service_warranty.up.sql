@@ -0,0 +1,3 @@
+-- A/S 신청 시점의 무상 보증 상태 ( model.WarrantyStatus )
+ALTER TABLE `after_service`
+    ADD COLUMN `warranty_status` TINYINT NOT NULL DEFAULT 0 AFTER `purchase_date`;
@@ -0,0 +1,4 @@
+ALTER TABLE `after_service`
+    DROP INDEX `idx_after_service_product_seq`,
+    DROP COLUMN `product_regist_seq`,
+    DROP COLUMN `product_seq`;
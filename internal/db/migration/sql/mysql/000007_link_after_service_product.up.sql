@@ -0,0 +1,5 @@
+-- A/S 신청과 인증된 제품의 연결 ( 시리얼 번호로 신청한 경우에만 값을 가진다 )
+ALTER TABLE `after_service`
+    ADD COLUMN `product_seq`        BIGINT NOT NULL DEFAULT 0 AFTER `after_service_seq`,
+    ADD COLUMN `product_regist_seq` BIGINT NOT NULL DEFAULT 0 AFTER `product_seq`,
+    ADD INDEX `idx_after_service_product_seq` (`product_seq`);
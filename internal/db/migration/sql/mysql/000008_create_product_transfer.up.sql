@@ -0,0 +1,15 @@
+-- 제품 소유권 이전. 양도인의 인증 정보( from )는 이전 완료(status = 3)로 남고 양수인의 인증 정보( to )가 새로 등록된다.
+CREATE TABLE IF NOT EXISTS `product_transfer` (
+    `product_transfer_seq`    BIGINT      NOT NULL AUTO_INCREMENT,
+    `product_seq`             BIGINT      NOT NULL,
+    `from_product_regist_seq` BIGINT      NOT NULL,
+    `to_product_regist_seq`   BIGINT      NOT NULL DEFAULT 0,
+    `transfer_code`           VARCHAR(16) NOT NULL,
+    `status`                  INT         NOT NULL DEFAULT 0,
+    `expire_date`             DATETIME    NOT NULL,
+    `regdate`                 DATETIME    NOT NULL,
+    `modified`                DATETIME    NOT NULL,
+    PRIMARY KEY (`product_transfer_seq`),
+    KEY `idx_product_transfer_product_seq_code` (`product_seq`, `transfer_code`),
+    KEY `idx_product_transfer_from_product_regist_seq` (`from_product_regist_seq`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_unicode_ci;
@@ -0,0 +1,8 @@
+-- 적용 전에 인증 대기(status = 4), 반려(status = 5) 정보를 정리해야 한다.
+ALTER TABLE `product_regist`
+    DROP INDEX `uk_product_regist_active_product_seq`,
+    DROP COLUMN `active_product_seq`;
+ALTER TABLE `product_regist`
+    ADD COLUMN `active_product_seq` BIGINT GENERATED ALWAYS AS (IF(`status` = 2, `product_seq`, NULL)) STORED,
+    ADD UNIQUE KEY `uk_product_regist_active_product_seq` (`active_product_seq`),
+    DROP COLUMN `reject_reason`;
@@ -0,0 +1,11 @@
+-- 영수증 확인 반려 사유
+ALTER TABLE `product_regist`
+    ADD COLUMN `reject_reason` VARCHAR(255) NOT NULL DEFAULT '' AFTER `status`;
+
+-- 제품당 하나의 인증 완료(status = 2) 또는 인증 대기(status = 4) 정보만 존재하도록 한다.
+ALTER TABLE `product_regist`
+    DROP INDEX `uk_product_regist_active_product_seq`,
+    DROP COLUMN `active_product_seq`;
+ALTER TABLE `product_regist`
+    ADD COLUMN `active_product_seq` BIGINT GENERATED ALWAYS AS (IF(`status` IN (2, 4), `product_seq`, NULL)) STORED,
+    ADD UNIQUE KEY `uk_product_regist_active_product_seq` (`active_product_seq`);
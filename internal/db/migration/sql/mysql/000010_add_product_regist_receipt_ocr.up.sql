@@ -0,0 +1,3 @@
+-- 영수증 OCR 로 추출한 구매일, 판매처, 금액 후보 ( model.ReceiptCandidates, JSON ). OCR 을 사용하지 않으면 NULL.
+ALTER TABLE `product_regist`
+    ADD COLUMN `receipt_ocr` TEXT NULL AFTER `receipt_sha256`;
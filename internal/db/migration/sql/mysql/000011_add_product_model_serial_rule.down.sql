@@ -0,0 +1,5 @@
+ALTER TABLE `product_model`
+    DROP COLUMN `serial_check_digit`,
+    DROP COLUMN `serial_charset`,
+    DROP COLUMN `serial_length`,
+    DROP COLUMN `serial_prefix`;
@@ -0,0 +1,6 @@
+-- 제품 모델별 시리얼 번호 형식 ( model.SerialRule ). 기본값은 형식 제한 없음.
+ALTER TABLE `product_model`
+    ADD COLUMN `serial_prefix`      VARCHAR(32) NOT NULL DEFAULT '' AFTER `image_url`,
+    ADD COLUMN `serial_length`      INT         NOT NULL DEFAULT 0 AFTER `serial_prefix`,
+    ADD COLUMN `serial_charset`     VARCHAR(16) NOT NULL DEFAULT '' AFTER `serial_length`,
+    ADD COLUMN `serial_check_digit` TINYINT(1)  NOT NULL DEFAULT 0 AFTER `serial_charset`;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `user`;
+DROP TABLE IF EXISTS `after_service`;
+DROP TABLE IF EXISTS `product_regist`;
+DROP TABLE IF EXISTS `product`;
@@ -0,0 +1,65 @@
+CREATE TABLE IF NOT EXISTS `product` (
+    `product_seq`  INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    `serial_no`    TEXT     NOT NULL,
+    `product_type` INTEGER  NOT NULL DEFAULT 0,
+    `regdate`      DATETIME NOT NULL,
+    `modified`     DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS `idx_product_serial_no` ON `product` (`serial_no`);
+
+CREATE TABLE IF NOT EXISTS `product_regist` (
+    `product_regist_seq`  INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    `product_seq`         INTEGER  NOT NULL REFERENCES `product` (`product_seq`),
+    `name`                TEXT     NOT NULL DEFAULT '',
+    `phone`               TEXT     NOT NULL DEFAULT '',
+    `addr`                TEXT     NOT NULL DEFAULT '',
+    `addr_detail`         TEXT     NOT NULL DEFAULT '',
+    `market_type`         INTEGER  NOT NULL DEFAULT 0,
+    `status`              INTEGER  NOT NULL DEFAULT 0,
+    `purchase_date`       DATETIME NOT NULL,
+    `receipt_s3_location` TEXT     NOT NULL DEFAULT '',
+    `regdate`             DATETIME NOT NULL,
+    `modified`            DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS `idx_product_regist_product_seq_status` ON `product_regist` (`product_seq`, `status`);
+CREATE INDEX IF NOT EXISTS `idx_product_regist_name_phone` ON `product_regist` (`name`, `phone`);
+CREATE INDEX IF NOT EXISTS `idx_product_regist_receipt_s3_location` ON `product_regist` (`receipt_s3_location`);
+
+CREATE TABLE IF NOT EXISTS `after_service` (
+    `after_service_seq` INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    `name`              TEXT     NOT NULL DEFAULT '',
+    `phone`             TEXT     NOT NULL DEFAULT '',
+    `email`             TEXT     NOT NULL DEFAULT '',
+    `addr`              TEXT     NOT NULL DEFAULT '',
+    `addr_detail`       TEXT     NOT NULL DEFAULT '',
+    `product_type`      INTEGER  NOT NULL DEFAULT 0,
+    `market_type`       INTEGER  NOT NULL DEFAULT 0,
+    `purchase_date`     DATETIME NOT NULL,
+    `file1_s3_location` TEXT     NOT NULL DEFAULT '',
+    `file2_s3_location` TEXT     NOT NULL DEFAULT '',
+    `file3_s3_location` TEXT     NOT NULL DEFAULT '',
+    `file4_s3_location` TEXT     NOT NULL DEFAULT '',
+    `file5_s3_location` TEXT     NOT NULL DEFAULT '',
+    `contents`          TEXT     NOT NULL DEFAULT '',
+    `regdate`           DATETIME NOT NULL,
+    `modified`          DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS `idx_after_service_name_phone` ON `after_service` (`name`, `phone`);
+CREATE INDEX IF NOT EXISTS `idx_after_service_regdate` ON `after_service` (`regdate`);
+
+CREATE TABLE IF NOT EXISTS `user` (
+    `user_seq`  INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    `id`        TEXT     NOT NULL,
+    `password`  TEXT     NOT NULL,
+    `user_type` INTEGER  NOT NULL DEFAULT 0,
+    `name`      TEXT     NOT NULL DEFAULT '',
+    `phone`     TEXT     NOT NULL DEFAULT '',
+    `birth`     TEXT     NOT NULL DEFAULT '',
+    `regdate`   DATETIME NOT NULL,
+    `modified`  DATETIME NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS `uk_user_id` ON `user` (`id`);
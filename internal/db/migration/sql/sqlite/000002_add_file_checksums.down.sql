@@ -0,0 +1,7 @@
+ALTER TABLE `after_service` DROP COLUMN `file5_sha256`;
+ALTER TABLE `after_service` DROP COLUMN `file4_sha256`;
+ALTER TABLE `after_service` DROP COLUMN `file3_sha256`;
+ALTER TABLE `after_service` DROP COLUMN `file2_sha256`;
+ALTER TABLE `after_service` DROP COLUMN `file1_sha256`;
+
+ALTER TABLE `product_regist` DROP COLUMN `receipt_sha256`;
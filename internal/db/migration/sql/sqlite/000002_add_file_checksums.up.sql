@@ -0,0 +1,7 @@
+ALTER TABLE `product_regist` ADD COLUMN `receipt_sha256` TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE `after_service` ADD COLUMN `file1_sha256` TEXT NOT NULL DEFAULT '';
+ALTER TABLE `after_service` ADD COLUMN `file2_sha256` TEXT NOT NULL DEFAULT '';
+ALTER TABLE `after_service` ADD COLUMN `file3_sha256` TEXT NOT NULL DEFAULT '';
+ALTER TABLE `after_service` ADD COLUMN `file4_sha256` TEXT NOT NULL DEFAULT '';
+ALTER TABLE `after_service` ADD COLUMN `file5_sha256` TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS `uk_product_regist_active_product_seq`;
+
+DROP INDEX IF EXISTS `uk_product_serial_no_product_type`;
+CREATE INDEX `idx_product_serial_no` ON `product` (`serial_no`);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS `idx_product_serial_no`;
+CREATE UNIQUE INDEX `uk_product_serial_no_product_type` ON `product` (`serial_no`, `product_type`);
+
+-- 제품당 하나의 인증 완료(status = 2) 정보만 존재하도록 부분 인덱스를 사용한다.
+CREATE UNIQUE INDEX `uk_product_regist_active_product_seq` ON `product_regist` (`product_seq`) WHERE `status` = 2;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS `product_model` (
+    `code`            INTEGER  NOT NULL PRIMARY KEY,
+    `name_ko`         TEXT     NOT NULL,
+    `name_en`         TEXT     NOT NULL,
+    `model_no`        TEXT     NOT NULL DEFAULT '',
+    `release_date`    DATETIME NULL,
+    `warranty_months` INTEGER  NOT NULL DEFAULT 12,
+    `active`          INTEGER  NOT NULL DEFAULT 1,
+    `image_url`       TEXT     NOT NULL DEFAULT '',
+    `regdate`         DATETIME NOT NULL,
+    `modified`        DATETIME NOT NULL
+);
+
+INSERT INTO `product_model` (`code`, `name_ko`, `name_en`, `regdate`, `modified`) VALUES
+    (0, '버들맘마 분유제조기 플러스', 'Buddle Mamma Formula Maker Plus', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    (1, '버들맘마 분유제조기 스마트', 'Buddle Mamma Formula Maker Smart', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    (2, '버들아이 젖병세척기', 'Buddle Kids Baby Bottle Washer', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    (3, '버들 스마트 차퍼', 'Buddle Smart Chopper', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
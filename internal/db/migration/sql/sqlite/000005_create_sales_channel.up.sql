@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS `sales_channel` (
+    `code`              INTEGER  NOT NULL PRIMARY KEY,
+    `name_ko`           TEXT     NOT NULL,
+    `name_en`           TEXT     NOT NULL,
+    `active`            INTEGER  NOT NULL DEFAULT 1,
+    `order_no_required` INTEGER  NOT NULL DEFAULT 0,
+    `regdate`           DATETIME NOT NULL,
+    `modified`          DATETIME NOT NULL
+);
+
+INSERT INTO `sales_channel` (`code`, `name_ko`, `name_en`, `regdate`, `modified`) VALUES
+    (0, '네이버', 'Naver', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    (1, '쿠팡', 'Coupang', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    (2, '오프라인 매장', 'Offline store', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+    (3, '기타', 'Other', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
+
+ALTER TABLE `product_regist` ADD COLUMN `order_no` TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE `after_service` ADD COLUMN `order_no` TEXT NOT NULL DEFAULT '';
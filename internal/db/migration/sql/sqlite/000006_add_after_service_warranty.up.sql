@@ -0,0 +1 @@
+ALTER TABLE `after_service` ADD COLUMN `warranty_status` INTEGER NOT NULL DEFAULT 0;
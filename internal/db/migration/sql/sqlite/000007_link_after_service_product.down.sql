@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS `idx_after_service_product_seq`;
+
+ALTER TABLE `after_service` DROP COLUMN `product_regist_seq`;
+ALTER TABLE `after_service` DROP COLUMN `product_seq`;
@@ -0,0 +1,4 @@
+ALTER TABLE `after_service` ADD COLUMN `product_seq` INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE `after_service` ADD COLUMN `product_regist_seq` INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX `idx_after_service_product_seq` ON `after_service` (`product_seq`);
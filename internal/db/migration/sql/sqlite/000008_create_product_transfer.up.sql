@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS `product_transfer` (
+    `product_transfer_seq`    INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    `product_seq`             INTEGER  NOT NULL,
+    `from_product_regist_seq` INTEGER  NOT NULL,
+    `to_product_regist_seq`   INTEGER  NOT NULL DEFAULT 0,
+    `transfer_code`           TEXT     NOT NULL,
+    `status`                  INTEGER  NOT NULL DEFAULT 0,
+    `expire_date`             DATETIME NOT NULL,
+    `regdate`                 DATETIME NOT NULL,
+    `modified`                DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS `idx_product_transfer_product_seq_code` ON `product_transfer` (`product_seq`, `transfer_code`);
+CREATE INDEX IF NOT EXISTS `idx_product_transfer_from_product_regist_seq` ON `product_transfer` (`from_product_regist_seq`);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS `uk_product_regist_active_product_seq`;
+CREATE UNIQUE INDEX `uk_product_regist_active_product_seq` ON `product_regist` (`product_seq`) WHERE `status` = 2;
+
+ALTER TABLE `product_regist` DROP COLUMN `reject_reason`;
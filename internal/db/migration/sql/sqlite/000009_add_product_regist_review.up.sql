@@ -0,0 +1,5 @@
+ALTER TABLE `product_regist` ADD COLUMN `reject_reason` TEXT NOT NULL DEFAULT '';
+
+-- 제품당 하나의 인증 완료(status = 2) 또는 인증 대기(status = 4) 정보만 존재하도록 부분 인덱스를 사용한다.
+DROP INDEX IF EXISTS `uk_product_regist_active_product_seq`;
+CREATE UNIQUE INDEX `uk_product_regist_active_product_seq` ON `product_regist` (`product_seq`) WHERE `status` IN (2, 4);
@@ -0,0 +1 @@
+ALTER TABLE `product_regist` DROP COLUMN `receipt_ocr`;
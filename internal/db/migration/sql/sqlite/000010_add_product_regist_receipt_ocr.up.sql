@@ -0,0 +1 @@
+ALTER TABLE `product_regist` ADD COLUMN `receipt_ocr` TEXT NULL;
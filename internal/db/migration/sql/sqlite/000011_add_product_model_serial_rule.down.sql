@@ -0,0 +1,4 @@
+ALTER TABLE `product_model` DROP COLUMN `serial_check_digit`;
+ALTER TABLE `product_model` DROP COLUMN `serial_charset`;
+ALTER TABLE `product_model` DROP COLUMN `serial_length`;
+ALTER TABLE `product_model` DROP COLUMN `serial_prefix`;
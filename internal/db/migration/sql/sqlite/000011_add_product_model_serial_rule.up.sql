@@ -0,0 +1,4 @@
+ALTER TABLE `product_model` ADD COLUMN `serial_prefix` TEXT NOT NULL DEFAULT '';
+ALTER TABLE `product_model` ADD COLUMN `serial_length` INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE `product_model` ADD COLUMN `serial_charset` TEXT NOT NULL DEFAULT '';
+ALTER TABLE `product_model` ADD COLUMN `serial_check_digit` INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,105 @@
+// Package i18n 은 응답 메시지의 언어( Accept-Language )를 다룬다.
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Language string
+
+const (
+	Korean  Language = "ko"
+	English Language = "en"
+
+	DefaultLanguage = Korean
+)
+
+// Languages 는 지원하는 언어 목록
+var Languages = []Language{Korean, English}
+
+func (l Language) supported() bool {
+	for _, lang := range Languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// Messages 는 언어별 메시지. 번역이 없으면 DefaultLanguage 메시지를 사용한다.
+type Messages map[Language]string
+
+func (m Messages) In(lang Language) string {
+	if msg, ok := m[lang]; ok && msg != "" {
+		return msg
+	}
+	return m[DefaultLanguage]
+}
+
+// ParseAcceptLanguage 는 Accept-Language 헤더에서 지원하는 언어 중 우선순위가 가장 높은 언어를 반환한다.
+// ( 예: "en-US,en;q=0.9,ko;q=0.8" => English ) 지원하는 언어가 없으면 DefaultLanguage.
+func ParseAcceptLanguage(header string) Language {
+	type candidate struct {
+		lang    Language
+		quality float64
+	}
+
+	candidates := make([]candidate, 0)
+	for _, part := range strings.Split(header, ",") {
+		tag, params := strings.TrimSpace(part), ""
+		if i := strings.Index(tag, ";"); i >= 0 {
+			tag, params = strings.TrimSpace(tag[:i]), tag[i+1:]
+		}
+
+		// 지역 코드는 무시한다. ( en-US => en )
+		if i := strings.IndexAny(tag, "-_"); i >= 0 {
+			tag = tag[:i]
+		}
+		lang := Language(strings.ToLower(tag))
+		if !lang.supported() {
+			continue
+		}
+
+		quality := 1.0
+		if q := strings.TrimSpace(params); strings.HasPrefix(q, "q=") {
+			v, err := strconv.ParseFloat(strings.TrimPrefix(q, "q="), 64)
+			if err != nil {
+				continue
+			}
+			quality = v
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		candidates = append(candidates, candidate{lang: lang, quality: quality})
+	}
+
+	if len(candidates) == 0 {
+		return DefaultLanguage
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].lang
+}
+
+type languageKey struct{}
+
+func ContextWithLanguage(c context.Context, lang Language) context.Context {
+	return context.WithValue(c, languageKey{}, lang)
+}
+
+// FromContext 는 context 의 응답 언어. 설정되지 않았으면 DefaultLanguage.
+func FromContext(c context.Context) Language {
+	if c != nil {
+		if lang, ok := c.Value(languageKey{}).(Language); ok {
+			return lang
+		}
+	}
+	return DefaultLanguage
+}
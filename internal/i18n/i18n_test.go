@@ -0,0 +1,44 @@
+package i18n_test
+
+import (
+	"buddle-server/internal/i18n"
+	"context"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   i18n.Language
+	}{
+		{"", i18n.Korean},
+		{"en", i18n.English},
+		{"en-US,en;q=0.9", i18n.English},
+		{"ko-KR,ko;q=0.9,en-US;q=0.8,en;q=0.7", i18n.Korean},
+		{"ja,en;q=0.5", i18n.English},
+		{"ko;q=0.3,EN;q=0.8", i18n.English},
+		{"en;q=0,ko", i18n.Korean},
+		{"fr, de", i18n.Korean},
+		{"*", i18n.Korean},
+	}
+
+	for _, tt := range tests {
+		if got := i18n.ParseAcceptLanguage(tt.header); got != tt.want {
+			t.Errorf("ParseAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestMessages_In(t *testing.T) {
+	msgs := i18n.Messages{i18n.Korean: "성공하였습니다."}
+	if got := msgs.In(i18n.English); got != "성공하였습니다." {
+		t.Errorf("fallback = %q", got)
+	}
+
+	if got := i18n.FromContext(context.Background()); got != i18n.DefaultLanguage {
+		t.Errorf("FromContext = %q", got)
+	}
+	if got := i18n.FromContext(i18n.ContextWithLanguage(context.Background(), i18n.English)); got != i18n.English {
+		t.Errorf("FromContext = %q", got)
+	}
+}
@@ -0,0 +1,60 @@
+package ocr
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+)
+
+// Provider 는 영수증 이미지에서 텍스트를 추출하는 OCR 서비스
+type Provider interface {
+	Name() string
+	Recognize(c context.Context, image io.Reader) (string, error)
+}
+
+// Config 는 영수증 OCR 설정. Provider 가 비어있으면 OCR 을 사용하지 않는다.
+type Config struct {
+	Provider string `json:"provider" yaml:"provider"` // plaintext
+}
+
+// NewProvider 는 설정의 OCR 서비스를 생성한다. 사용하지 않으면 nil 을 반환한다.
+func NewProvider(c Config) (Provider, error) {
+	switch c.Provider {
+	case "":
+		return nil, nil
+	case PlainTextProviderName:
+		return PlainText{}, nil
+	}
+
+	return nil, errors.Errorf("unknown ocr provider(%s)", c.Provider)
+}
+
+// PlainTextProviderName 은 PlainText 의 설정 이름
+const PlainTextProviderName = "plaintext"
+
+// plainTextMaxSize 는 PlainText 가 읽는 최대 크기
+const plainTextMaxSize = 1 << 20
+
+// PlainText 는 업로드한 파일을 이미 추출된 텍스트로 보고 그대로 반환하는 로컬 개발, 테스트용 Provider
+type PlainText struct{}
+
+func (PlainText) Name() string {
+	return PlainTextProviderName
+}
+
+func (PlainText) Recognize(c context.Context, image io.Reader) (string, error) {
+	switch {
+	case c == nil:
+		return "", errors.New("nil context")
+	case image == nil:
+		return "", errors.New("nil image")
+	}
+
+	b, err := ioutil.ReadAll(io.LimitReader(image, plainTextMaxSize))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read receipt")
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,96 @@
+package ocr
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCandidates 는 항목별로 추출하는 최대 후보 개수
+const maxCandidates = 5
+
+// Receipt 는 영수증 텍스트에서 추출한 구매일, 판매처, 금액 후보 ( 나타난 순서 )
+type Receipt struct {
+	Dates     []time.Time
+	Merchants []string
+	Amounts   []int64
+}
+
+var (
+	// 2022-03-01, 2022.03.01, 2022/3/1, 2022년 3월 1일
+	datePattern = regexp.MustCompile(`(20\d{2})\s*[-./년]\s*(\d{1,2})\s*[-./월]\s*(\d{1,2})`)
+	// 상호: 쿠팡(주), 판매처 네이버 스마트스토어
+	merchantPattern = regexp.MustCompile(`(?i)^\s*(?:상호명?|가맹점명?|판매처|판매자|매장명?|store|merchant|seller)\s*[:：]?\s*(.+?)\s*$`)
+	// 합계 39,000원, 결제금액: 39000
+	amountLinePattern = regexp.MustCompile(`(?i)(합계|총액|결제\s*금액|판매\s*금액|total|amount)`)
+	amountPattern     = regexp.MustCompile(`\d{1,3}(?:,\d{3})+|\d+`)
+)
+
+// ParseReceipt 는 OCR 로 추출한 영수증 텍스트에서 구매일, 판매처, 금액 후보를 찾는다.
+func ParseReceipt(text string) Receipt {
+	var r Receipt
+	for _, line := range strings.Split(text, "\n") {
+		for _, m := range datePattern.FindAllStringSubmatch(line, -1) {
+			if date, ok := parseDate(m[1], m[2], m[3]); ok && !containsDate(r.Dates, date) && len(r.Dates) < maxCandidates {
+				r.Dates = append(r.Dates, date)
+			}
+		}
+
+		if m := merchantPattern.FindStringSubmatch(line); m != nil && len(r.Merchants) < maxCandidates {
+			r.Merchants = appendUnique(r.Merchants, m[1])
+		}
+
+		if loc := amountLinePattern.FindStringIndex(line); loc != nil {
+			// 구매일 등 다른 숫자를 금액으로 보지 않도록 항목명 뒤의 첫 숫자만 사용한다.
+			if m := amountPattern.FindString(line[loc[1]:]); m != "" && len(r.Amounts) < maxCandidates {
+				if amount, err := strconv.ParseInt(strings.ReplaceAll(m, ",", ""), 10, 64); err == nil && amount > 0 && !containsAmount(r.Amounts, amount) {
+					r.Amounts = append(r.Amounts, amount)
+				}
+			}
+		}
+	}
+
+	return r
+}
+
+func parseDate(year, month, day string) (time.Time, bool) {
+	y, _ := strconv.Atoi(year)
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+
+	date := time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	// 2022-02-30 처럼 존재하지 않는 날짜는 제외한다.
+	if date.Year() != y || int(date.Month()) != m || date.Day() != d {
+		return time.Time{}, false
+	}
+
+	return date, true
+}
+
+func containsDate(dates []time.Time, date time.Time) bool {
+	for _, d := range dates {
+		if d.Equal(date) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAmount(amounts []int64, amount int64) bool {
+	for _, a := range amounts {
+		if a == amount {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
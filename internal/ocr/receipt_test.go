@@ -0,0 +1,59 @@
+package ocr_test
+
+import (
+	"buddle-server/internal/ocr"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseReceipt(t *testing.T) {
+	text := `쿠팡 주문 영수증
+상호: 쿠팡(주)
+주문일시 2022.03.01 14:32
+배송완료 2022년 3월 3일
+잘못된 날짜 2022-02-30
+판매금액 35,000원
+합계: 39,000 원
+TOTAL 39000`
+
+	got := ocr.ParseReceipt(text)
+
+	wantDates := []time.Time{
+		time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 3, 3, 0, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(got.Dates, wantDates) {
+		t.Errorf("Dates = %v, want %v", got.Dates, wantDates)
+	}
+	if want := []string{"쿠팡(주)"}; !reflect.DeepEqual(got.Merchants, want) {
+		t.Errorf("Merchants = %v, want %v", got.Merchants, want)
+	}
+	if want := []int64{35000, 39000}; !reflect.DeepEqual(got.Amounts, want) {
+		t.Errorf("Amounts = %v, want %v", got.Amounts, want)
+	}
+
+	if empty := ocr.ParseReceipt("no receipt text"); len(empty.Dates)+len(empty.Merchants)+len(empty.Amounts) != 0 {
+		t.Errorf("ParseReceipt(no candidates) = %+v", empty)
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	if p, err := ocr.NewProvider(ocr.Config{}); p != nil || err != nil {
+		t.Errorf("NewProvider(disabled) = %v, %v, want nil, nil", p, err)
+	}
+	if _, err := ocr.NewProvider(ocr.Config{Provider: "unknown"}); err == nil {
+		t.Errorf("NewProvider(unknown) error = nil")
+	}
+
+	p, err := ocr.NewProvider(ocr.Config{Provider: ocr.PlainTextProviderName})
+	if err != nil {
+		t.Fatalf("NewProvider(plaintext) error = %+v", err)
+	}
+	text, err := p.Recognize(context.Background(), strings.NewReader("합계 39,000원"))
+	if err != nil || text != "합계 39,000원" {
+		t.Errorf("Recognize() = %q, %v", text, err)
+	}
+}
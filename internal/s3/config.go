@@ -4,6 +4,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
 )
 
 type Config struct {
@@ -15,6 +17,27 @@ type Config struct {
 	DisableSSL     bool   `yaml:"disable_ssl"`
 	Bucket         string `json:"bucket" yaml:"bucket"`
 	ForcePathStyle bool   `yaml:"force_path_style"`
+
+	// ServerSideEncryption 은 업로드 객체의 서버측 암호화 방식 ( AES256: SSE-S3, aws:kms: SSE-KMS )
+	ServerSideEncryption string `json:"server_side_encryption" yaml:"server_side_encryption"`
+	KMSKeyID             string `json:"kms_key_id" yaml:"kms_key_id"`
+
+	// LocalPath 가 설정되면 S3 대신 로컬 디스크를 저장소로 사용한다. ( 개발용 )
+	LocalPath string `json:"local_path" yaml:"local_path"`
+}
+
+func (c Config) validateEncryption() error {
+	switch c.ServerSideEncryption {
+	case "", awss3.ServerSideEncryptionAes256:
+		if c.KMSKeyID != "" {
+			return errors.New("kms_key_id requires server_side_encryption aws:kms")
+		}
+	case awss3.ServerSideEncryptionAwsKms:
+	default:
+		return errors.Errorf("server_side_encryption(%s) is invalid", c.ServerSideEncryption)
+	}
+
+	return nil
 }
 
 func (c Config) createSession() (*session.Session, error) {
@@ -0,0 +1,33 @@
+package s3
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"path"
+	"strings"
+)
+
+const (
+	MetadataOriginalFilename = "Original-Filename"
+	MetadataLegacyKey        = "Legacy-Key"
+)
+
+// NewObjectKey 는 prefix 하위에 추측할 수 없는 임의의 객체 키를 생성한다.
+func NewObjectKey(prefix string) (string, error) {
+	if prefix == "" {
+		return "", ErrEmptyPrefix
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate random object id")
+	}
+
+	return path.Join(prefix, hex.EncodeToString(b)), nil
+}
+
+// HasPrefix 는 key 가 NewObjectKey(prefix) 로 생성된 키인지 확인한다.
+func HasPrefix(key, prefix string) bool {
+	return strings.HasPrefix(key, strings.TrimSuffix(prefix, "/")+"/")
+}
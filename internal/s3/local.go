@@ -0,0 +1,234 @@
+package s3
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local 은 로컬 디스크를 사용하는 개발용 저장소. 객체 키는 root 하위의 경로가 되며
+// Upload, Download 의 체크섬 동작은 S3 와 같다. 객체 메타데이터와 서버측 암호화 설정은 무시한다.
+type Local struct {
+	root       string
+	bucketName string
+}
+
+func NewLocal(c Config) (*Local, error) {
+	if c.LocalPath == "" {
+		return nil, errors.New("empty local path")
+	}
+
+	root, err := filepath.Abs(c.LocalPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get absolute local path")
+	}
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return nil, errors.Wrap(err, "failed to create local storage directory")
+	}
+
+	bucketName := c.Bucket
+	if bucketName == "" {
+		bucketName = root
+	}
+
+	return &Local{root: root, bucketName: bucketName}, nil
+}
+
+func (l *Local) BucketName() string {
+	return l.bucketName
+}
+
+func (l *Local) path(key string) (string, error) {
+	if len(key) == 0 {
+		return "", ErrEmptyKey
+	}
+
+	p := filepath.Join(l.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(p, l.root+string(filepath.Separator)) {
+		return "", errors.Errorf("invalid key(%s)", key)
+	}
+
+	return p, nil
+}
+
+func (l *Local) IsExist(c context.Context, key string) error {
+	if c == nil {
+		return ErrNilContext
+	}
+
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(p); err != nil {
+		if os.IsNotExist(err) {
+			return ErrObjectNotFound
+		}
+		return errors.Wrap(err, "stat object")
+	}
+
+	return nil
+}
+
+func (l *Local) Upload(c context.Context, key string, file io.Reader, opts ...UploadOption) (string, error) {
+	switch {
+	case c == nil:
+		return "", ErrNilContext
+	case file == nil:
+		return "", errors.New("nil Body")
+	}
+
+	p, err := l.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return "", errors.Wrap(err, "create object directory")
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return "", errors.Wrap(err, "create temp object")
+	}
+	defer os.Remove(tmp.Name())
+
+	body := newChecksumReader(file)
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return "", errors.Wrap(err, "put object")
+	}
+	if err := tmp.Close(); err != nil {
+		return "", errors.Wrap(err, "close temp object")
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return "", errors.Wrap(err, "put object")
+	}
+
+	return body.Sum(), nil
+}
+
+func (l *Local) Download(c context.Context, writer io.WriterAt, key string, opts ...DownloadOption) (int64, error) {
+	switch {
+	case c == nil:
+		return 0, ErrNilContext
+	case writer == nil:
+		return 0, ErrNilWriter
+	}
+
+	p, err := l.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, errors.Wrap(ErrObjectNotFound, "download object")
+		}
+		return 0, errors.Wrap(err, "download object")
+	}
+	defer f.Close()
+
+	var n int64
+	buf := make([]byte, 32*1024)
+	for {
+		read, err := f.Read(buf)
+		if read > 0 {
+			if _, err := writer.WriteAt(buf[:read], n); err != nil {
+				return 0, errors.Wrap(err, "write object")
+			}
+			n += int64(read)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, errors.Wrap(err, "download object")
+		}
+	}
+
+	if err := newDownloadOptions(opts...).verify(writer, n); err != nil {
+		return 0, errors.Wrapf(err, "verify object [ key = %s ]", key)
+	}
+
+	return n, nil
+}
+
+func (l *Local) Delete(c context.Context, key string) error {
+	if c == nil {
+		return ErrNilContext
+	}
+
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "delete object")
+	}
+
+	return nil
+}
+
+func (l *Local) Copy(c context.Context, srcKey, dstKey string, metadata map[string]string) error {
+	if c == nil {
+		return ErrNilContext
+	}
+
+	src, err := l.path(srcKey)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(src)
+	if err != nil {
+		return errors.Wrap(err, "copy object")
+	}
+	defer f.Close()
+
+	if _, err := l.Upload(c, dstKey, f); err != nil {
+		return errors.Wrap(err, "copy object")
+	}
+
+	return nil
+}
+
+func (l *Local) List(c context.Context, prefix string) (*ObjectListResponse, error) {
+	if c == nil {
+		return nil, ErrNilContext
+	}
+
+	result := &ObjectListResponse{Prefix: prefix, Objects: make(Objects, 0)}
+	if err := filepath.Walk(l.root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(l.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		result.Objects = append(result.Objects, Object{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		result.TotalSize += info.Size()
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "list objects")
+	}
+	result.ObjectCount = len(result.Objects)
+
+	return result, nil
+}
@@ -10,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 var (
@@ -30,6 +31,8 @@ type S3 struct {
 	downloader *s3manager.Downloader
 	bucketName string
 	region     string
+	sse        string
+	kmsKeyID   string
 }
 
 var DefaultDownloaderOption = func(uploader *s3manager.Downloader) {
@@ -37,6 +40,10 @@ var DefaultDownloaderOption = func(uploader *s3manager.Downloader) {
 }
 
 func New(c Config) (*S3, error) {
+	if err := c.validateEncryption(); err != nil {
+		return nil, errors.Wrap(err, "invalid encryption config")
+	}
+
 	sess, err := c.createSession()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create session")
@@ -54,6 +61,8 @@ func New(c Config) (*S3, error) {
 		downloader: s3manager.NewDownloaderWithClient(s, DefaultDownloaderOption),
 		bucketName: c.Bucket,
 		region:     c.Region,
+		sse:        c.ServerSideEncryption,
+		kmsKeyID:   c.KMSKeyID,
 	}, nil
 }
 
@@ -117,15 +126,35 @@ func SetTags(tags ...Tag) UploadOption {
 	}
 }
 
-func (s *S3) Upload(c context.Context, key string, file io.Reader, opts ...UploadOption) error {
+func SetMetadata(metadata map[string]string) UploadOption {
+	return func(input *s3manager.UploadInput) {
+		if input.Metadata == nil {
+			input.Metadata = make(map[string]*string)
+		}
+		for k, v := range metadata {
+			// 메타데이터는 HTTP 헤더로 전달되므로 ASCII 로 인코딩
+			input.Metadata[k] = aws.String(url.QueryEscape(v))
+		}
+	}
+}
+
+// Upload 는 객체를 업로드하고 업로드한 내용의 SHA-256(hex)을 반환한다.
+func (s *S3) Upload(c context.Context, key string, file io.Reader, opts ...UploadOption) (string, error) {
 	switch {
 	case c == nil:
-		return ErrNilContext
+		return "", ErrNilContext
 	case len(key) == 0:
-		return ErrEmptyKey
+		return "", ErrEmptyKey
+	case file == nil:
+		return "", errors.New("nil Body")
+	}
+
+	body := newChecksumReader(file)
+	if err := s.upload(c, key, body, opts...); err != nil {
+		return "", err
 	}
 
-	return s.upload(c, key, file, opts...)
+	return body.Sum(), nil
 }
 
 func (s *S3) upload(c context.Context, key string, body io.Reader, opts ...UploadOption) error {
@@ -142,7 +171,12 @@ func (s *S3) upload(c context.Context, key string, body io.Reader, opts ...Uploa
 		Body:   body,
 		Bucket: aws.String(s.BucketName()),
 		Key:    aws.String(key),
-		ACL:    aws.String(awss3.BucketCannedACLPublicRead),
+	}
+	if s.sse != "" {
+		input.ServerSideEncryption = aws.String(s.sse)
+	}
+	if s.kmsKeyID != "" {
+		input.SSEKMSKeyId = aws.String(s.kmsKeyID)
 	}
 	for _, opt := range opts {
 		opt(input)
@@ -155,7 +189,7 @@ func (s *S3) upload(c context.Context, key string, body io.Reader, opts ...Uploa
 	return nil
 }
 
-func (s *S3) Download(c context.Context, writer io.WriterAt, key string) (int64, error) {
+func (s *S3) Download(c context.Context, writer io.WriterAt, key string, opts ...DownloadOption) (int64, error) {
 	switch {
 	case c == nil:
 		return 0, ErrNilContext
@@ -167,8 +201,109 @@ func (s *S3) Download(c context.Context, writer io.WriterAt, key string) (int64,
 
 	n, err := s.downloader.DownloadWithContext(c, writer, &awss3.GetObjectInput{Bucket: aws.String(s.BucketName()), Key: aws.String(key)})
 	if err != nil {
+		if isNotFound(err) {
+			return 0, errors.Wrap(ErrObjectNotFound, "download object")
+		}
 		return 0, errors.Wrap(err, "download object")
 	}
 
+	if err := newDownloadOptions(opts...).verify(writer, n); err != nil {
+		return 0, errors.Wrapf(err, "verify object [ key = %s ]", key)
+	}
+
 	return n, nil
-}
\ No newline at end of file
+}
+
+// isNotFound 는 객체가 없어서 실패한 요청인지 확인한다.
+func isNotFound(err error) bool {
+	if err, ok := err.(awserr.RequestFailure); ok && err.StatusCode() == http.StatusNotFound {
+		return true
+	}
+	if err, ok := err.(awserr.Error); ok && err.Code() == awss3.ErrCodeNoSuchKey {
+		return true
+	}
+	return false
+}
+
+func (s *S3) Delete(c context.Context, key string) error {
+	switch {
+	case c == nil:
+		return ErrNilContext
+	case len(key) == 0:
+		return ErrEmptyKey
+	}
+
+	if _, err := s.srv.DeleteObjectWithContext(c, &awss3.DeleteObjectInput{Bucket: aws.String(s.BucketName()), Key: aws.String(key)}); err != nil {
+		return errors.Wrap(err, "delete object")
+	}
+
+	return nil
+}
+
+func (s *S3) List(c context.Context, prefix string) (*ObjectListResponse, error) {
+	if c == nil {
+		return nil, ErrNilContext
+	}
+
+	result := &ObjectListResponse{Prefix: prefix, Objects: make(Objects, 0)}
+	input := &awss3.ListObjectsV2Input{
+		Bucket: aws.String(s.BucketName()),
+		Prefix: aws.String(prefix),
+	}
+	if err := s.srv.ListObjectsV2PagesWithContext(c, input, func(page *awss3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			result.Objects = append(result.Objects, Object{
+				Key:          aws.StringValue(obj.Key),
+				Size:         aws.Int64Value(obj.Size),
+				LastModified: aws.TimeValue(obj.LastModified),
+			})
+			result.TotalSize += aws.Int64Value(obj.Size)
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "list objects")
+	}
+	result.ObjectCount = len(result.Objects)
+
+	return result, nil
+}
+
+func (s *S3) Copy(c context.Context, srcKey, dstKey string, metadata map[string]string) error {
+	switch {
+	case c == nil:
+		return ErrNilContext
+	case len(srcKey) == 0, len(dstKey) == 0:
+		return ErrEmptyKey
+	}
+
+	input := &awss3.CopyObjectInput{
+		Bucket:            aws.String(s.BucketName()),
+		CopySource:        aws.String(copySource(s.BucketName(), srcKey)),
+		Key:               aws.String(dstKey),
+		MetadataDirective: aws.String(awss3.MetadataDirectiveReplace),
+		Metadata:          make(map[string]*string),
+	}
+	if s.sse != "" {
+		input.ServerSideEncryption = aws.String(s.sse)
+	}
+	if s.kmsKeyID != "" {
+		input.SSEKMSKeyId = aws.String(s.kmsKeyID)
+	}
+	for k, v := range metadata {
+		input.Metadata[k] = aws.String(url.QueryEscape(v))
+	}
+
+	if _, err := s.srv.CopyObjectWithContext(c, input); err != nil {
+		return errors.Wrap(err, "copy object")
+	}
+
+	return nil
+}
+
+func copySource(bucket, key string) string {
+	segments := strings.Split(key, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return bucket + "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,190 @@
+// Package s3test 는 테스트용 파일 저장소를 제공한다.
+package s3test
+
+import (
+	"buddle-server/internal/s3"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Memory 는 메모리에 객체를 보관하는 s3.Storage 구현체. 체크섬 동작은 s3.S3 와 같다.
+type Memory struct {
+	mu      sync.RWMutex
+	objects map[string]object
+}
+
+type object struct {
+	body         []byte
+	metadata     map[string]string
+	lastModified time.Time
+}
+
+var _ s3.Storage = (*Memory)(nil)
+
+func NewMemory() *Memory {
+	return &Memory{objects: make(map[string]object)}
+}
+
+func (m *Memory) BucketName() string {
+	return "memory"
+}
+
+// Object 는 key 객체의 내용을 반환한다. ( 테스트 검증용 )
+func (m *Memory) Object(key string) ([]byte, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	obj, ok := m.objects[key]
+	return obj.body, ok
+}
+
+// Put 은 key 에 객체를 저장한다. ( 테스트 데이터 준비용 )
+func (m *Memory) Put(key string, body []byte, lastModified time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.objects[key] = object{body: body, lastModified: lastModified}
+}
+
+// Keys 는 저장된 객체 키를 정렬하여 반환한다.
+func (m *Memory) Keys() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]string, 0, len(m.objects))
+	for key := range m.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (m *Memory) IsExist(c context.Context, key string) error {
+	switch {
+	case c == nil:
+		return s3.ErrNilContext
+	case len(key) == 0:
+		return s3.ErrEmptyKey
+	}
+
+	if _, ok := m.Object(key); !ok {
+		return s3.ErrObjectNotFound
+	}
+	return nil
+}
+
+func (m *Memory) Upload(c context.Context, key string, file io.Reader, opts ...s3.UploadOption) (string, error) {
+	switch {
+	case c == nil:
+		return "", s3.ErrNilContext
+	case len(key) == 0:
+		return "", s3.ErrEmptyKey
+	case file == nil:
+		return "", errors.New("nil Body")
+	}
+
+	body, err := ioutil.ReadAll(file)
+	if err != nil {
+		return "", errors.Wrap(err, "put object")
+	}
+
+	m.mu.Lock()
+	m.objects[key] = object{body: body, lastModified: time.Now()}
+	m.mu.Unlock()
+
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (m *Memory) Download(c context.Context, writer io.WriterAt, key string, opts ...s3.DownloadOption) (int64, error) {
+	switch {
+	case c == nil:
+		return 0, s3.ErrNilContext
+	case writer == nil:
+		return 0, s3.ErrNilWriter
+	case len(key) == 0:
+		return 0, s3.ErrEmptyKey
+	}
+
+	body, ok := m.Object(key)
+	if !ok {
+		return 0, errors.Wrap(s3.ErrObjectNotFound, "download object")
+	}
+
+	n, err := writer.WriteAt(body, 0)
+	if err != nil {
+		return 0, errors.Wrap(err, "download object")
+	}
+
+	if err := s3.VerifyDownload(writer, int64(n), opts...); err != nil {
+		return 0, errors.Wrapf(err, "verify object [ key = %s ]", key)
+	}
+
+	return int64(n), nil
+}
+
+func (m *Memory) Delete(c context.Context, key string) error {
+	switch {
+	case c == nil:
+		return s3.ErrNilContext
+	case len(key) == 0:
+		return s3.ErrEmptyKey
+	}
+
+	m.mu.Lock()
+	delete(m.objects, key)
+	m.mu.Unlock()
+
+	return nil
+}
+
+func (m *Memory) Copy(c context.Context, srcKey, dstKey string, metadata map[string]string) error {
+	switch {
+	case c == nil:
+		return s3.ErrNilContext
+	case len(srcKey) == 0, len(dstKey) == 0:
+		return s3.ErrEmptyKey
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	obj, ok := m.objects[srcKey]
+	if !ok {
+		return errors.Wrap(s3.ErrObjectNotFound, "copy object")
+	}
+	m.objects[dstKey] = object{body: obj.body, metadata: metadata, lastModified: time.Now()}
+
+	return nil
+}
+
+func (m *Memory) List(c context.Context, prefix string) (*s3.ObjectListResponse, error) {
+	if c == nil {
+		return nil, s3.ErrNilContext
+	}
+
+	result := &s3.ObjectListResponse{Prefix: prefix, Objects: make(s3.Objects, 0)}
+	for _, key := range m.Keys() {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		m.mu.RLock()
+		obj := m.objects[key]
+		m.mu.RUnlock()
+
+		result.Objects = append(result.Objects, s3.Object{Key: key, Size: int64(len(obj.body)), LastModified: obj.lastModified})
+		result.TotalSize += int64(len(obj.body))
+	}
+	result.ObjectCount = len(result.Objects)
+
+	return result, nil
+}
@@ -0,0 +1,106 @@
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"hash"
+	"io"
+)
+
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// Storage 는 파일 저장소. Upload 는 업로드한 내용의 SHA-256(hex)을 반환하며,
+// Download 는 VerifyChecksum 옵션이 주어지면 내려받은 내용을 검증한다.
+type Storage interface {
+	BucketName() string
+	IsExist(c context.Context, key string) error
+	Upload(c context.Context, key string, file io.Reader, opts ...UploadOption) (string, error)
+	Download(c context.Context, writer io.WriterAt, key string, opts ...DownloadOption) (int64, error)
+	Delete(c context.Context, key string) error
+	Copy(c context.Context, srcKey, dstKey string, metadata map[string]string) error
+	List(c context.Context, prefix string) (*ObjectListResponse, error)
+}
+
+var (
+	_ Storage = (*S3)(nil)
+	_ Storage = (*Local)(nil)
+)
+
+// NewStorage 는 설정에 local_path 가 있으면 로컬 디스크 저장소를, 없으면 S3 저장소를 생성한다.
+func NewStorage(c Config) (Storage, error) {
+	if c.LocalPath != "" {
+		return NewLocal(c)
+	}
+
+	return New(c)
+}
+
+type downloadOptions struct {
+	checksum string
+}
+
+type DownloadOption func(opts *downloadOptions)
+
+// VerifyChecksum 은 내려받은 내용의 SHA-256 이 checksum 과 다르면 ErrChecksumMismatch 를 반환하도록 한다.
+// checksum 이 비어있으면(체크섬 도입 이전 파일) 검증하지 않는다.
+func VerifyChecksum(checksum string) DownloadOption {
+	return func(opts *downloadOptions) {
+		opts.checksum = checksum
+	}
+}
+
+func newDownloadOptions(opts ...DownloadOption) downloadOptions {
+	o := downloadOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// VerifyDownload 는 writer 에 내려받은 n 바이트를 opts 에 따라 검증한다. ( Storage 구현체용 )
+func VerifyDownload(writer io.WriterAt, n int64, opts ...DownloadOption) error {
+	return newDownloadOptions(opts...).verify(writer, n)
+}
+
+// verify 는 writer 에 내려받은 n 바이트의 체크섬을 검증한다.
+func (o downloadOptions) verify(writer io.WriterAt, n int64) error {
+	if o.checksum == "" {
+		return nil
+	}
+
+	r, ok := writer.(io.ReaderAt)
+	if !ok {
+		return errors.New("writer does not support reading for checksum verification")
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(r, 0, n)); err != nil {
+		return errors.Wrap(err, "failed to read downloaded object")
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != o.checksum {
+		return errors.Wrapf(ErrChecksumMismatch, "expected %s, got %s", o.checksum, sum)
+	}
+
+	return nil
+}
+
+// checksumReader 는 읽은 내용의 SHA-256 을 계산한다.
+type checksumReader struct {
+	r io.Reader
+	h hash.Hash
+}
+
+func newChecksumReader(r io.Reader) *checksumReader {
+	h := sha256.New()
+	return &checksumReader{r: io.TeeReader(r, h), h: h}
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	return r.r.Read(p)
+}
+
+func (r *checksumReader) Sum() string {
+	return hex.EncodeToString(r.h.Sum(nil))
+}
@@ -8,25 +8,30 @@ import (
 	"gorm.io/gorm"
 )
 
-func WithDB(key interface{}, conn *gorm.DB, opts ...DatabaseOption) echo.MiddlewareFunc {
+// WithDB 는 요청 context 에 쓰기(primary)와 읽기(replica) DB 연결을 설정한다.
+// read 가 nil 이면 읽기 요청도 write 연결을 사용한다.
+func WithDB(write, read *gorm.DB, opts ...DatabaseOption) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			switch {
-			case key == nil:
-				return fmt.Errorf("nil db key")
-			case conn == nil:
+			if write == nil {
 				return fmt.Errorf("nil db conn")
 			}
 
+			writeConn, readConn := write, read
+			if readConn == nil {
+				readConn = writeConn
+			}
 			for _, opt := range opts {
-				conn = opt(conn)
+				writeConn = opt(writeConn)
+				readConn = opt(readConn)
 			}
 
 			ctx, err := UpgradeContext(c)
 			if err != nil {
 				return errors.Wrap(err, "upgrade context")
 			}
-			ctx.SetContext(db.ContextWithConn(ctx.GoContext(), db.WriteDBKey, conn))
+			goCtx := db.ContextWithConn(ctx.GoContext(), db.WriteDBKey, writeConn)
+			ctx.SetContext(db.ContextWithConn(goCtx, db.ReadDBKey, readConn))
 
 			return next(ctx)
 		}
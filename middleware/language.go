@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"buddle-server/internal/i18n"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerAcceptLanguage  = "Accept-Language"
+	headerContentLanguage = "Content-Language"
+)
+
+// Language 는 Accept-Language 헤더로 응답 언어를 정하여 요청 context 에 설정한다.
+func Language(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		lang := i18n.ParseAcceptLanguage(c.Request().Header.Get(headerAcceptLanguage))
+
+		c.SetRequest(c.Request().WithContext(i18n.ContextWithLanguage(c.Request().Context(), lang)))
+		c.Response().Header().Add(echo.HeaderVary, headerAcceptLanguage)
+		c.Response().Header().Set(headerContentLanguage, string(lang))
+
+		return next(c)
+	}
+}
@@ -1,74 +1,156 @@
 package model
 
 import (
-	"errors"
+	"buddle-server/internal/i18n"
+	"fmt"
 	jsoniter "github.com/json-iterator/go"
+	"strings"
 	"time"
 )
 
+// AfterServiceMaxFiles 는 A/S 신청시 첨부할 수 있는 최대 파일 개수
+const AfterServiceMaxFiles = 5
+
 type AfterService struct {
-	AfterServiceSeq int64       `form:"after_service_seq" json:"after_service_seq" gorm:"Column:after_service_seq;PRIMARY_KEY"`
-	Name            string      `form:"name" json:"name" gorm:"Column:name"`
-	Phone           string      `form:"phone" json:"phone" gorm:"Column:phone"`
-	Email           string      `form:"email" json:"email" gorm:"Column:email"`
-	Addr            string      `form:"addr" json:"addr" gorm:"Column:addr"`
-	AddrDetail      string      `form:"addr_detail" json:"addr_detail" gorm:"Column:addr_detail"`
-	ProductType     ProductType `form:"product_type" json:"product_type" gorm:"Column:product_type;default:0"`
-	MarketType      MarketType  `form:"market_type" json:"market_type" gorm:"Column:market_type"`
-	PurchaseDate    time.Time   `form:"purchase_date" json:"purchase_date" gorm:"Column:purchase_date"`
-	File1S3Location string      `form:"file1_s3_location" json:"file1_s3_location" gorm:"Column:file1_s3_location"`
-	File2S3Location string      `form:"file2_s3_location" json:"file2_s3_location" gorm:"Column:file2_s3_location"`
-	File3S3Location string      `form:"file3_s3_location" json:"file3_s3_location" gorm:"Column:file3_s3_location"`
-	File4S3Location string      `form:"file4_s3_location" json:"file4_s3_location" gorm:"Column:file4_s3_location"`
-	File5S3Location string      `form:"file5_s3_location" json:"file5_s3_location" gorm:"Column:file5_s3_location"`
-	Contents        string      `form:"contents" json:"contents" gorm:"Column:contents"`
-	RegDate         time.Time   `form:"regdate" json:"regdate" gorm:"Column:regdate"`
-	Modified        time.Time   `form:"modified" json:"modified" gorm:"Column:modified"`
+	AfterServiceSeq  int64          `form:"after_service_seq" json:"after_service_seq" gorm:"Column:after_service_seq;PRIMARY_KEY"`
+	ProductSeq       int64          `form:"-" json:"product_seq,omitempty" gorm:"Column:product_seq"`               // 인증된 제품으로 신청한 경우의 제품
+	ProductRegistSeq int64          `form:"-" json:"product_regist_seq,omitempty" gorm:"Column:product_regist_seq"` // 인증된 제품으로 신청한 경우의 제품 인증 정보
+	SerialNo         string         `form:"serial_no" json:"serial_no,omitempty" gorm:"->;Column:serial_no"`        // 인증된 제품의 시리얼 번호 ( 입력 시 제품 인증 정보로 신청 )
+	Name             string         `form:"name" json:"name" gorm:"Column:name"`
+	Phone            string         `form:"phone" json:"phone" gorm:"Column:phone"`
+	Email            string         `form:"email" json:"email" gorm:"Column:email"`
+	Addr             string         `form:"addr" json:"addr" gorm:"Column:addr"`
+	AddrDetail       string         `form:"addr_detail" json:"addr_detail" gorm:"Column:addr_detail"`
+	ProductType      ProductType    `form:"product_type" json:"product_type" gorm:"Column:product_type;default:0"`
+	MarketType       MarketType     `form:"market_type" json:"market_type" gorm:"Column:market_type"`
+	OrderNo          string         `form:"order_no" json:"order_no" gorm:"Column:order_no"` // 주문번호 ( 판매 채널에 따라 필수 )
+	PurchaseDate     time.Time      `form:"purchase_date" json:"purchase_date" gorm:"Column:purchase_date"`
+	WarrantyStatus   WarrantyStatus `form:"-" json:"warranty_status" gorm:"Column:warranty_status"` // 신청 시점의 무상 보증 상태
+	File1S3Location  string         `form:"file1_s3_location" json:"file1_s3_location" gorm:"Column:file1_s3_location"`
+	File2S3Location  string         `form:"file2_s3_location" json:"file2_s3_location" gorm:"Column:file2_s3_location"`
+	File3S3Location  string         `form:"file3_s3_location" json:"file3_s3_location" gorm:"Column:file3_s3_location"`
+	File4S3Location  string         `form:"file4_s3_location" json:"file4_s3_location" gorm:"Column:file4_s3_location"`
+	File5S3Location  string         `form:"file5_s3_location" json:"file5_s3_location" gorm:"Column:file5_s3_location"`
+	File1Sha256      string         `form:"-" json:"-" gorm:"Column:file1_sha256"`
+	File2Sha256      string         `form:"-" json:"-" gorm:"Column:file2_sha256"`
+	File3Sha256      string         `form:"-" json:"-" gorm:"Column:file3_sha256"`
+	File4Sha256      string         `form:"-" json:"-" gorm:"Column:file4_sha256"`
+	File5Sha256      string         `form:"-" json:"-" gorm:"Column:file5_sha256"`
+	Contents         string         `form:"contents" json:"contents" gorm:"Column:contents"`
+	RegDate          time.Time      `form:"regdate" json:"regdate" gorm:"Column:regdate"`
+	Modified         time.Time      `form:"modified" json:"modified" gorm:"Column:modified"`
+	ProductName
+	MarketName
+
+	lang i18n.Language // 제품명, 구매처명 언어
 }
 
 func (a AfterService) TableName() string {
 	return "after_service"
 }
 
+// FileS3Locations 는 첨부파일 순서(file1 ~ file5)대로 s3 위치 필드를 반환한다.
+func (a *AfterService) FileS3Locations() []*string {
+	return []*string{&a.File1S3Location, &a.File2S3Location, &a.File3S3Location, &a.File4S3Location, &a.File5S3Location}
+}
+
+// FileSha256s 는 첨부파일 순서(file1 ~ file5)대로 SHA-256 필드를 반환한다.
+func (a *AfterService) FileSha256s() []*string {
+	return []*string{&a.File1Sha256, &a.File2Sha256, &a.File3Sha256, &a.File4Sha256, &a.File5Sha256}
+}
+
+// FileS3LocationColumn 은 fileIdx(1 ~ 5)번째 첨부파일의 s3 위치 컬럼명을 반환한다.
+func FileS3LocationColumn(fileIdx int) (string, error) {
+	if fileIdx < 1 || fileIdx > AfterServiceMaxFiles {
+		return "", fmt.Errorf("file index(%d) is invalid", fileIdx)
+	}
+	return fmt.Sprintf("file%d_s3_location", fileIdx), nil
+}
+
+// Validate 는 A/S 신청 요청의 모든 필드를 검증한다. 검증 실패 시 FieldErrors 를 반환한다.
+// 시리얼 번호를 입력한 경우 주소, 구매 정보는 제품 인증 정보로 채우므로 검증하지 않는다.
 func (a AfterService) Validate() error {
-	switch {
-	case a.Name == "":
-		return errors.New("name is required")
-	case a.Phone == "":
-		return errors.New("phone is required")
-	case a.Addr == "":
-		return errors.New("addr is required")
-	case a.AddrDetail == "":
-		return errors.New("addrDetail is required")
-	case a.PurchaseDate.IsZero():
-		return errors.New("purchaseDate is required")
+	var errs FieldErrors
+	requireString(&errs, "name", a.Name)
+	validatePhone(&errs, "phone", a.Phone)
+	validateEmail(&errs, "email", a.Email)
+	validateProductType(&errs, "product_type", a.ProductType)
+	if a.SerialNo == "" {
+		requireString(&errs, "addr", a.Addr)
+		requireString(&errs, "addr_detail", a.AddrDetail)
+		validateMarketType(&errs, "market_type", a.MarketType)
+		validatePurchaseDate(&errs, "purchase_date", a.PurchaseDate)
+	}
+
+	return errs.Err()
+}
+
+// LinkProductRegist 는 A/S 신청을 인증된 제품에 연결한다.
+// 구매 정보는 제품 인증 정보를 사용하고, 입력하지 않은 주소는 제품 인증 정보의 주소로 채운다.
+func (a *AfterService) LinkProductRegist(product *Product, productRegist *ProductRegist) {
+	a.ProductSeq = product.ProductSeq
+	a.ProductRegistSeq = productRegist.ProductRegistSeq
+	a.ProductType = product.ProductType
+	a.MarketType = productRegist.MarketType
+	a.OrderNo = productRegist.OrderNo
+	a.PurchaseDate = productRegist.PurchaseDate
+
+	if strings.TrimSpace(a.Addr) == "" {
+		a.Addr, a.AddrDetail = productRegist.Addr, productRegist.AddrDetail
 	}
+}
 
-	return nil
+var afterServiceFileLabel = i18n.Messages{i18n.Korean: "첨부파일", i18n.English: "attachment"}
+
+// AfterServiceFilename 은 lang 으로 번역된 A/S 첨부파일 다운로드 파일명
+func AfterServiceFilename(name, phone string, fileIdx int64, lang i18n.Language) string {
+	return fmt.Sprintf("%s(%s) %s(%d)", name, phone, afterServiceFileLabel.In(lang), fileIdx)
+}
+
+// AfterServices 는 A/S 신청 정보 목록 응답
+type AfterServices []*AfterService
+
+// Localize 는 제품명, 구매처명을 lang 으로 번역하여 응답하는 목록을 반환한다.
+func (a AfterServices) Localize(lang i18n.Language) interface{} {
+	result := make(AfterServices, len(a))
+	for i, as := range a {
+		localized := *as
+		localized.lang = lang
+		result[i] = &localized
+	}
+	return result
 }
 
 func (a AfterService) MarshalJSON() ([]byte, error) {
 	result := struct {
-		AfterServiceSeq int64  `json:"after_service_seq"`
-		Name            string `json:"name"`
-		Phone           string `json:"phone"`
-		Email           string `json:"email"`
-		Addr            string `json:"addr"`
-		AddrDetail      string `json:"addr_detail"`
-		ProductType     string `json:"product_type"`
-		MarketType      string `json:"market_type"`
-		PurchaseDate    string `json:"purchase_date"`
-		Contents        string `json:"contents"`
+		AfterServiceSeq int64          `json:"after_service_seq"`
+		ProductSeq      int64          `json:"product_seq,omitempty"`
+		SerialNo        string         `json:"serial_no,omitempty"`
+		Name            string         `json:"name"`
+		Phone           string         `json:"phone"`
+		Email           string         `json:"email"`
+		Addr            string         `json:"addr"`
+		AddrDetail      string         `json:"addr_detail"`
+		ProductType     string         `json:"product_type"`
+		MarketType      string         `json:"market_type"`
+		OrderNo         string         `json:"order_no,omitempty"`
+		PurchaseDate    string         `json:"purchase_date"`
+		WarrantyStatus  WarrantyStatus `json:"warranty_status"`
+		Contents        string         `json:"contents"`
 	}{
 		AfterServiceSeq: a.AfterServiceSeq,
+		ProductSeq:      a.ProductSeq,
+		SerialNo:        a.SerialNo,
 		Name:            a.Name,
 		Phone:           a.Phone,
 		Email:           a.Email,
 		Addr:            a.Addr,
 		AddrDetail:      a.AddrDetail,
-		ProductType:     a.ProductType.String(),
-		MarketType:      a.MarketType.String(),
+		ProductType:     a.ProductName.Label(a.lang),
+		MarketType:      a.MarketName.Label(a.lang),
+		OrderNo:         a.OrderNo,
 		PurchaseDate:    a.PurchaseDate.Format("2006-01-02"),
+		WarrantyStatus:  a.WarrantyStatus,
 		Contents:        a.Contents,
 	}
 
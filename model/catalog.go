@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// CatalogEntry 는 code 를 키로 하는 카탈로그 테이블( 제품 모델, 판매 채널 )의 행.
+// 등록, 수정, 삭제는 카탈로그 공통 처리를 사용하고, 검증 규칙( Validate )만 테이블마다 다르다.
+type CatalogEntry interface {
+	CatalogCode() int
+	SetCatalogCode(code int)
+	SetRegdate(regdate time.Time) // 등록일이 없으면 regdate 로 설정하고 수정일을 등록일로 맞춘다.
+	ToUpdateMap() map[string]interface{}
+	Validate() error
+}
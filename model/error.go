@@ -0,0 +1,69 @@
+package model
+
+import (
+	"buddle-server/internal/i18n"
+	"errors"
+	"fmt"
+)
+
+// 도메인 에러 종류. handler.ErrorHandler 가 HTTP 상태 코드로 변환한다.
+var (
+	ErrNotFound     = errors.New("not found")         // 404
+	ErrConflict     = errors.New("conflict")          // 409
+	ErrValidation   = errors.New("validation failed") // 400
+	ErrUnauthorized = errors.New("unauthorized")      // 401
+	ErrForbidden    = errors.New("forbidden")         // 403
+	ErrInternal     = errors.New("internal error")    // 500
+)
+
+// Error 는 응답 에러 코드를 가진 도메인 에러. 사용자 메시지는 에러 코드의 언어별 메시지를 사용한다.
+// errors.Is(err, ErrNotFound) 처럼 종류를 확인할 수 있다.
+type Error struct {
+	kind      error
+	ErrorCode ResponseErrorCode
+	Fields    FieldErrors // 필드 검증 에러 ( ErrValidation )
+	cause     error       // 원인 ( 로그용, 응답에는 포함하지 않음 )
+}
+
+// NewError 는 에러 코드에 정의된 종류로 에러를 생성한다.
+func NewError(code ResponseErrorCode) *Error {
+	return &Error{kind: code.Info().Kind, ErrorCode: code}
+}
+
+// NewFieldValidationError 는 필드 검증 에러 목록을 가진 ErrValidation 에러를 생성한다.
+func NewFieldValidationError(fields FieldErrors) *Error {
+	e := NewError(ResponseErrorCodeInvalidRequest)
+	e.Fields = fields
+	return e
+}
+
+// WithCause 는 원인 에러를 설정한다.
+func (e *Error) WithCause(cause error) *Error {
+	e.cause = cause
+	return e
+}
+
+// Kind 는 에러 종류( ErrNotFound 등 )를 반환한다.
+func (e *Error) Kind() error {
+	return e.kind
+}
+
+// Message 는 lang 으로 번역된 사용자 메시지
+func (e *Error) Message(lang i18n.Language) string {
+	return e.ErrorCode.Message(lang)
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%v: %s: %v", e.kind, e.Message(i18n.DefaultLanguage), e.cause)
+	}
+	return fmt.Sprintf("%v: %s", e.kind, e.Message(i18n.DefaultLanguage))
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.kind
+}
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"buddle-server/internal/i18n"
+	"fmt"
+)
+
+type ResponseErrorCode string
+
+// 응답 에러 코드. 코드별 에러 종류와 메시지는 errorCodes 에 정의한다.
+const (
+	// 제품, 제품 인증
+	ResponseErrorCodeDuplProduct             ResponseErrorCode = "1000" // 이미 등록된 제품을 등록 하려는 경우
+	ResponseErrorCodeProductNotExist         ResponseErrorCode = "1001" // 제품이 존재하지 않음
+	ResponseErrorCodeUserIDNotExist          ResponseErrorCode = "1002" // 회원 아이디가 존재하지 않음
+	ResponseErrorCodeInvalidUserPwd          ResponseErrorCode = "1003" // 회원 비밀번호가 일치하지 않음
+	ResponseErrorCodeDuplUserID              ResponseErrorCode = "1004" // 이미 사용 중인 회원 아이디
+	ResponseErrorCodeProductRegistNotExist   ResponseErrorCode = "1005" // 제품 인증 정보가 존재하지 않음
+	ResponseErrorCodeProductAuthNotMatched   ResponseErrorCode = "1006" // 이름, 연락처와 일치하는 제품 인증 정보가 없음
+	ResponseErrorCodeInvalidStatusTransition ResponseErrorCode = "1007" // 현재 상태에서 변경할 수 없는 제품 인증
+	ResponseErrorCodeInvalidCSV              ResponseErrorCode = "1008" // 제품 CSV 파일 형식 오류
+	ResponseErrorCodeProductModelNotExist    ResponseErrorCode = "1009" // 제품 모델이 존재하지 않음
+	ResponseErrorCodeDuplProductModel        ResponseErrorCode = "1010" // 이미 등록된 제품 모델 코드
+	ResponseErrorCodeProductModelInUse       ResponseErrorCode = "1011" // 제품, A/S 신청이 등록된 제품 모델 삭제
+	ResponseErrorCodeProductTransferNotExist ResponseErrorCode = "1012" // 시리얼 번호, 이전 코드와 일치하는 유효한 이전 요청이 없음
+	ResponseErrorCodeProductRegistPending    ResponseErrorCode = "1013" // 영수증 확인 중인 제품 인증 정보 사용
+
+	// A/S
+	ResponseErrorCodeAfterServiceNotExist   ResponseErrorCode = "1100" // A/S 신청 정보가 존재하지 않음
+	ResponseErrorCodeAfterServiceNotMatched ResponseErrorCode = "1101" // 이름, 연락처와 일치하는 A/S 신청 정보가 없음
+	ResponseErrorCodeTooManyFiles           ResponseErrorCode = "1102" // 첨부파일 개수 초과
+
+	// 파일
+	ResponseErrorCodeFileNotFound     ResponseErrorCode = "1200" // 다운로드 받을 파일이 없음
+	ResponseErrorCodeUploadFailed     ResponseErrorCode = "1201" // 파일 업로드 실패
+	ResponseErrorCodeDownloadFailed   ResponseErrorCode = "1202" // 파일 다운로드 실패 ( 체크섬 불일치 포함 )
+	ResponseErrorCodeInvalidFileIndex ResponseErrorCode = "1203" // 첨부파일 번호 오류
+
+	// 판매 채널
+	ResponseErrorCodeSalesChannelNotExist ResponseErrorCode = "1300" // 판매 채널이 존재하지 않음
+	ResponseErrorCodeDuplSalesChannel     ResponseErrorCode = "1301" // 이미 등록된 판매 채널 코드
+	ResponseErrorCodeSalesChannelInUse    ResponseErrorCode = "1302" // 제품 인증, A/S 신청이 등록된 판매 채널 삭제
+
+	// 공통
+	ResponseErrorCodeInternal       ResponseErrorCode = "9000" // 서버 내부 오류
+	ResponseErrorCodeInvalidRequest ResponseErrorCode = "9001" // 요청 파라미터가 잘못됨
+	ResponseErrorCodeNotFound       ResponseErrorCode = "9002" // 요청한 데이터가 존재하지 않음
+	ResponseErrorCodeConflict       ResponseErrorCode = "9003" // 이미 존재하는 데이터
+	ResponseErrorCodeUnauthorized   ResponseErrorCode = "9004" // 인증이 필요함
+	ResponseErrorCodeForbidden      ResponseErrorCode = "9005" // 권한이 없음
+)
+
+// ErrorCodeInfo 는 에러 코드의 종류와 언어별 메시지
+type ErrorCodeInfo struct {
+	Code     ResponseErrorCode
+	Kind     error // ErrNotFound 등, ErrInternal 이면 500
+	Messages i18n.Messages
+}
+
+var errorCodes = []ErrorCodeInfo{
+	{ResponseErrorCodeDuplProduct, ErrConflict, i18n.Messages{i18n.Korean: "이미 등록된 제품입니다.", i18n.English: "This product is already registered."}},
+	{ResponseErrorCodeProductNotExist, ErrNotFound, i18n.Messages{i18n.Korean: "시리얼 번호가 잘못 되었습니다.", i18n.English: "The serial number is invalid."}},
+	{ResponseErrorCodeUserIDNotExist, ErrUnauthorized, i18n.Messages{i18n.Korean: "아이디가 존재 하지 않습니다.", i18n.English: "The ID does not exist."}},
+	{ResponseErrorCodeInvalidUserPwd, ErrUnauthorized, i18n.Messages{i18n.Korean: "비밀번호가 일치하지 않습니다.", i18n.English: "The password does not match."}},
+	{ResponseErrorCodeDuplUserID, ErrConflict, i18n.Messages{i18n.Korean: "이미 사용 중인 아이디입니다.", i18n.English: "This ID is already in use."}},
+	{ResponseErrorCodeProductRegistNotExist, ErrNotFound, i18n.Messages{i18n.Korean: "제품 인증 정보가 존재하지 않습니다.", i18n.English: "The product registration does not exist."}},
+	{ResponseErrorCodeProductAuthNotMatched, ErrNotFound, i18n.Messages{i18n.Korean: "일치하는 제품 인증 정보가 없습니다.", i18n.English: "No matching product registration was found."}},
+	{ResponseErrorCodeInvalidStatusTransition, ErrConflict, i18n.Messages{i18n.Korean: "현재 상태에서는 변경할 수 없는 제품 인증입니다.", i18n.English: "The product registration cannot be changed in its current status."}},
+	{ResponseErrorCodeInvalidCSV, ErrValidation, i18n.Messages{i18n.Korean: "CSV 파일 형식이 올바르지 않습니다.", i18n.English: "The CSV file format is invalid."}},
+	{ResponseErrorCodeProductModelNotExist, ErrNotFound, i18n.Messages{i18n.Korean: "제품 모델이 존재하지 않습니다.", i18n.English: "The product model does not exist."}},
+	{ResponseErrorCodeDuplProductModel, ErrConflict, i18n.Messages{i18n.Korean: "이미 등록된 제품 모델 코드입니다.", i18n.English: "This product model code is already registered."}},
+	{ResponseErrorCodeProductModelInUse, ErrConflict, i18n.Messages{i18n.Korean: "제품 또는 A/S 신청이 등록된 모델은 삭제할 수 없습니다. 판매 중지로 변경해 주세요.", i18n.English: "A product model in use by products or A/S requests cannot be deleted. Deactivate it instead."}},
+	{ResponseErrorCodeProductTransferNotExist, ErrNotFound, i18n.Messages{i18n.Korean: "이전 코드가 일치하지 않거나 만료되었습니다.", i18n.English: "The transfer code does not match or has expired."}},
+	{ResponseErrorCodeProductRegistPending, ErrConflict, i18n.Messages{i18n.Korean: "영수증 확인 중인 제품입니다. 확인이 완료된 후 이용해 주세요.", i18n.English: "The receipt of this product is being reviewed. Please try again after the review."}},
+
+	{ResponseErrorCodeAfterServiceNotExist, ErrNotFound, i18n.Messages{i18n.Korean: "A/S 신청 정보가 존재하지 않습니다.", i18n.English: "The A/S request does not exist."}},
+	{ResponseErrorCodeAfterServiceNotMatched, ErrNotFound, i18n.Messages{i18n.Korean: "일치하는 A/S 신청 정보가 없습니다.", i18n.English: "No matching A/S request was found."}},
+	{ResponseErrorCodeTooManyFiles, ErrValidation, i18n.Messages{i18n.Korean: fmt.Sprintf("첨부파일은 최대 %d개까지 가능합니다.", AfterServiceMaxFiles), i18n.English: fmt.Sprintf("Up to %d files can be attached.", AfterServiceMaxFiles)}},
+
+	{ResponseErrorCodeFileNotFound, ErrNotFound, i18n.Messages{i18n.Korean: "다운로드 받을 파일이 존재하지 않습니다.", i18n.English: "The file to download does not exist."}},
+	{ResponseErrorCodeUploadFailed, ErrInternal, i18n.Messages{i18n.Korean: "파일 업로드에 실패하였습니다.", i18n.English: "Failed to upload the file."}},
+	{ResponseErrorCodeDownloadFailed, ErrInternal, i18n.Messages{i18n.Korean: "파일 다운로드에 실패하였습니다.", i18n.English: "Failed to download the file."}},
+	{ResponseErrorCodeInvalidFileIndex, ErrValidation, i18n.Messages{i18n.Korean: "첨부파일 번호가 잘못 되었습니다.", i18n.English: "The attachment number is invalid."}},
+
+	{ResponseErrorCodeSalesChannelNotExist, ErrNotFound, i18n.Messages{i18n.Korean: "판매 채널이 존재하지 않습니다.", i18n.English: "The sales channel does not exist."}},
+	{ResponseErrorCodeDuplSalesChannel, ErrConflict, i18n.Messages{i18n.Korean: "이미 등록된 판매 채널 코드입니다.", i18n.English: "This sales channel code is already registered."}},
+	{ResponseErrorCodeSalesChannelInUse, ErrConflict, i18n.Messages{i18n.Korean: "제품 인증 또는 A/S 신청이 등록된 판매 채널은 삭제할 수 없습니다. 판매 중지로 변경해 주세요.", i18n.English: "A sales channel in use by product registrations or A/S requests cannot be deleted. Deactivate it instead."}},
+
+	{ResponseErrorCodeInternal, ErrInternal, i18n.Messages{i18n.Korean: "서버 오류가 발생하였습니다.", i18n.English: "An internal server error occurred."}},
+	{ResponseErrorCodeInvalidRequest, ErrValidation, i18n.Messages{i18n.Korean: "요청 파라미터가 잘못 되었습니다.", i18n.English: "The request parameters are invalid."}},
+	{ResponseErrorCodeNotFound, ErrNotFound, i18n.Messages{i18n.Korean: "요청한 데이터가 존재하지 않습니다.", i18n.English: "The requested data does not exist."}},
+	{ResponseErrorCodeConflict, ErrConflict, i18n.Messages{i18n.Korean: "이미 존재하는 데이터입니다.", i18n.English: "The data already exists."}},
+	{ResponseErrorCodeUnauthorized, ErrUnauthorized, i18n.Messages{i18n.Korean: "인증이 필요합니다.", i18n.English: "Authentication is required."}},
+	{ResponseErrorCodeForbidden, ErrForbidden, i18n.Messages{i18n.Korean: "권한이 없습니다.", i18n.English: "You do not have permission."}},
+}
+
+var errorCodeIndex = func() map[ResponseErrorCode]ErrorCodeInfo {
+	index := make(map[ResponseErrorCode]ErrorCodeInfo, len(errorCodes))
+	for _, info := range errorCodes {
+		index[info.Code] = info
+	}
+	return index
+}()
+
+// ErrorCodes 는 전체 에러 코드 목록 ( 코드 순 )
+func ErrorCodes() []ErrorCodeInfo {
+	return append([]ErrorCodeInfo(nil), errorCodes...)
+}
+
+// Info 는 에러 코드의 종류와 메시지. 정의되지 않은 코드는 서버 내부 오류로 취급한다.
+func (c ResponseErrorCode) Info() ErrorCodeInfo {
+	if info, ok := errorCodeIndex[c]; ok {
+		return info
+	}
+	return ErrorCodeInfo{Code: c, Kind: ErrInternal, Messages: errorCodeIndex[ResponseErrorCodeInternal].Messages}
+}
+
+// Message 는 lang 으로 번역된 에러 메시지
+func (c ResponseErrorCode) Message(lang i18n.Language) string {
+	return c.Info().Messages.In(lang)
+}
@@ -0,0 +1,43 @@
+package model_test
+
+import (
+	"buddle-server/internal/i18n"
+	"buddle-server/model"
+	"errors"
+	"testing"
+)
+
+func TestErrorCodes(t *testing.T) {
+	seen := make(map[model.ResponseErrorCode]bool)
+	for _, info := range model.ErrorCodes() {
+		switch {
+		case seen[info.Code]:
+			t.Errorf("%s: duplicated", info.Code)
+		case info.Kind == nil:
+			t.Errorf("%s: empty kind", info.Code)
+		}
+		for _, lang := range i18n.Languages {
+			if info.Messages[lang] == "" {
+				t.Errorf("%s: empty %s message", info.Code, lang)
+			}
+		}
+		seen[info.Code] = true
+	}
+}
+
+func TestNewError(t *testing.T) {
+	err := model.NewError(model.ResponseErrorCodeDuplProduct)
+	if !errors.Is(err, model.ErrConflict) || err.Message(i18n.English) != "This product is already registered." {
+		t.Fatalf("err = %v", err)
+	}
+
+	if err := model.NewError("0000"); !errors.Is(err, model.ErrInternal) {
+		t.Fatalf("unknown code: err = %v, want internal error", err)
+	}
+}
+
+func TestSimpleFail(t *testing.T) {
+	if resp := model.SimpleFail(); resp.Success || resp.ErrorCode != model.ResponseErrorCodeInternal {
+		t.Fatalf("resp = %+v", resp)
+	}
+}
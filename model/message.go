@@ -0,0 +1,27 @@
+package model
+
+import "buddle-server/internal/i18n"
+
+// ResponseMessageCode 는 성공 응답 메시지 코드
+type ResponseMessageCode string
+
+const (
+	ResponseMessageSuccess  ResponseMessageCode = "success"  // 성공
+	ResponseMessageModified ResponseMessageCode = "modified" // 수정 완료
+)
+
+var responseMessages = map[ResponseMessageCode]i18n.Messages{
+	ResponseMessageSuccess:  {i18n.Korean: "성공하였습니다.", i18n.English: "Success."},
+	ResponseMessageModified: {i18n.Korean: "수정 되었습니다.", i18n.English: "Modified successfully."},
+}
+
+// Message 는 lang 으로 번역된 성공 메시지
+func (c ResponseMessageCode) Message(lang i18n.Language) string {
+	return responseMessages[c].In(lang)
+}
+
+// Localizer 는 응답 언어에 따라 내용( 메시지, 라벨 등 )이 바뀌는 응답 데이터.
+// Response.Localize 가 Data 를 lang 으로 번역된 값으로 바꾼다.
+type Localizer interface {
+	Localize(lang i18n.Language) interface{}
+}
@@ -1,14 +1,17 @@
 package model
 
 import (
-	"errors"
+	"buddle-server/internal/i18n"
+	"fmt"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
 )
 
+// ProductType 은 제품 모델 코드 ( product_model.code ). 제품명 등은 product_model 테이블에서 관리한다.
 type ProductType int
 
+// 제품 모델 테이블 생성 시 등록된 제품 모델 코드
 const (
 	ProductTypePowderMilkMaker      ProductType = iota // 버들맘마 분유제조기 플러스
 	ProductTypePowderMilkMakerSmart                    // 버들맘마 분유제조기 스마트
@@ -16,19 +19,13 @@ const (
 	ProductTypeSmartChopper                            // 버들 스마트 차퍼
 )
 
-func (t ProductType) String() string {
-	switch t {
-	case ProductTypePowderMilkMaker:
-		return "버들맘마 분유제조기 플러스"
-	case ProductTypePowderMilkMakerSmart:
-		return "버들맘마 분유제조기 스마트"
-	case ProductTypeBabyBottleWasher:
-		return "버들아이 젖병세척기"
-	case ProductTypeSmartChopper:
-		return "버들 스마트 차퍼"
+// Validate 는 코드 형식만 확인한다. 제품 모델 존재 여부는 서비스에서 product_model 테이블로 확인한다.
+func (t ProductType) Validate() error {
+	if t < 0 {
+		return fmt.Errorf("product_type(%d) is invalid", t)
 	}
 
-	return ""
+	return nil
 }
 
 type Product struct {
@@ -43,51 +40,61 @@ func (p Product) TableName() string {
 	return "product"
 }
 
+// MarketType 은 판매 채널 코드 ( sales_channel.code ). 채널명 등은 sales_channel 테이블에서 관리한다.
 type MarketType int
 
+// 판매 채널 테이블 생성 시 등록된 판매 채널 코드
 const (
-	MarketTypeNaver MarketType = iota
-	MarketTypeCoupang
-	MarketTypeOffline
-	MarketTypeEtc
+	MarketTypeNaver   MarketType = iota // 네이버
+	MarketTypeCoupang                   // 쿠팡
+	MarketTypeOffline                   // 오프라인 매장
+	MarketTypeEtc                       // 기타
 )
 
-func (t MarketType) String() string {
-	switch t {
-	case MarketTypeNaver:
-		return "네이버"
-	case MarketTypeCoupang:
-		return "쿠팡"
-	case MarketTypeOffline:
-		return "오프라인 매장"
-	default:
-		return "기타"
+// Validate 는 코드 형식만 확인한다. 판매 채널 존재 여부는 서비스에서 sales_channel 테이블로 확인한다.
+func (t MarketType) Validate() error {
+	if t < 0 {
+		return fmt.Errorf("market_type(%d) is invalid", t)
 	}
+
+	return nil
 }
 
 type ProductAuthStatus int
 
 const (
-	ProductAuthStatusNone   ProductAuthStatus = iota // 미인증
-	ProductAuthStatusCancel                          // 인증 취소
-	ProductAuthStatusOK                              // 인증 완료
+	ProductAuthStatusNone        ProductAuthStatus = iota // 미인증
+	ProductAuthStatusCancel                               // 인증 취소
+	ProductAuthStatusOK                                   // 인증 완료
+	ProductAuthStatusTransferred                          // 소유권 이전 ( 새 소유자의 인증 정보가 등록됨 )
+	ProductAuthStatusPending                              // 인증 대기 ( 관리자의 영수증 확인 중 )
+	ProductAuthStatusRejected                             // 인증 반려 ( 영수증 확인 실패 )
 )
 
+// Active 는 제품당 하나만 존재할 수 있는 인증 상태( 인증 완료, 인증 대기 )인지 확인한다.
+func (s ProductAuthStatus) Active() bool {
+	return s == ProductAuthStatusOK || s == ProductAuthStatusPending
+}
+
 type ProductRegist struct {
-	ProductRegistSeq  int64             `form:"product_regist_seq" json:"product_regist_seq,omitempty" gorm:"Column:product_regist_seq;PRIMARY_KEY"`
-	ProductSeq        int64             `form:"product_seq" json:"product_seq,omitempty" gorm:"Column:product_seq"`
-	Name              string            `form:"name" json:"name,omitempty" gorm:"Column:name"`
-	Phone             string            `form:"phone" json:"phone,omitempty" gorm:"Column:phone"`
-	Addr              string            `form:"addr" json:"addr,omitempty" gorm:"Column:addr"`
-	AddrDetail        string            `form:"addr_detail" json:"addr_detail,omitempty" gorm:"Column:addr_detail"`
-	SerialNo          string            `form:"serial_no" json:"serial_no" gorm:"-"`
-	ProductType       ProductType       `form:"product_type" json:"product_type" gorm:"-"`
-	MarketType        MarketType        `form:"market_type" json:"market_type,omitempty" gorm:"Column:market_type"`
-	Status            ProductAuthStatus `form:"status" json:"status,omitempty" gorm:"Column:status"`
-	PurchaseDate      time.Time         `form:"purchase_date" json:"purchase_date" gorm:"Column:purchase_date"`
-	ReceiptS3Location string            `form:"receipt_s3_location" json:"receipt_s3_location,omitempty" gorm:"Column:receipt_s3_location"`
-	Regdate           time.Time         `form:"regdate" json:"regdate" gorm:"Column:regdate"`
-	Modified          time.Time         `form:"modified" json:"modified" gorm:"Column:modified"`
+	ProductRegistSeq  int64              `form:"product_regist_seq" json:"product_regist_seq,omitempty" gorm:"Column:product_regist_seq;PRIMARY_KEY"`
+	ProductSeq        int64              `form:"product_seq" json:"product_seq,omitempty" gorm:"Column:product_seq"`
+	Name              string             `form:"name" json:"name,omitempty" gorm:"Column:name"`
+	Phone             string             `form:"phone" json:"phone,omitempty" gorm:"Column:phone"`
+	Addr              string             `form:"addr" json:"addr,omitempty" gorm:"Column:addr"`
+	AddrDetail        string             `form:"addr_detail" json:"addr_detail,omitempty" gorm:"Column:addr_detail"`
+	SerialNo          string             `form:"serial_no" json:"serial_no" gorm:"-"`
+	ProductType       *ProductType       `form:"product_type" json:"product_type,omitempty" gorm:"-"` // nil 이면 시리얼 번호로 제품 종류를 찾는다.
+	MarketType        MarketType         `form:"market_type" json:"market_type,omitempty" gorm:"Column:market_type"`
+	OrderNo           string             `form:"order_no" json:"order_no,omitempty" gorm:"Column:order_no"` // 주문번호 ( 판매 채널에 따라 필수 )
+	Status            ProductAuthStatus  `form:"-" json:"status,omitempty" gorm:"Column:status"`
+	RejectReason      string             `form:"-" json:"reject_reason,omitempty" gorm:"Column:reject_reason"` // 영수증 확인 반려 사유
+	PurchaseDate      time.Time          `form:"purchase_date" json:"purchase_date" gorm:"Column:purchase_date"`
+	ReceiptS3Location string             `form:"receipt_s3_location" json:"receipt_s3_location,omitempty" gorm:"Column:receipt_s3_location"`
+	ReceiptSha256     string             `form:"-" json:"-" gorm:"Column:receipt_sha256"`
+	ReceiptOCR        *ReceiptCandidates `form:"-" json:"-" gorm:"Column:receipt_ocr"` // 영수증 OCR 로 추출한 구매 정보 후보
+	Regdate           time.Time          `form:"regdate" json:"regdate" gorm:"Column:regdate"`
+	Modified          time.Time          `form:"modified" json:"modified" gorm:"Column:modified"`
 }
 
 func (pr ProductRegist) ToUpdateMap() map[string]interface{} {
@@ -97,7 +104,6 @@ func (pr ProductRegist) ToUpdateMap() map[string]interface{} {
 		"addr":          pr.Addr,
 		"addr_detail":   pr.AddrDetail,
 		"purchase_date": pr.PurchaseDate,
-		"regdate":       pr.Regdate,
 		"modified":      time.Now(),
 	}
 }
@@ -106,65 +112,122 @@ func (pr ProductRegist) TableName() string {
 	return "product_regist"
 }
 
+// Validate 는 제품 인증 요청의 모든 필드를 검증한다. 검증 실패 시 FieldErrors 를 반환한다.
 func (pr ProductRegist) Validate() error {
-	switch {
-	case pr.Name == "":
-		return errors.New("name is required")
-	case pr.Phone == "":
-		return errors.New("phone is required")
-	case pr.Addr == "":
-		return errors.New("addr is required")
-	case pr.AddrDetail == "":
-		return errors.New("addrDetail is required")
-	case pr.PurchaseDate.IsZero():
-		return errors.New("purchaseDate is required")
-	case pr.SerialNo == "":
-		return errors.New("serial number is required")
+	errs := pr.validateContact()
+	requireString(&errs, "serial_no", pr.SerialNo)
+	if pr.ProductType != nil {
+		validateProductType(&errs, "product_type", *pr.ProductType)
 	}
+	validateMarketType(&errs, "market_type", pr.MarketType)
 
-	return nil
+	return errs.Err()
+}
+
+// ValidateUpdate 는 제품 인증 정보 수정 요청( ToUpdateMap 필드 )을 검증한다.
+func (pr ProductRegist) ValidateUpdate() error {
+	errs := pr.validateContact()
+	return errs.Err()
+}
+
+func (pr ProductRegist) validateContact() FieldErrors {
+	var errs FieldErrors
+	requireString(&errs, "name", pr.Name)
+	validatePhone(&errs, "phone", pr.Phone)
+	requireString(&errs, "addr", pr.Addr)
+	requireString(&errs, "addr_detail", pr.AddrDetail)
+	validatePurchaseDate(&errs, "purchase_date", pr.PurchaseDate)
+
+	return errs
+}
+
+var receiptLabel = i18n.Messages{i18n.Korean: "영수증", i18n.English: "receipt"}
+
+// ReceiptFilename 은 lang 으로 번역된 영수증 다운로드 파일명
+func ReceiptFilename(name, phone string, lang i18n.Language) string {
+	return fmt.Sprintf("%s(%s) %s", name, phone, receiptLabel.In(lang))
 }
 
 type ProductManageInfos []ProductManageInfo
 
 type ProductManageInfo struct {
-	ProductSeq           int64             `json:"product_seq,omitempty"`
-	SerialNo             string            `json:"serial_no,omitempty"`
-	ProductType          ProductType       `json:"product_type,omitempty"`
-	ProductRegdate       time.Time         `json:"product_regdate"`
-	ProductRegistSeq     int64             `json:"product_regist_seq,omitempty"`
-	Name                 string            `json:"name,omitempty"`
-	Phone                string            `json:"phone,omitempty"`
-	Addr                 string            `json:"addr,omitempty"`
-	AddrDetail           string            `json:"addr_detail,omitempty"`
-	MarketType           MarketType        `json:"market_type,omitempty"`
-	Status               ProductAuthStatus `json:"status,omitempty"`
-	PurchaseDate         time.Time         `json:"purchase_date"`
-	ProductRegistRegdate time.Time         `json:"product_regist_regdate"`
-	Filename             string            `json:"filename,omitempty"`
+	ProductSeq           int64              `json:"product_seq,omitempty"`
+	SerialNo             string             `json:"serial_no,omitempty"`
+	ProductType          ProductType        `json:"product_type,omitempty"`
+	ProductRegdate       time.Time          `json:"product_regdate"`
+	ProductRegistSeq     int64              `json:"product_regist_seq,omitempty"`
+	Name                 string             `json:"name,omitempty"`
+	Phone                string             `json:"phone,omitempty"`
+	Addr                 string             `json:"addr,omitempty"`
+	AddrDetail           string             `json:"addr_detail,omitempty"`
+	MarketType           MarketType         `json:"market_type,omitempty"`
+	OrderNo              string             `json:"order_no,omitempty"`
+	Status               ProductAuthStatus  `json:"status,omitempty"`
+	RejectReason         string             `json:"reject_reason,omitempty"`
+	PurchaseDate         time.Time          `json:"purchase_date"`
+	WarrantyMonths       int                `json:"warranty_months"` // 제품 모델의 보증 기간
+	ProductRegistRegdate time.Time          `json:"product_regist_regdate"`
+	Filename             string             `json:"filename,omitempty"`
+	ReceiptOCR           *ReceiptCandidates `json:"receipt_ocr,omitempty"` // 영수증 OCR 로 추출한 구매 정보 후보
+}
+
+// Warranty 는 now 기준 무상 보증 기간. 인증되지 않은 제품은 WarrantyStatusUnknown.
+func (info ProductManageInfo) Warranty(now time.Time) Warranty {
+	return NewWarranty(info.PurchaseDate, info.WarrantyMonths, now)
+}
+
+// Localize 는 영수증 파일명을 lang 으로 번역한 목록을 반환한다.
+func (p ProductManageInfos) Localize(lang i18n.Language) interface{} {
+	result := make(ProductManageInfos, len(p))
+	for i, info := range p {
+		if info.Filename != "" {
+			info.Filename = ReceiptFilename(info.Name, info.Phone, lang)
+		}
+		result[i] = info
+	}
+	return result
+}
+
+// receiptURL 은 관리자 영수증 미리보기 경로. 인증 정보가 없으면 빈 문자열.
+func receiptURL(productRegistSeq int64) string {
+	if productRegistSeq == 0 {
+		return ""
+	}
+	return fmt.Sprintf("/v1/product/receipt?product_regist_seq=%d&inline=true", productRegistSeq)
 }
 
 func (p ProductManageInfos) MarshalJSON() ([]byte, error) {
 	type Result struct {
-		ProductSeq           int64             `json:"product_seq,omitempty"`
-		SerialNo             string            `json:"serial_no,omitempty"`
-		ProductType          ProductType       `json:"product_type,omitempty"`
-		ProductRegdate       string            `json:"product_regdate"`
-		ProductRegistSeq     int64             `json:"product_regist_seq,omitempty"`
-		Name                 string            `json:"name,omitempty"`
-		Phone                string            `json:"phone,omitempty"`
-		Addr                 string            `json:"addr,omitempty"`
-		AddrDetail           string            `json:"addr_detail,omitempty"`
-		MarketType           MarketType        `json:"market_type,omitempty"`
-		Status               ProductAuthStatus `json:"status,omitempty"`
-		PurchaseDate         string            `json:"purchase_date"`
-		ProductRegistRegdate string            `json:"product_regist_regdate"`
-		Filename             string            `json:"filename,omitempty"`
+		ProductSeq           int64              `json:"product_seq,omitempty"`
+		SerialNo             string             `json:"serial_no,omitempty"`
+		ProductType          ProductType        `json:"product_type,omitempty"`
+		ProductRegdate       string             `json:"product_regdate"`
+		ProductRegistSeq     int64              `json:"product_regist_seq,omitempty"`
+		Name                 string             `json:"name,omitempty"`
+		Phone                string             `json:"phone,omitempty"`
+		Addr                 string             `json:"addr,omitempty"`
+		AddrDetail           string             `json:"addr_detail,omitempty"`
+		MarketType           MarketType         `json:"market_type,omitempty"`
+		OrderNo              string             `json:"order_no,omitempty"`
+		Status               ProductAuthStatus  `json:"status,omitempty"`
+		RejectReason         string             `json:"reject_reason,omitempty"`
+		PurchaseDate         string             `json:"purchase_date"`
+		WarrantyStart        string             `json:"warranty_start,omitempty"`
+		WarrantyEnd          string             `json:"warranty_end,omitempty"`
+		WarrantyStatus       WarrantyStatus     `json:"warranty_status,omitempty"`
+		ProductRegistRegdate string             `json:"product_regist_regdate"`
+		Filename             string             `json:"filename,omitempty"`
+		ReceiptURL           string             `json:"receipt_url,omitempty"`
+		ReceiptOCR           *ReceiptCandidates `json:"receipt_ocr,omitempty"`
+		PurchaseDateMismatch bool               `json:"purchase_date_mismatch,omitempty"` // OCR 구매일 후보와 구매일이 다름
+		MarketTypeMismatch   bool               `json:"market_type_mismatch,omitempty"`   // OCR 판매 채널 후보와 구매처가 다름
 	}
 
 	results := make([]Result, 0)
+	now := time.Now()
 
 	for _, info := range p {
+		warranty := info.Warranty(now)
 		results = append(results, Result{
 			ProductSeq:           info.ProductSeq,
 			SerialNo:             info.SerialNo,
@@ -176,10 +239,19 @@ func (p ProductManageInfos) MarshalJSON() ([]byte, error) {
 			Addr:                 info.Addr,
 			AddrDetail:           info.AddrDetail,
 			MarketType:           info.MarketType,
+			OrderNo:              info.OrderNo,
 			Status:               info.Status,
+			RejectReason:         info.RejectReason,
 			PurchaseDate:         info.PurchaseDate.Format("2006-01-02"),
+			WarrantyStart:        formatDate(warranty.Start),
+			WarrantyEnd:          formatDate(warranty.End),
+			WarrantyStatus:       warranty.Status,
 			ProductRegistRegdate: info.ProductRegistRegdate.Format("2006-01-02"),
 			Filename:             info.Filename,
+			ReceiptURL:           receiptURL(info.ProductRegistSeq),
+			ReceiptOCR:           info.ReceiptOCR,
+			PurchaseDateMismatch: info.ReceiptOCR.PurchaseDateMismatch(info.PurchaseDate),
+			MarketTypeMismatch:   info.ReceiptOCR.MarketTypeMismatch(info.MarketType),
 		})
 	}
 
@@ -187,34 +259,83 @@ func (p ProductManageInfos) MarshalJSON() ([]byte, error) {
 }
 
 type ProductAuthInfo struct {
-	Name         string      `json:"name,omitempty" gorm:"Column:name"`
-	Phone        string      `json:"phone,omitempty" gorm:"Column:phone"`
-	ProductType  ProductType `json:"product_type,omitempty" gorm:"Column:product_type"`
-	MarketType   MarketType  `json:"market_type,omitempty" gorm:"Column:market_type"`
-	PurchaseDate time.Time   `json:"purchase_date,omitempty" gorm:"Column:purchase_date"`
-	SerialNo     string      `json:"serial_no,omitempty" gorm:"Column:serial_no"`
+	ProductRegistSeq int64             `json:"product_regist_seq,omitempty" gorm:"Column:product_regist_seq"`
+	Status           ProductAuthStatus `json:"status,omitempty" gorm:"Column:status"`
+	RejectReason     string            `json:"reject_reason,omitempty" gorm:"Column:reject_reason"`
+	Name             string            `json:"name,omitempty" gorm:"Column:name"`
+	Phone            string            `json:"phone,omitempty" gorm:"Column:phone"`
+	ProductType      ProductType       `json:"product_type,omitempty" gorm:"Column:product_type"`
+	MarketType       MarketType        `json:"market_type,omitempty" gorm:"Column:market_type"`
+	OrderNo          string            `json:"order_no,omitempty" gorm:"Column:order_no"`
+	PurchaseDate     time.Time         `json:"purchase_date,omitempty" gorm:"Column:purchase_date"`
+	SerialNo         string            `json:"serial_no,omitempty" gorm:"Column:serial_no"`
+	WarrantyMonths   int               `json:"warranty_months,omitempty" gorm:"->;Column:warranty_months"` // 제품 모델의 보증 기간
+	ProductName
+	MarketName
+
+	lang i18n.Language // 제품명, 구매처명 언어
 }
 
 func (pa ProductAuthInfo) TableName() string {
 	return "product_regist"
 }
 
+// Localize 는 제품명, 구매처명을 lang 으로 번역하여 응답하는 인증 정보를 반환한다.
+func (pa ProductAuthInfo) Localize(lang i18n.Language) interface{} {
+	pa.lang = lang
+	return pa
+}
+
+// Warranty 는 now 기준 무상 보증 기간
+func (pa ProductAuthInfo) Warranty(now time.Time) Warranty {
+	return NewWarranty(pa.PurchaseDate, pa.WarrantyMonths, now)
+}
+
 func (pa ProductAuthInfo) MarshalJSON() ([]byte, error) {
+	warranty := pa.Warranty(time.Now())
 	result := struct {
-		Name         string `json:"name,omitempty"`
-		Phone        string `json:"phone,omitempty"`
-		ProductType  string `json:"product_type,omitempty"`
-		MarketType   string `json:"market_type,omitempty"`
-		PurchaseDate string `json:"purchase_date,omitempty"`
-		SerialNo     string `json:"serial_no,omitempty"`
+		ProductRegistSeq int64             `json:"product_regist_seq,omitempty"`
+		Status           ProductAuthStatus `json:"status,omitempty"`
+		RejectReason     string            `json:"reject_reason,omitempty"`
+		Name             string            `json:"name,omitempty"`
+		Phone            string            `json:"phone,omitempty"`
+		ProductType      string            `json:"product_type,omitempty"`
+		MarketType       string            `json:"market_type,omitempty"`
+		OrderNo          string            `json:"order_no,omitempty"`
+		PurchaseDate     string            `json:"purchase_date,omitempty"`
+		SerialNo         string            `json:"serial_no,omitempty"`
+		WarrantyStart    string            `json:"warranty_start,omitempty"`
+		WarrantyEnd      string            `json:"warranty_end,omitempty"`
+		WarrantyStatus   WarrantyStatus    `json:"warranty_status,omitempty"`
 	}{
-		Name:         pa.Name,
-		Phone:        pa.Phone,
-		ProductType:  pa.ProductType.String(),
-		MarketType:   pa.MarketType.String(),
-		PurchaseDate: pa.PurchaseDate.Format("2006-01-02"),
-		SerialNo:     pa.SerialNo,
+		ProductRegistSeq: pa.ProductRegistSeq,
+		Status:           pa.Status,
+		RejectReason:     pa.RejectReason,
+		Name:             pa.Name,
+		Phone:            pa.Phone,
+		ProductType:      pa.ProductName.Label(pa.lang),
+		MarketType:       pa.MarketName.Label(pa.lang),
+		OrderNo:          pa.OrderNo,
+		PurchaseDate:     pa.PurchaseDate.Format("2006-01-02"),
+		SerialNo:         pa.SerialNo,
+		WarrantyStart:    formatDate(warranty.Start),
+		WarrantyEnd:      formatDate(warranty.End),
+		WarrantyStatus:   warranty.Status,
 	}
 
 	return jsoniter.Marshal(result)
 }
+
+// ProductAuthInfos 는 고객의 제품 인증 정보 목록 응답
+type ProductAuthInfos []*ProductAuthInfo
+
+// Localize 는 제품명, 구매처명을 lang 으로 번역하여 응답하는 목록을 반환한다.
+func (p ProductAuthInfos) Localize(lang i18n.Language) interface{} {
+	result := make(ProductAuthInfos, len(p))
+	for i, info := range p {
+		localized := *info
+		localized.lang = lang
+		result[i] = &localized
+	}
+	return result
+}
@@ -0,0 +1,161 @@
+package model
+
+import (
+	"buddle-server/internal/i18n"
+	"net/url"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// ProductModel 은 제품 모델 카탈로그 ( product_model 테이블 ).
+// Code 는 product.product_type, after_service.product_type 값이다.
+type ProductModel struct {
+	Code           ProductType `form:"code" json:"code" gorm:"Column:code;PRIMARY_KEY;autoIncrement:false"`
+	NameKo         string      `form:"name_ko" json:"name_ko" gorm:"Column:name_ko"`
+	NameEn         string      `form:"name_en" json:"name_en" gorm:"Column:name_en"`
+	ModelNo        string      `form:"model_no" json:"model_no" gorm:"Column:model_no"`
+	ReleaseDate    *time.Time  `form:"release_date" json:"release_date" gorm:"Column:release_date"`
+	WarrantyMonths int         `form:"warranty_months" json:"warranty_months" gorm:"Column:warranty_months"`
+	Active         bool        `form:"active" json:"active" gorm:"Column:active"` // 판매 중 ( 공개 목록 노출, 시리얼 등록 가능 )
+	ImageURL       string      `form:"image_url" json:"image_url" gorm:"Column:image_url"`
+
+	// 시리얼 번호 형식 ( SerialRule )
+	SerialPrefix     string        `form:"serial_prefix" json:"serial_prefix" gorm:"Column:serial_prefix"`
+	SerialLength     int           `form:"serial_length" json:"serial_length" gorm:"Column:serial_length"`
+	SerialCharset    SerialCharset `form:"serial_charset" json:"serial_charset" gorm:"Column:serial_charset"`
+	SerialCheckDigit bool          `form:"serial_check_digit" json:"serial_check_digit" gorm:"Column:serial_check_digit"`
+
+	Regdate  time.Time `form:"-" json:"regdate" gorm:"Column:regdate"`
+	Modified time.Time `form:"-" json:"modified" gorm:"Column:modified"`
+
+	lang i18n.Language // 응답 제품명 언어
+}
+
+func (m ProductModel) TableName() string {
+	return "product_model"
+}
+
+// SerialRule 은 제품 모델의 시리얼 번호 형식
+func (m ProductModel) SerialRule() SerialRule {
+	return SerialRule{Prefix: m.SerialPrefix, Length: m.SerialLength, Charset: m.SerialCharset, CheckDigit: m.SerialCheckDigit}
+}
+
+// Name 은 언어별 제품명
+func (m ProductModel) Name() i18n.Messages {
+	return i18n.Messages{i18n.Korean: m.NameKo, i18n.English: m.NameEn}
+}
+
+func (m ProductModel) CatalogCode() int {
+	return int(m.Code)
+}
+
+func (m *ProductModel) SetCatalogCode(code int) {
+	m.Code = ProductType(code)
+}
+
+func (m *ProductModel) SetRegdate(regdate time.Time) {
+	if m.Regdate.IsZero() {
+		m.Regdate = regdate
+	}
+	m.Modified = m.Regdate
+}
+
+func (m ProductModel) ToUpdateMap() map[string]interface{} {
+	return map[string]interface{}{
+		"name_ko":            m.NameKo,
+		"name_en":            m.NameEn,
+		"model_no":           m.ModelNo,
+		"release_date":       m.ReleaseDate,
+		"warranty_months":    m.WarrantyMonths,
+		"active":             m.Active,
+		"image_url":          m.ImageURL,
+		"serial_prefix":      m.SerialPrefix,
+		"serial_length":      m.SerialLength,
+		"serial_charset":     m.SerialCharset,
+		"serial_check_digit": m.SerialCheckDigit,
+		"modified":           time.Now(),
+	}
+}
+
+// Validate 는 제품 모델 등록, 수정 요청의 모든 필드를 검증한다. 검증 실패 시 FieldErrors 를 반환한다.
+func (m ProductModel) Validate() error {
+	var errs FieldErrors
+	validateProductType(&errs, "code", m.Code)
+	requireString(&errs, "name_ko", m.NameKo)
+	requireString(&errs, "name_en", m.NameEn)
+	if m.WarrantyMonths < 0 {
+		errs.Add("warranty_months", ValidationRuleRange, i18n.Messages{i18n.Korean: "보증 기간은 0개월 이상이어야 합니다.", i18n.English: "The warranty period cannot be negative."})
+	}
+	if m.ImageURL != "" {
+		if u, err := url.Parse(m.ImageURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs.Add("image_url", ValidationRuleFormat, i18n.Messages{i18n.Korean: "이미지 URL 형식이 올바르지 않습니다.", i18n.English: "The image URL format is invalid."})
+		}
+	}
+	m.SerialRule().validate(&errs)
+
+	return errs.Err()
+}
+
+func (m ProductModel) MarshalJSON() ([]byte, error) {
+	result := struct {
+		Code           ProductType `json:"code"`
+		Name           string      `json:"name"`
+		NameKo         string      `json:"name_ko"`
+		NameEn         string      `json:"name_en"`
+		ModelNo        string      `json:"model_no"`
+		ReleaseDate    string      `json:"release_date,omitempty"`
+		WarrantyMonths int         `json:"warranty_months"`
+		Active         bool        `json:"active"`
+		ImageURL       string      `json:"image_url,omitempty"`
+
+		SerialPrefix     string        `json:"serial_prefix"`
+		SerialLength     int           `json:"serial_length"`
+		SerialCharset    SerialCharset `json:"serial_charset"`
+		SerialCheckDigit bool          `json:"serial_check_digit"`
+	}{
+		Code:           m.Code,
+		Name:           m.Name().In(m.lang),
+		NameKo:         m.NameKo,
+		NameEn:         m.NameEn,
+		ModelNo:        m.ModelNo,
+		WarrantyMonths: m.WarrantyMonths,
+		Active:         m.Active,
+		ImageURL:       m.ImageURL,
+
+		SerialPrefix:     m.SerialPrefix,
+		SerialLength:     m.SerialLength,
+		SerialCharset:    m.SerialCharset,
+		SerialCheckDigit: m.SerialCheckDigit,
+	}
+	if m.ReleaseDate != nil {
+		result.ReleaseDate = m.ReleaseDate.Format("2006-01-02")
+	}
+
+	return jsoniter.Marshal(result)
+}
+
+// ProductModels 는 제품 모델 목록 응답
+type ProductModels []*ProductModel
+
+// Localize 는 제품명( name )을 lang 으로 번역하여 응답하는 목록을 반환한다.
+func (m ProductModels) Localize(lang i18n.Language) interface{} {
+	result := make(ProductModels, len(m))
+	for i, pm := range m {
+		localized := *pm
+		localized.lang = lang
+		result[i] = &localized
+	}
+	return result
+}
+
+// ProductName 은 product_model 과 join 하여 조회한 언어별 제품명
+type ProductName struct {
+	ProductNameKo string `form:"-" json:"-" gorm:"->;Column:product_name_ko"`
+	ProductNameEn string `form:"-" json:"-" gorm:"->;Column:product_name_en"`
+}
+
+// Label 은 lang 으로 번역된 제품명. 제품 모델이 없으면 빈 문자열.
+func (n ProductName) Label(lang i18n.Language) string {
+	return i18n.Messages{i18n.Korean: n.ProductNameKo, i18n.English: n.ProductNameEn}.In(lang)
+}
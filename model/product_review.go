@@ -0,0 +1,24 @@
+package model
+
+import (
+	"buddle-server/internal/i18n"
+	"unicode/utf8"
+)
+
+// ProductRejectReasonMaxLength 는 영수증 확인 반려 사유의 최대 글자 수 ( product_regist.reject_reason )
+const ProductRejectReasonMaxLength = 255
+
+// ProductRejectRequest 는 관리자의 제품 인증 반려 요청
+type ProductRejectRequest struct {
+	Reason string `json:"reason" form:"reason"`
+}
+
+// Validate 는 반려 사유를 검증한다. 검증 실패 시 FieldErrors 를 반환한다.
+func (r ProductRejectRequest) Validate() error {
+	var errs FieldErrors
+	if requireString(&errs, "reason", r.Reason) && utf8.RuneCountInString(r.Reason) > ProductRejectReasonMaxLength {
+		errs.Add("reason", ValidationRuleRange, i18n.Messages{i18n.Korean: "반려 사유는 255자 이하로 입력해 주세요.", i18n.English: "The rejection reason must be 255 characters or fewer."})
+	}
+
+	return errs.Err()
+}
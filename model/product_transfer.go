@@ -0,0 +1,93 @@
+package model
+
+import (
+	"time"
+)
+
+// ProductTransferExpiration 은 소유권 이전 코드의 유효 기간
+const ProductTransferExpiration = 7 * 24 * time.Hour
+
+type ProductTransferStatus int
+
+const (
+	ProductTransferStatusPending ProductTransferStatus = iota // 새 소유자의 인증 대기
+	ProductTransferStatusDone                                 // 이전 완료
+	ProductTransferStatusCancel                               // 이전 취소 ( 새 이전 요청, 인증 취소 )
+)
+
+// ProductTransfer 는 제품 소유권 이전 요청 ( product_transfer 테이블 ).
+// 이전 완료 시 양도인의 인증 정보( FromProductRegistSeq )는 소유권 이전 상태로 남는다.
+type ProductTransfer struct {
+	ProductTransferSeq   int64                 `json:"product_transfer_seq" gorm:"Column:product_transfer_seq;PRIMARY_KEY"`
+	ProductSeq           int64                 `json:"product_seq" gorm:"Column:product_seq"`
+	FromProductRegistSeq int64                 `json:"from_product_regist_seq" gorm:"Column:from_product_regist_seq"`
+	ToProductRegistSeq   int64                 `json:"to_product_regist_seq,omitempty" gorm:"Column:to_product_regist_seq"`
+	TransferCode         string                `json:"transfer_code" gorm:"Column:transfer_code"`
+	Status               ProductTransferStatus `json:"status" gorm:"Column:status"`
+	ExpireDate           time.Time             `json:"expire_date" gorm:"Column:expire_date"`
+	Regdate              time.Time             `json:"regdate" gorm:"Column:regdate"`
+	Modified             time.Time             `json:"modified" gorm:"Column:modified"`
+}
+
+func (t ProductTransfer) TableName() string {
+	return "product_transfer"
+}
+
+// Expired 는 now 기준으로 이전 코드의 유효 기간이 지났는지 확인한다.
+func (t ProductTransfer) Expired(now time.Time) bool {
+	return !now.Before(t.ExpireDate)
+}
+
+// ProductTransferRequest 는 관리자의 소유권 이전 요청
+type ProductTransferRequest struct {
+	ProductRegistSeq int64 `json:"product_regist_seq" form:"product_regist_seq"`
+}
+
+// ProductTransferClaim 은 새 소유자가 시리얼 번호, 이전 코드로 제품을 인증하는 요청
+type ProductTransferClaim struct {
+	SerialNo     string       `form:"serial_no" json:"serial_no"`
+	ProductType  *ProductType `form:"product_type" json:"product_type,omitempty"` // nil 이면 시리얼 번호로 제품 종류를 찾는다.
+	TransferCode string       `form:"transfer_code" json:"transfer_code"`
+	Name         string       `form:"name" json:"name"`
+	Phone        string       `form:"phone" json:"phone"`
+	Addr         string       `form:"addr" json:"addr"`
+	AddrDetail   string       `form:"addr_detail" json:"addr_detail"`
+}
+
+// Validate 는 소유권 이전 인증 요청의 모든 필드를 검증한다. 검증 실패 시 FieldErrors 를 반환한다.
+func (t ProductTransferClaim) Validate() error {
+	var errs FieldErrors
+	requireString(&errs, "serial_no", t.SerialNo)
+	if t.ProductType != nil {
+		validateProductType(&errs, "product_type", *t.ProductType)
+	}
+	requireString(&errs, "transfer_code", t.TransferCode)
+	requireString(&errs, "name", t.Name)
+	validatePhone(&errs, "phone", t.Phone)
+	requireString(&errs, "addr", t.Addr)
+	requireString(&errs, "addr_detail", t.AddrDetail)
+
+	return errs.Err()
+}
+
+// ProductRegist 는 새 소유자의 product 제품 인증 정보를 생성한다.
+// 구매 정보와 영수증은 양도인의 인증 정보를 그대로 사용하므로 영수증 확인 없이 인증 완료 상태가 된다.
+func (t ProductTransferClaim) ProductRegist(product *Product, from *ProductRegist) *ProductRegist {
+	productType := product.ProductType
+	return &ProductRegist{
+		ProductSeq:        from.ProductSeq,
+		Name:              t.Name,
+		Phone:             t.Phone,
+		Addr:              t.Addr,
+		AddrDetail:        t.AddrDetail,
+		SerialNo:          product.SerialNo,
+		ProductType:       &productType,
+		MarketType:        from.MarketType,
+		OrderNo:           from.OrderNo,
+		PurchaseDate:      from.PurchaseDate,
+		ReceiptS3Location: from.ReceiptS3Location,
+		ReceiptSha256:     from.ReceiptSha256,
+		ReceiptOCR:        from.ReceiptOCR,
+		Status:            ProductAuthStatusOK,
+	}
+}
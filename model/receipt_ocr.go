@@ -0,0 +1,69 @@
+package model
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// ReceiptCandidates 는 영수증 OCR 로 추출한 구매 정보 후보 ( product_regist.receipt_ocr, JSON )
+// 관리자가 영수증을 확인할 때 고객이 입력한 구매일, 구매처와 비교한다.
+type ReceiptCandidates struct {
+	Provider      string       `json:"provider"`                 // OCR 서비스
+	PurchaseDates []string     `json:"purchase_dates,omitempty"` // 2006-01-02
+	Merchants     []string     `json:"merchants,omitempty"`
+	Amounts       []int64      `json:"amounts,omitempty"`
+	MarketTypes   []MarketType `json:"market_types,omitempty"` // 영수증에서 이름을 찾은 판매 채널
+}
+
+// PurchaseDateMismatch 는 구매일 후보가 있고 그 중 purchaseDate 가 없으면 true.
+func (r *ReceiptCandidates) PurchaseDateMismatch(purchaseDate time.Time) bool {
+	if r == nil || len(r.PurchaseDates) == 0 {
+		return false
+	}
+
+	date := purchaseDate.Format("2006-01-02")
+	for _, d := range r.PurchaseDates {
+		if d == date {
+			return false
+		}
+	}
+	return true
+}
+
+// MarketTypeMismatch 는 판매 채널 후보가 있고 그 중 marketType 이 없으면 true.
+func (r *ReceiptCandidates) MarketTypeMismatch(marketType MarketType) bool {
+	if r == nil || len(r.MarketTypes) == 0 {
+		return false
+	}
+
+	for _, m := range r.MarketTypes {
+		if m == marketType {
+			return false
+		}
+	}
+	return true
+}
+
+func (r ReceiptCandidates) Value() (driver.Value, error) {
+	b, err := jsoniter.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (r *ReceiptCandidates) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return jsoniter.Unmarshal(v, r)
+	case string:
+		return jsoniter.Unmarshal([]byte(v), r)
+	}
+
+	return fmt.Errorf("unsupported receipt_ocr type %T", src)
+}
@@ -0,0 +1,58 @@
+package model_test
+
+import (
+	"buddle-server/model"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReceiptCandidates_Mismatch(t *testing.T) {
+	purchaseDate := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	var none *model.ReceiptCandidates
+	if none.PurchaseDateMismatch(purchaseDate) || none.MarketTypeMismatch(model.MarketTypeCoupang) {
+		t.Errorf("nil candidates mismatch = true")
+	}
+	if empty := (&model.ReceiptCandidates{Provider: "plaintext"}); empty.PurchaseDateMismatch(purchaseDate) || empty.MarketTypeMismatch(model.MarketTypeCoupang) {
+		t.Errorf("empty candidates mismatch = true")
+	}
+
+	candidates := &model.ReceiptCandidates{
+		PurchaseDates: []string{"2022-02-27", "2022-03-01"},
+		MarketTypes:   []model.MarketType{model.MarketTypeCoupang},
+	}
+	if candidates.PurchaseDateMismatch(purchaseDate) || candidates.MarketTypeMismatch(model.MarketTypeCoupang) {
+		t.Errorf("matched candidates mismatch = true")
+	}
+	if !candidates.PurchaseDateMismatch(purchaseDate.AddDate(0, 0, 1)) || !candidates.MarketTypeMismatch(model.MarketTypeNaver) {
+		t.Errorf("different candidates mismatch = false")
+	}
+}
+
+func TestReceiptCandidates_ValueScan(t *testing.T) {
+	candidates := model.ReceiptCandidates{
+		Provider:      "plaintext",
+		PurchaseDates: []string{"2022-03-01"},
+		Merchants:     []string{"쿠팡(주)"},
+		Amounts:       []int64{39000},
+		MarketTypes:   []model.MarketType{model.MarketTypeCoupang},
+	}
+
+	value, err := candidates.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %+v", err)
+	}
+
+	var got model.ReceiptCandidates
+	if err := got.Scan(value); err != nil {
+		t.Fatalf("Scan() error = %+v", err)
+	}
+	if !reflect.DeepEqual(got, candidates) {
+		t.Errorf("Scan(Value()) = %+v, want %+v", got, candidates)
+	}
+
+	if err := got.Scan(42); err == nil {
+		t.Errorf("Scan(int) error = nil")
+	}
+}
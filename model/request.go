@@ -1,5 +1,9 @@
 package model
 
+import (
+	"strings"
+)
+
 type ProductManageRequest struct {
 	Limit      int               `json:"limit,omitempty" query:"limit"`
 	Offset     int               `json:"offset,omitempty" query:"offset"`
@@ -13,11 +17,22 @@ func (r ProductManageRequest) Validate() error {
 	return nil
 }
 
+// ProductAuthRequest 는 제품 인증 정보를 조회, 변경하는 고객 정보 ( 이름, 휴대폰 번호 )
 type ProductAuthRequest struct {
 	Name  string `json:"name,omitempty" query:"name"`
 	Phone string `json:"phone,omitempty" query:"phone"`
 }
 
+// Matches 는 이름, 휴대폰 번호가 요청한 고객과 일치하는지 확인한다. 휴대폰 번호의 '-' 는 무시한다.
+func (r ProductAuthRequest) Matches(name, phone string) bool {
+	return r.Name != "" && r.Name == name && NormalizePhone(r.Phone) == NormalizePhone(phone)
+}
+
+// NormalizePhone 은 비교할 수 있도록 휴대폰 번호의 '-' 를 제거한다.
+func NormalizePhone(phone string) string {
+	return strings.ReplaceAll(phone, "-", "")
+}
+
 type AfterServiceRequest struct {
 	Name  string `json:"name,omitempty" query:"name"`
 	Phone string `json:"phone,omitempty" query:"phone"`
@@ -1,31 +1,55 @@
 package model
 
-type ResponseErrorCode string
-
-const (
-	ResponseErrorCodeDuplProduct     ResponseErrorCode = "1000" // 이미 등록된 제품을 등록 하려는 경우
-	ResponseErrorCodeProductNotExist ResponseErrorCode = "1001" // 제품이 존재하지 않음
-	ResponseErrorCodeUserIDNotExist  ResponseErrorCode = "1002" // 회원 아이디가 존재하지 않음
-	ResponseErrorCodeInvalidUserPwd  ResponseErrorCode = "1003" // 회원 비밀번호가 일치하지 않음
-
-)
+import "buddle-server/internal/i18n"
 
 type Response struct {
-	Success   bool              `json:"success"`
-	Message   string            `json:"message,omitempty"`
-	ErrorCode ResponseErrorCode `json:"error_code,omitempty"`
-	Data      interface{}       `json:"data,omitempty"`
+	Success     bool                `json:"success"`
+	Message     string              `json:"message,omitempty"`
+	MessageCode ResponseMessageCode `json:"-"` // 성공 메시지 코드 ( Localize 시 Message 로 번역 )
+	ErrorCode   ResponseErrorCode   `json:"error_code,omitempty"`
+	Data        interface{}         `json:"data,omitempty"`
+	Errors      FieldErrors         `json:"errors,omitempty"` // 필드 검증 에러
 }
 
 func SimpleSuccess() *Response {
+	return NewSuccess(ResponseMessageSuccess, nil)
+}
+
+// NewSuccess 는 메시지 코드와 데이터를 가진 성공 응답
+func NewSuccess(code ResponseMessageCode, data interface{}) *Response {
 	return &Response{
-		Success: true,
-		Message: "성공하였습니다.",
+		Success:     true,
+		Message:     code.Message(i18n.DefaultLanguage),
+		MessageCode: code,
+		Data:        data,
 	}
 }
 
+// SimpleFail 은 서버 내부 오류 응답
 func SimpleFail() *Response {
 	return &Response{
-		Success: true,
+		Success:   false,
+		Message:   ResponseErrorCodeInternal.Message(i18n.DefaultLanguage),
+		ErrorCode: ResponseErrorCodeInternal,
+	}
+}
+
+// Localize 는 메시지 코드, 에러 코드, 필드 에러와 Data( Localizer )를 lang 으로 번역한 응답을 반환한다.
+func (r Response) Localize(lang i18n.Language) *Response {
+	switch {
+	case r.MessageCode != "":
+		r.Message = r.MessageCode.Message(lang)
+	case r.ErrorCode != "" && r.Message != "":
+		r.Message = r.ErrorCode.Message(lang)
+	}
+
+	if len(r.Errors) > 0 {
+		r.Errors = r.Errors.Localize(lang)
 	}
+
+	if data, ok := r.Data.(Localizer); ok {
+		r.Data = data.Localize(lang)
+	}
+
+	return &r
 }
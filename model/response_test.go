@@ -0,0 +1,70 @@
+package model_test
+
+import (
+	"buddle-server/internal/i18n"
+	"buddle-server/model"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestResponse_Localize(t *testing.T) {
+	if resp := model.SimpleSuccess().Localize(i18n.English); resp.Message != "Success." {
+		t.Errorf("success message = %q", resp.Message)
+	}
+
+	fail := model.SimpleFail()
+	if resp := fail.Localize(i18n.English); resp.Message != "An internal server error occurred." {
+		t.Errorf("error message = %q", resp.Message)
+	}
+	if fail.Message != "서버 오류가 발생하였습니다." {
+		t.Errorf("original response is modified: %q", fail.Message)
+	}
+
+	var fields model.FieldErrors
+	as := validAfterService()
+	as.Name, as.ProductType = "", -1
+	if err := as.Validate(); err != nil {
+		fields = err.(model.FieldErrors)
+	}
+	resp := (&model.Response{ErrorCode: model.ResponseErrorCodeInvalidRequest, Message: "요청 파라미터가 잘못 되었습니다.", Errors: fields}).Localize(i18n.English)
+	want := map[string]string{"name": "Please enter the name.", "product_type": "The product type is invalid."}
+	if len(resp.Errors) != len(want) {
+		t.Fatalf("errors = %+v", resp.Errors)
+	}
+	for _, fe := range resp.Errors {
+		if fe.Message != want[fe.Field] {
+			t.Errorf("%s: message = %q, want %q", fe.Field, fe.Message, want[fe.Field])
+		}
+	}
+
+	as = validAfterService()
+	as.ProductName = model.ProductName{ProductNameKo: "버들아이 젖병세척기", ProductNameEn: "Buddle Kids Baby Bottle Washer"}
+	as.MarketName = model.MarketName{MarketNameKo: "네이버", MarketNameEn: "Naver"}
+	body, err := json.Marshal(model.NewSuccess(model.ResponseMessageSuccess, model.AfterServices{&as}).Localize(i18n.English))
+	if err != nil {
+		t.Fatalf("failed to marshal: %+v", err)
+	}
+	for _, label := range []string{`"product_type":"Buddle Kids Baby Bottle Washer"`, `"market_type":"Naver"`} {
+		if !strings.Contains(string(body), label) {
+			t.Errorf("body = %s, want %s", body, label)
+		}
+	}
+}
+
+func TestLabels(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{model.ProductName{ProductNameKo: "버들아이 젖병세척기"}.Label(i18n.English), "버들아이 젖병세척기"},
+		{model.MarketName{MarketNameKo: "쿠팡", MarketNameEn: "Coupang"}.Label(i18n.English), "Coupang"},
+		{model.MarketName{}.Label(i18n.English), ""},
+		{model.ReceiptFilename("홍길동", "01012345678", i18n.English), "홍길동(01012345678) receipt"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
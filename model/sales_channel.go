@@ -0,0 +1,111 @@
+package model
+
+import (
+	"buddle-server/internal/i18n"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// SalesChannel 은 판매 채널 카탈로그 ( sales_channel 테이블 ).
+// Code 는 product_regist.market_type, after_service.market_type 값이다.
+type SalesChannel struct {
+	Code            MarketType `form:"code" json:"code" gorm:"Column:code;PRIMARY_KEY;autoIncrement:false"`
+	NameKo          string     `form:"name_ko" json:"name_ko" gorm:"Column:name_ko"`
+	NameEn          string     `form:"name_en" json:"name_en" gorm:"Column:name_en"`
+	Active          bool       `form:"active" json:"active" gorm:"Column:active"`                                  // 판매 중 ( 공개 목록 노출 )
+	OrderNoRequired bool       `form:"order_no_required" json:"order_no_required" gorm:"Column:order_no_required"` // 제품 인증, A/S 신청 시 주문번호 필수
+	Regdate         time.Time  `form:"-" json:"regdate" gorm:"Column:regdate"`
+	Modified        time.Time  `form:"-" json:"modified" gorm:"Column:modified"`
+
+	lang i18n.Language // 응답 채널명 언어
+}
+
+func (s SalesChannel) TableName() string {
+	return "sales_channel"
+}
+
+// Name 은 언어별 채널명
+func (s SalesChannel) Name() i18n.Messages {
+	return i18n.Messages{i18n.Korean: s.NameKo, i18n.English: s.NameEn}
+}
+
+func (s SalesChannel) CatalogCode() int {
+	return int(s.Code)
+}
+
+func (s *SalesChannel) SetCatalogCode(code int) {
+	s.Code = MarketType(code)
+}
+
+func (s *SalesChannel) SetRegdate(regdate time.Time) {
+	if s.Regdate.IsZero() {
+		s.Regdate = regdate
+	}
+	s.Modified = s.Regdate
+}
+
+func (s SalesChannel) ToUpdateMap() map[string]interface{} {
+	return map[string]interface{}{
+		"name_ko":           s.NameKo,
+		"name_en":           s.NameEn,
+		"active":            s.Active,
+		"order_no_required": s.OrderNoRequired,
+		"modified":          time.Now(),
+	}
+}
+
+// Validate 는 판매 채널 등록, 수정 요청의 모든 필드를 검증한다. 검증 실패 시 FieldErrors 를 반환한다.
+func (s SalesChannel) Validate() error {
+	var errs FieldErrors
+	validateMarketType(&errs, "code", s.Code)
+	requireString(&errs, "name_ko", s.NameKo)
+	requireString(&errs, "name_en", s.NameEn)
+
+	return errs.Err()
+}
+
+func (s SalesChannel) MarshalJSON() ([]byte, error) {
+	result := struct {
+		Code            MarketType `json:"code"`
+		Name            string     `json:"name"`
+		NameKo          string     `json:"name_ko"`
+		NameEn          string     `json:"name_en"`
+		Active          bool       `json:"active"`
+		OrderNoRequired bool       `json:"order_no_required"`
+	}{
+		Code:            s.Code,
+		Name:            s.Name().In(s.lang),
+		NameKo:          s.NameKo,
+		NameEn:          s.NameEn,
+		Active:          s.Active,
+		OrderNoRequired: s.OrderNoRequired,
+	}
+
+	return jsoniter.Marshal(result)
+}
+
+// SalesChannels 는 판매 채널 목록 응답
+type SalesChannels []*SalesChannel
+
+// Localize 는 채널명( name )을 lang 으로 번역하여 응답하는 목록을 반환한다.
+func (s SalesChannels) Localize(lang i18n.Language) interface{} {
+	result := make(SalesChannels, len(s))
+	for i, channel := range s {
+		localized := *channel
+		localized.lang = lang
+		result[i] = &localized
+	}
+	return result
+}
+
+// MarketName 은 sales_channel 과 join 하여 조회한 언어별 채널명
+type MarketName struct {
+	MarketNameKo string `form:"-" json:"-" gorm:"->;Column:market_name_ko"`
+	MarketNameEn string `form:"-" json:"-" gorm:"->;Column:market_name_en"`
+}
+
+// Label 은 lang 으로 번역된 채널명. 판매 채널이 없으면 빈 문자열.
+func (n MarketName) Label(lang i18n.Language) string {
+	return i18n.Messages{i18n.Korean: n.MarketNameKo, i18n.English: n.MarketNameEn}.In(lang)
+}
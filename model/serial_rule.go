@@ -0,0 +1,204 @@
+package model
+
+import (
+	"buddle-server/internal/i18n"
+	"fmt"
+	"strings"
+)
+
+// SerialCharset 은 시리얼 번호에 사용할 수 있는 문자 집합
+type SerialCharset string
+
+const (
+	SerialCharsetAny          SerialCharset = ""             // 제한 없음
+	SerialCharsetNumeric      SerialCharset = "numeric"      // 숫자
+	SerialCharsetAlphanumeric SerialCharset = "alphanumeric" // 숫자, 영문 대문자
+)
+
+// alphabet 은 문자 집합의 문자 목록. 체크 디지트 계산 순서이기도 하다.
+func (c SerialCharset) alphabet() string {
+	switch c {
+	case SerialCharsetNumeric:
+		return "0123456789"
+	case SerialCharsetAlphanumeric:
+		return "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	}
+	return ""
+}
+
+func (c SerialCharset) Validate() error {
+	switch c {
+	case SerialCharsetAny, SerialCharsetNumeric, SerialCharsetAlphanumeric:
+		return nil
+	}
+	return fmt.Errorf("invalid serial charset(%s)", string(c))
+}
+
+// NormalizeSerial 은 제품 CSV 등록과 같이 시리얼 번호의 모든 공백을 제거한다.
+func NormalizeSerial(serial string) string {
+	return strings.ReplaceAll(serial, " ", "")
+}
+
+// SerialRule 은 제품 모델별 시리얼 번호 형식. 값이 없는 항목은 확인하지 않는다.
+// 체크 디지트는 마지막 문자이며, 앞의 모든 문자( 접두사 포함 )로 계산한 Luhn mod N 값이다.
+type SerialRule struct {
+	Prefix     string        // 접두사
+	Length     int           // 전체 길이 ( 접두사, 체크 디지트 포함 ), 0 이면 제한 없음
+	Charset    SerialCharset // 문자 집합
+	CheckDigit bool          // 체크 디지트 사용 ( Charset 필수 )
+}
+
+// Defined 는 시리얼 형식이 하나라도 정의되어 있는지 확인한다.
+func (r SerialRule) Defined() bool {
+	return r.Prefix != "" || r.Length > 0 || r.Charset != SerialCharsetAny || r.CheckDigit
+}
+
+// Check 는 시리얼 번호가 형식에 맞는지 확인한다. 맞지 않으면 언어별 사유를 반환한다.
+func (r SerialRule) Check(serial string) i18n.Messages {
+	if serial == "" {
+		return serialRequiredMessages
+	}
+	if !strings.HasPrefix(serial, r.Prefix) {
+		return i18n.Messages{
+			i18n.Korean:  fmt.Sprintf("시리얼 번호는 %s(으)로 시작해야 합니다.", r.Prefix),
+			i18n.English: fmt.Sprintf("The serial number must start with %s.", r.Prefix),
+		}
+	}
+	if r.Length > 0 && len(serial) != r.Length {
+		return i18n.Messages{
+			i18n.Korean:  fmt.Sprintf("시리얼 번호는 %d자리입니다.", r.Length),
+			i18n.English: fmt.Sprintf("The serial number must be %d characters long.", r.Length),
+		}
+	}
+
+	alphabet := r.Charset.alphabet()
+	if alphabet == "" {
+		return nil
+	}
+	for _, ch := range serial {
+		if !strings.ContainsRune(alphabet, ch) {
+			return serialCharsetMessages[r.Charset]
+		}
+	}
+	if r.CheckDigit && (len(serial) < 2 || luhnCheckChar(alphabet, serial[:len(serial)-1]) != serial[len(serial)-1]) {
+		return i18n.Messages{i18n.Korean: "시리얼 번호가 올바르지 않습니다. 다시 확인해 주세요.", i18n.English: "The serial number is incorrect. Please check it again."}
+	}
+
+	return nil
+}
+
+// validate 는 관리자가 입력한 형식이 사용 가능한지 확인한다.
+func (r SerialRule) validate(errs *FieldErrors) {
+	if err := r.Charset.Validate(); err != nil {
+		errs.Add("serial_charset", ValidationRuleEnum, i18n.Messages{i18n.Korean: "시리얼 문자 집합이 올바르지 않습니다.", i18n.English: "The serial charset is invalid."})
+		return
+	}
+	if r.Length < 0 || (r.Length > 0 && r.Length < len(r.Prefix)+btoi(r.CheckDigit)) {
+		errs.Add("serial_length", ValidationRuleRange, i18n.Messages{i18n.Korean: "시리얼 길이가 접두사보다 짧습니다.", i18n.English: "The serial length is shorter than the prefix."})
+	}
+	if alphabet := r.Charset.alphabet(); alphabet != "" && strings.Trim(r.Prefix, alphabet) != "" {
+		errs.Add("serial_prefix", ValidationRuleFormat, serialCharsetMessages[r.Charset])
+	}
+	if r.CheckDigit && r.Charset == SerialCharsetAny {
+		errs.Add("serial_check_digit", ValidationRuleRequired, i18n.Messages{i18n.Korean: "체크 디지트를 사용하려면 시리얼 문자 집합을 선택해 주세요.", i18n.English: "Please choose a serial charset to use a check digit."})
+	}
+}
+
+// CheckChar 는 payload 뒤에 붙일 체크 디지트. 체크 디지트를 사용하지 않거나 payload 가 문자 집합에 맞지 않으면 빈 문자열.
+func (r SerialRule) CheckChar(payload string) string {
+	alphabet := r.Charset.alphabet()
+	if !r.CheckDigit || alphabet == "" || strings.Trim(payload, alphabet) != "" {
+		return ""
+	}
+	return string(luhnCheckChar(alphabet, payload))
+}
+
+// luhnCheckChar 는 Luhn mod N 알고리즘으로 payload 의 체크 디지트를 계산한다. ( N = len(alphabet) )
+func luhnCheckChar(alphabet, payload string) byte {
+	n := len(alphabet)
+	factor, sum := 2, 0
+	for i := len(payload) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(alphabet, payload[i])
+		sum += addend/n + addend%n
+		factor = 3 - factor
+	}
+	return alphabet[(n-sum%n)%n]
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+var serialRequiredMessages = i18n.Messages{i18n.Korean: "시리얼 번호를 입력해 주세요.", i18n.English: "Please enter the serial number."}
+
+var serialCharsetMessages = map[SerialCharset]i18n.Messages{
+	SerialCharsetNumeric:      {i18n.Korean: "시리얼 번호는 숫자만 입력할 수 있습니다.", i18n.English: "The serial number can only contain digits."},
+	SerialCharsetAlphanumeric: {i18n.Korean: "시리얼 번호는 숫자와 영문 대문자만 입력할 수 있습니다.", i18n.English: "The serial number can only contain digits and uppercase letters."},
+}
+
+// NewSerialFormatError 는 제품 모델의 형식에 맞지 않는 시리얼 번호의 필드 검증 에러
+func NewSerialFormatError(field string, reason i18n.Messages) *Error {
+	var errs FieldErrors
+	errs.Add(field, ValidationRuleFormat, reason)
+	return NewFieldValidationError(errs)
+}
+
+// SerialCheckProduct 는 시리얼 번호 형식에 맞는 제품 모델
+type SerialCheckProduct struct {
+	ProductType ProductType `json:"product_type"`
+	ProductName string      `json:"product_name"`
+}
+
+// SerialCheckResult 는 시리얼 번호 확인 결과
+type SerialCheckResult struct {
+	SerialNo   string                `json:"serial_no"`
+	Valid      bool                  `json:"valid"`            // 등록된 제품이거나 형식에 맞는 제품 모델이 있음
+	Registered bool                  `json:"registered"`       // 등록( import )된 제품의 시리얼
+	Reason     string                `json:"reason,omitempty"` // 형식 오류 사유
+	Products   []*SerialCheckProduct `json:"products"`         // 형식에 맞는 제품 ( 등록된 제품이면 해당 제품만 )
+	reason     i18n.Messages
+	names      []i18n.Messages
+}
+
+// NewSerialCheckResult 는 형식 오류 사유( reason )와 형식에 맞는 제품 모델로 확인 결과를 만든다.
+func NewSerialCheckResult(serialNo string, registered bool, reason i18n.Messages, productModels []*ProductModel) *SerialCheckResult {
+	result := &SerialCheckResult{
+		SerialNo:   serialNo,
+		Valid:      reason == nil && len(productModels) > 0,
+		Registered: registered,
+		Products:   make([]*SerialCheckProduct, len(productModels)),
+		names:      make([]i18n.Messages, len(productModels)),
+	}
+	if !result.Valid {
+		if reason == nil {
+			reason = i18n.Messages{i18n.Korean: "시리얼 번호 형식이 올바르지 않습니다.", i18n.English: "The serial number format is invalid."}
+		}
+		result.reason = reason
+	}
+	for i, productModel := range productModels {
+		result.Products[i] = &SerialCheckProduct{ProductType: productModel.Code}
+		result.names[i] = productModel.Name()
+	}
+
+	return result.localize(i18n.DefaultLanguage)
+}
+
+// Localize 는 형식 오류 사유와 제품명을 lang 으로 번역한 결과를 반환한다.
+func (r SerialCheckResult) Localize(lang i18n.Language) interface{} {
+	return r.localize(lang)
+}
+
+func (r SerialCheckResult) localize(lang i18n.Language) *SerialCheckResult {
+	if r.reason != nil {
+		r.Reason = r.reason.In(lang)
+	}
+	products := make([]*SerialCheckProduct, len(r.Products))
+	for i, product := range r.Products {
+		products[i] = &SerialCheckProduct{ProductType: product.ProductType, ProductName: r.names[i].In(lang)}
+	}
+	r.Products = products
+	return &r
+}
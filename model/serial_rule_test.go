@@ -0,0 +1,76 @@
+package model_test
+
+import (
+	"buddle-server/internal/i18n"
+	"buddle-server/model"
+	"testing"
+)
+
+func TestSerialRule_Check(t *testing.T) {
+	rule := model.SerialRule{Prefix: "BM", Length: 8, Charset: model.SerialCharsetAlphanumeric, CheckDigit: true}
+
+	tests := []struct {
+		serial string
+		valid  bool
+	}{
+		{"BM123454", true},
+		{"BM123455", false}, // 체크 디지트 오류
+		{"BM124354", false}, // 자리 바뀜
+		{"XM123454", false}, // 접두사
+		{"BM12345", false},  // 길이
+		{"BM12345a", false}, // 소문자
+		{"", false},
+	}
+	for _, tt := range tests {
+		if reason := rule.Check(tt.serial); (reason == nil) != tt.valid {
+			t.Errorf("Check(%q) = %v, want valid %v", tt.serial, reason.In(i18n.English), tt.valid)
+		}
+	}
+
+	// 형식이 정의되지 않으면 모든 시리얼을 허용한다.
+	if reason := (model.SerialRule{}).Check("SN-001"); reason != nil {
+		t.Errorf("empty rule Check() = %v", reason)
+	}
+
+	// 숫자 체크 디지트는 일반적인 Luhn 알고리즘과 같다.
+	numeric := model.SerialRule{Charset: model.SerialCharsetNumeric, CheckDigit: true}
+	if got := numeric.CheckChar("7992739871"); got != "3" {
+		t.Errorf("CheckChar(7992739871) = %q, want 3", got)
+	}
+	if payload := "BM99999"; rule.Check(payload+rule.CheckChar(payload)) != nil {
+		t.Errorf("Check(CheckChar(%s)) is invalid", payload)
+	}
+}
+
+func TestProductModel_ValidateSerialRule(t *testing.T) {
+	base := model.ProductModel{Code: 0, NameKo: "분유제조기", NameEn: "Formula maker"}
+
+	tests := []struct {
+		name   string
+		modify func(m *model.ProductModel)
+		field  string
+		rule   string
+	}{
+		{"no rule", func(m *model.ProductModel) {}, "", ""},
+		{"valid rule", func(m *model.ProductModel) {
+			m.SerialPrefix, m.SerialLength, m.SerialCharset, m.SerialCheckDigit = "BM", 8, model.SerialCharsetAlphanumeric, true
+		}, "", ""},
+		{"unknown charset", func(m *model.ProductModel) { m.SerialCharset = "hex" }, "serial_charset", model.ValidationRuleEnum},
+		{"length shorter than prefix", func(m *model.ProductModel) { m.SerialPrefix, m.SerialLength = "BM01", 3 }, "serial_length", model.ValidationRuleRange},
+		{"prefix outside charset", func(m *model.ProductModel) { m.SerialPrefix, m.SerialCharset = "BM", model.SerialCharsetNumeric }, "serial_prefix", model.ValidationRuleFormat},
+		{"check digit without charset", func(m *model.ProductModel) { m.SerialCheckDigit = true }, "serial_check_digit", model.ValidationRuleRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := base
+			tt.modify(&m)
+			got := fieldRules(t, m.Validate())
+			switch {
+			case tt.field == "" && len(got) != 0:
+				t.Errorf("field errors = %v, want none", got)
+			case tt.field != "" && (len(got) != 1 || got[tt.field] != tt.rule):
+				t.Errorf("field errors = %v, want %s %s", got, tt.field, tt.rule)
+			}
+		})
+	}
+}
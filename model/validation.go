@@ -0,0 +1,165 @@
+package model
+
+import (
+	"buddle-server/internal/i18n"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// 필드 검증 규칙
+const (
+	ValidationRuleRequired  = "required"   // 필수 값
+	ValidationRuleFormat    = "format"     // 형식 오류
+	ValidationRuleNotFuture = "not_future" // 미래 날짜 불가
+	ValidationRuleEnum      = "enum"       // 정의되지 않은 값
+	ValidationRuleRange     = "range"      // 허용 범위를 벗어난 값
+)
+
+// FieldError 는 필드 단위 검증 에러
+type FieldError struct {
+	Field    string        `json:"field"`
+	Rule     string        `json:"rule"`
+	Message  string        `json:"message"`
+	messages i18n.Messages // 언어별 메시지 ( Localize )
+}
+
+// FieldErrors 는 요청의 모든 필드 검증 에러. 에러가 없으면 Err() 가 nil 을 반환한다.
+type FieldErrors []FieldError
+
+func (e *FieldErrors) Add(field, rule string, messages i18n.Messages) {
+	*e = append(*e, FieldError{Field: field, Rule: rule, Message: messages.In(i18n.DefaultLanguage), messages: messages})
+}
+
+func (e FieldErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fe := range e {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", fe.Field, fe.Rule))
+	}
+	return "invalid fields [ " + strings.Join(msgs, ", ") + " ]"
+}
+
+// Localize 는 메시지를 lang 으로 번역한 필드 에러 목록을 반환한다.
+func (e FieldErrors) Localize(lang i18n.Language) FieldErrors {
+	result := make(FieldErrors, len(e))
+	for i, fe := range e {
+		if fe.messages != nil {
+			fe.Message = fe.messages.In(lang)
+		}
+		result[i] = fe
+	}
+	return result
+}
+
+// 필드 라벨
+var fieldLabels = map[string]i18n.Messages{
+	"name":          {i18n.Korean: "이름", i18n.English: "name"},
+	"phone":         {i18n.Korean: "휴대폰 번호", i18n.English: "mobile phone number"},
+	"addr":          {i18n.Korean: "주소", i18n.English: "address"},
+	"addr_detail":   {i18n.Korean: "상세 주소", i18n.English: "address detail"},
+	"serial_no":     {i18n.Korean: "시리얼 번호", i18n.English: "serial number"},
+	"name_ko":       {i18n.Korean: "제품명(한국어)", i18n.English: "Korean product name"},
+	"name_en":       {i18n.Korean: "제품명(영어)", i18n.English: "English product name"},
+	"order_no":      {i18n.Korean: "주문번호", i18n.English: "order number"},
+	"transfer_code": {i18n.Korean: "이전 코드", i18n.English: "transfer code"},
+	"reason":        {i18n.Korean: "반려 사유", i18n.English: "rejection reason"},
+}
+
+// 휴대폰 번호 ( 010-1234-5678, 01012345678 )
+var mobilePhonePattern = regexp.MustCompile(`^01[016789]-?\d{3,4}-?\d{4}$`)
+
+func requireString(errs *FieldErrors, field, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		label := fieldLabels[field]
+		errs.Add(field, ValidationRuleRequired, i18n.Messages{
+			i18n.Korean:  fmt.Sprintf("%s을(를) 입력해 주세요.", label.In(i18n.Korean)),
+			i18n.English: fmt.Sprintf("Please enter the %s.", label.In(i18n.English)),
+		})
+		return false
+	}
+	return true
+}
+
+func validatePhone(errs *FieldErrors, field, phone string) {
+	if !requireString(errs, field, phone) {
+		return
+	}
+	if !mobilePhonePattern.MatchString(phone) {
+		errs.Add(field, ValidationRuleFormat, i18n.Messages{i18n.Korean: "휴대폰 번호 형식이 올바르지 않습니다.", i18n.English: "The mobile phone number format is invalid."})
+	}
+}
+
+// validateEmail 은 입력된 경우에만 이메일 형식을 확인한다.
+func validateEmail(errs *FieldErrors, field, email string) {
+	if email == "" {
+		return
+	}
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		errs.Add(field, ValidationRuleFormat, i18n.Messages{i18n.Korean: "이메일 형식이 올바르지 않습니다.", i18n.English: "The email format is invalid."})
+	}
+}
+
+func validatePurchaseDate(errs *FieldErrors, field string, purchaseDate time.Time) {
+	switch {
+	case purchaseDate.IsZero():
+		errs.Add(field, ValidationRuleRequired, i18n.Messages{i18n.Korean: "구매일을 입력해 주세요.", i18n.English: "Please enter the purchase date."})
+	case purchaseDate.After(time.Now()):
+		errs.Add(field, ValidationRuleNotFuture, i18n.Messages{i18n.Korean: "구매일은 미래 날짜일 수 없습니다.", i18n.English: "The purchase date cannot be in the future."})
+	}
+}
+
+var invalidProductTypeMessages = i18n.Messages{i18n.Korean: "제품 종류가 올바르지 않습니다.", i18n.English: "The product type is invalid."}
+
+func validateProductType(errs *FieldErrors, field string, productType ProductType) {
+	if err := productType.Validate(); err != nil {
+		errs.Add(field, ValidationRuleEnum, invalidProductTypeMessages)
+	}
+}
+
+// NewProductTypeError 는 제품 모델 테이블에 없는 제품 종류의 필드 검증 에러
+func NewProductTypeError(field string) *Error {
+	var errs FieldErrors
+	errs.Add(field, ValidationRuleEnum, invalidProductTypeMessages)
+	return NewFieldValidationError(errs)
+}
+
+// NewProductTypeRequiredError 는 여러 제품 종류에 같은 시리얼 번호가 있어 제품 종류를 선택해야 하는 경우의 필드 검증 에러
+func NewProductTypeRequiredError(field string) *Error {
+	var errs FieldErrors
+	errs.Add(field, ValidationRuleRequired, i18n.Messages{
+		i18n.Korean:  "같은 시리얼 번호의 제품이 여러 종류 있습니다. 제품 종류를 선택해 주세요.",
+		i18n.English: "Several products have this serial number. Please choose the product type.",
+	})
+	return NewFieldValidationError(errs)
+}
+
+var invalidMarketTypeMessages = i18n.Messages{i18n.Korean: "구매처가 올바르지 않습니다.", i18n.English: "The place of purchase is invalid."}
+
+func validateMarketType(errs *FieldErrors, field string, marketType MarketType) {
+	if err := marketType.Validate(); err != nil {
+		errs.Add(field, ValidationRuleEnum, invalidMarketTypeMessages)
+	}
+}
+
+// NewMarketTypeError 는 판매 채널 테이블에 없는 구매처의 필드 검증 에러
+func NewMarketTypeError(field string) *Error {
+	var errs FieldErrors
+	errs.Add(field, ValidationRuleEnum, invalidMarketTypeMessages)
+	return NewFieldValidationError(errs)
+}
+
+// NewRequiredError 는 조건부 필수 값( 예: 판매 채널의 주문번호 )이 비어 있는 경우의 필드 검증 에러
+func NewRequiredError(field string) *Error {
+	var errs FieldErrors
+	requireString(&errs, field, "")
+	return NewFieldValidationError(errs)
+}
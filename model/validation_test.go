@@ -0,0 +1,166 @@
+package model_test
+
+import (
+	"buddle-server/model"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func validAfterService() model.AfterService {
+	return model.AfterService{
+		Name:         "김철수",
+		Phone:        "010-9876-5432",
+		Email:        "test@example.com",
+		Addr:         "서울시 서초구",
+		AddrDetail:   "202호",
+		ProductType:  model.ProductTypeBabyBottleWasher,
+		MarketType:   model.MarketTypeNaver,
+		PurchaseDate: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func fieldRules(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+	var fields model.FieldErrors
+	if !errors.As(err, &fields) {
+		t.Fatalf("err = %v, want model.FieldErrors", err)
+	}
+
+	rules := make(map[string]string)
+	for _, fe := range fields {
+		if fe.Message == "" {
+			t.Errorf("%s: empty message", fe.Field)
+		}
+		rules[fe.Field] = fe.Rule
+	}
+	return rules
+}
+
+func TestAfterService_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(as *model.AfterService)
+		want   map[string]string
+	}{
+		{"valid", func(as *model.AfterService) {}, nil},
+		{"phone without hyphen", func(as *model.AfterService) { as.Phone = "01098765432" }, nil},
+		{"empty email is allowed", func(as *model.AfterService) { as.Email = "" }, nil},
+		{"landline phone", func(as *model.AfterService) { as.Phone = "02-123-4567" }, map[string]string{"phone": model.ValidationRuleFormat}},
+		{"invalid email", func(as *model.AfterService) { as.Email = "김철수 <test@example.com>" }, map[string]string{"email": model.ValidationRuleFormat}},
+		{"future purchase date", func(as *model.AfterService) { as.PurchaseDate = time.Now().AddDate(0, 0, 2) }, map[string]string{"purchase_date": model.ValidationRuleNotFuture}},
+		{"unknown enums", func(as *model.AfterService) {
+			as.ProductType = -1
+			as.MarketType = -1
+		}, map[string]string{"product_type": model.ValidationRuleEnum, "market_type": model.ValidationRuleEnum}},
+		{"all missing", func(as *model.AfterService) { *as = model.AfterService{} }, map[string]string{
+			"name":          model.ValidationRuleRequired,
+			"phone":         model.ValidationRuleRequired,
+			"addr":          model.ValidationRuleRequired,
+			"addr_detail":   model.ValidationRuleRequired,
+			"purchase_date": model.ValidationRuleRequired,
+		}},
+		// 시리얼 번호로 신청하면 주소, 구매 정보는 제품 인증 정보로 채운다.
+		{"serial number only", func(as *model.AfterService) {
+			*as = model.AfterService{Name: "김철수", Phone: "01098765432", SerialNo: "SN-001"}
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := validAfterService()
+			tt.modify(&as)
+
+			got := fieldRules(t, as.Validate())
+			if len(got) != len(tt.want) {
+				t.Fatalf("field errors = %v, want %v", got, tt.want)
+			}
+			for field, rule := range tt.want {
+				if got[field] != rule {
+					t.Errorf("%s: rule = %q, want %q", field, got[field], rule)
+				}
+			}
+		})
+	}
+}
+
+func TestProductRegist_Validate(t *testing.T) {
+	pr := model.ProductRegist{
+		Name:         "홍길동",
+		Phone:        "01012345678",
+		Addr:         "서울시 강남구",
+		AddrDetail:   "101호",
+		PurchaseDate: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	if err := pr.ValidateUpdate(); err != nil {
+		t.Fatalf("ValidateUpdate: %v", err)
+	}
+
+	got := fieldRules(t, pr.Validate())
+	if len(got) != 1 || got["serial_no"] != model.ValidationRuleRequired {
+		t.Fatalf("field errors = %v, want serial_no required", got)
+	}
+
+	// 제품 종류는 선택하지 않아도 된다. ( 시리얼 번호로 찾음 )
+	pr.SerialNo = "SN-001"
+	if err := pr.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	productType := model.ProductType(-1)
+	pr.ProductType = &productType
+	if got := fieldRules(t, pr.Validate()); len(got) != 1 || got["product_type"] != model.ValidationRuleEnum {
+		t.Fatalf("field errors = %v, want product_type enum", got)
+	}
+}
+
+func TestProductTransferClaim_Validate(t *testing.T) {
+	claim := model.ProductTransferClaim{
+		SerialNo:    "SN-001",
+		Name:        "김철수",
+		Phone:       "010-9876-5432",
+		Addr:        "부산시 해운대구",
+		AddrDetail:  "202호",
+	}
+
+	got := fieldRules(t, claim.Validate())
+	if len(got) != 1 || got["transfer_code"] != model.ValidationRuleRequired {
+		t.Fatalf("field errors = %v, want transfer_code required", got)
+	}
+
+	// 제품 종류는 선택하지 않아도 된다. ( 시리얼 번호로 찾음 )
+	claim.TransferCode = "ABCD2345"
+	if err := claim.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	productType := model.ProductType(-1)
+	claim.ProductType = &productType
+	if got := fieldRules(t, claim.Validate()); len(got) != 1 || got["product_type"] != model.ValidationRuleEnum {
+		t.Fatalf("field errors = %v, want product_type enum", got)
+	}
+}
+
+func TestProductRejectRequest_Validate(t *testing.T) {
+	tests := []struct {
+		reason string
+		want   string
+	}{
+		{reason: "", want: model.ValidationRuleRequired},
+		{reason: strings.Repeat("가", model.ProductRejectReasonMaxLength+1), want: model.ValidationRuleRange},
+		{reason: strings.Repeat("가", model.ProductRejectReasonMaxLength)},
+	}
+
+	for _, tt := range tests {
+		got := fieldRules(t, model.ProductRejectRequest{Reason: tt.reason}.Validate())
+		if got["reason"] != tt.want {
+			t.Errorf("Validate(%d chars) = %v, want reason %q", len([]rune(tt.reason)), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+)
+
+type WarrantyStatus int
+
+const (
+	WarrantyStatusUnknown WarrantyStatus = iota // 구매일 없음 ( 미인증 제품 )
+	WarrantyStatusIn                            // 무상 보증 기간 내
+	WarrantyStatusOut                           // 무상 보증 기간 만료
+)
+
+// Warranty 는 구매일과 제품 모델의 보증 기간( warranty_months )으로 계산한 무상 보증 기간
+type Warranty struct {
+	Start  time.Time      // 보증 시작일 ( 구매일 )
+	End    time.Time      // 보증 종료일 ( 보증되는 마지막 날 ). 보증 기간이 없으면 zero
+	Status WarrantyStatus // now 기준 보증 상태
+}
+
+// NewWarranty 는 purchaseDate 부터 months 개월간의 보증 기간과 now 기준 보증 상태를 계산한다.
+// 구매일 + months 개월 전날까지 보증한다. ( 2022-03-01 구매, 12개월 → 2023-02-28 까지 )
+func NewWarranty(purchaseDate time.Time, months int, now time.Time) Warranty {
+	if purchaseDate.IsZero() {
+		return Warranty{Status: WarrantyStatusUnknown}
+	}
+
+	start := time.Date(purchaseDate.Year(), purchaseDate.Month(), purchaseDate.Day(), 0, 0, 0, 0, purchaseDate.Location())
+	if months <= 0 {
+		return Warranty{Start: start, Status: WarrantyStatusOut}
+	}
+
+	expire := start.AddDate(0, months, 0)
+	warranty := Warranty{Start: start, End: expire.AddDate(0, 0, -1), Status: WarrantyStatusOut}
+	if now.Before(expire) {
+		warranty.Status = WarrantyStatusIn
+	}
+
+	return warranty
+}
+
+// formatDate 는 응답용 날짜 문자열. zero 이면 빈 문자열.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
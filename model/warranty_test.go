@@ -0,0 +1,43 @@
+package model_test
+
+import (
+	"buddle-server/model"
+	"testing"
+	"time"
+)
+
+func TestNewWarranty(t *testing.T) {
+	purchaseDate := time.Date(2022, 3, 1, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		purchaseDate time.Time
+		months       int
+		now          time.Time
+		wantEnd      string
+		wantStatus   model.WarrantyStatus
+	}{
+		{name: "no purchase date", months: 12, now: purchaseDate, wantStatus: model.WarrantyStatusUnknown},
+		{name: "purchase day", purchaseDate: purchaseDate, months: 12, now: purchaseDate, wantEnd: "2023-02-28", wantStatus: model.WarrantyStatusIn},
+		{name: "last day", purchaseDate: purchaseDate, months: 12, now: time.Date(2023, 2, 28, 23, 59, 0, 0, time.UTC), wantEnd: "2023-02-28", wantStatus: model.WarrantyStatusIn},
+		{name: "expired", purchaseDate: purchaseDate, months: 12, now: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), wantEnd: "2023-02-28", wantStatus: model.WarrantyStatusOut},
+		{name: "no warranty", purchaseDate: purchaseDate, months: 0, now: purchaseDate, wantStatus: model.WarrantyStatusOut},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := model.NewWarranty(tt.purchaseDate, tt.months, tt.now)
+
+			var end string
+			if !got.End.IsZero() {
+				end = got.End.Format("2006-01-02")
+			}
+			if end != tt.wantEnd || got.Status != tt.wantStatus {
+				t.Fatalf("NewWarranty() = end %q, status %d, want end %q, status %d", end, got.Status, tt.wantEnd, tt.wantStatus)
+			}
+			if !tt.purchaseDate.IsZero() && got.Start.Format("2006-01-02") != "2022-03-01" {
+				t.Fatalf("NewWarranty() start = %v", got.Start)
+			}
+		})
+	}
+}
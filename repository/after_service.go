@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/pkg/errors"
+	"gorm.io/gorm"
 	"time"
 )
 
@@ -15,6 +16,10 @@ type AfterServiceRepository interface {
 	FindAfterServiceInfo(c context.Context, req model.AfterServiceRequest) ([]*model.AfterService, error)
 	FindAfterServiceManagerInfo(c context.Context, req model.AfterServiceRequest) ([]*model.AfterService, error)
 	GetAfterServiceBySeq(c context.Context, afterServiceSeq int64) (*model.AfterService, error)
+	FindAfterServicesByProductSeq(c context.Context, productSeq int64) ([]*model.AfterService, error)
+	FindFileLocations(c context.Context, locations []string) ([]string, error)
+	FindLegacyFileAfterServices(c context.Context, keyPrefix string, afterSeq int64, limit int) ([]*model.AfterService, error)
+	UpdateFileLocation(c context.Context, afterServiceSeq int64, fileIdx int, oldLocation, newLocation string) error
 }
 
 type afterServiceRepository struct {
@@ -57,7 +62,7 @@ func (r afterServiceRepository) FindAfterServiceInfo(c context.Context, req mode
 	}
 
 	result := make([]*model.AfterService, 0)
-	if err := conn.Where("name=?", req.Name).Where("phone=?", req.Phone).Order("regdate desc").Find(&result).Error; err != nil && !errors.Is(err, sql.ErrNoRows) {
+	if err := withCatalogNames(conn).Where("a.name=?", req.Name).Where("a.phone=?", req.Phone).Order("a.regdate desc").Find(&result).Error; err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return nil, errors.Wrap(err, "failed to get after service info")
 	}
 
@@ -69,21 +74,21 @@ func (r afterServiceRepository) FindAfterServiceManagerInfo(c context.Context, r
 		return nil, errors.New("nil context")
 	}
 
-	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	conn, err := db.ConnFromContext(c, db.ReadDBKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get db connection")
 	}
 
 	result := make([]*model.AfterService, 0)
 
-	tx := conn.Order("regdate desc")
+	tx := withCatalogNames(conn).Order("a.regdate desc")
 
 	if req.Name != "" {
-		tx = tx.Where("name=?", req.Name)
+		tx = tx.Where("a.name=?", req.Name)
 	}
 
 	if req.Phone != "" {
-		tx = tx.Where("phone=?", req.Phone)
+		tx = tx.Where("a.phone=?", req.Phone)
 	}
 
 	if err := tx.Find(&result).Error; err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -93,6 +98,37 @@ func (r afterServiceRepository) FindAfterServiceManagerInfo(c context.Context, r
 	return result, nil
 }
 
+// FindAfterServicesByProductSeq 는 제품에 연결된 A/S 신청정보를 신청일 역순으로 조회한다. ( 제품의 모든 인증 정보 포함 )
+func (r afterServiceRepository) FindAfterServicesByProductSeq(c context.Context, productSeq int64) ([]*model.AfterService, error) {
+	switch {
+	case c == nil:
+		return nil, errors.New("nil context")
+	case productSeq == 0:
+		return nil, errors.New("product sequence is required")
+	}
+
+	conn, err := db.ConnFromContext(c, db.ReadDBKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get db connection")
+	}
+
+	result := make([]*model.AfterService, 0)
+	if err := withCatalogNames(conn).Where("a.product_seq = ?", productSeq).Order("a.regdate desc").Find(&result).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find after services by product seq(%d)", productSeq)
+	}
+
+	return result, nil
+}
+
+// withCatalogNames 는 A/S 신청정보( alias a )를 제품 모델의 제품명, 판매 채널의 채널명, 연결된 제품의 시리얼 번호와 함께 조회한다.
+func withCatalogNames(conn *gorm.DB) *gorm.DB {
+	return conn.Table("after_service a").
+		Select("a.*, pm.name_ko AS product_name_ko, pm.name_en AS product_name_en, sc.name_ko AS market_name_ko, sc.name_en AS market_name_en, p.serial_no").
+		Joins("LEFT JOIN product_model pm ON pm.code = a.product_type").
+		Joins("LEFT JOIN sales_channel sc ON sc.code = a.market_type").
+		Joins("LEFT JOIN product p ON p.product_seq = a.product_seq")
+}
+
 func (r afterServiceRepository) GetAfterServiceBySeq(c context.Context, afterServiceSeq int64) (*model.AfterService, error) {
 	switch {
 	case c == nil:
@@ -113,3 +149,97 @@ func (r afterServiceRepository) GetAfterServiceBySeq(c context.Context, afterSer
 
 	return result, nil
 }
+
+func (r afterServiceRepository) FindFileLocations(c context.Context, locations []string) ([]string, error) {
+	if c == nil {
+		return nil, errors.New("nil context")
+	}
+
+	result := make([]string, 0)
+	if len(locations) == 0 {
+		return result, nil
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get db connection")
+	}
+
+	for i := 1; i <= model.AfterServiceMaxFiles; i++ {
+		column, err := model.FileS3LocationColumn(i)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		found := make([]string, 0)
+		if err := conn.Model(&model.AfterService{}).Where(column+" IN ?", locations).Distinct().Pluck(column, &found).Error; err != nil {
+			return nil, errors.Wrapf(err, "failed to find after service file locations [ column = %s ]", column)
+		}
+		result = append(result, found...)
+	}
+
+	return result, nil
+}
+
+// FindLegacyFileAfterServices 는 keyPrefix 하위가 아닌 위치의 첨부파일을 가진 A/S 신청정보를 afterSeq 이후부터 조회한다.
+func (r afterServiceRepository) FindLegacyFileAfterServices(c context.Context, keyPrefix string, afterSeq int64, limit int) ([]*model.AfterService, error) {
+	switch {
+	case c == nil:
+		return nil, errors.New("nil context")
+	case keyPrefix == "":
+		return nil, errors.New("key prefix is required")
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get db connection")
+	}
+
+	legacy := conn.Where("1 = 0")
+	for i := 1; i <= model.AfterServiceMaxFiles; i++ {
+		column, err := model.FileS3LocationColumn(i)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		legacy = legacy.Or(fmt.Sprintf("%s <> '' AND %s NOT LIKE ?", column, column), keyPrefix+"/%")
+	}
+
+	result := make([]*model.AfterService, 0)
+	if err := conn.Where("after_service_seq > ?", afterSeq).Where(legacy).Order("after_service_seq").Limit(limit).Find(&result).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to find legacy file after services")
+	}
+
+	return result, nil
+}
+
+func (r afterServiceRepository) UpdateFileLocation(c context.Context, afterServiceSeq int64, fileIdx int, oldLocation, newLocation string) error {
+	switch {
+	case c == nil:
+		return errors.New("nil context")
+	case afterServiceSeq == 0:
+		return errors.New("after service sequence is required")
+	}
+
+	column, err := model.FileS3LocationColumn(fileIdx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get db connection")
+	}
+
+	tx := conn.Model(&model.AfterService{}).
+		Where("after_service_seq = ?", afterServiceSeq).
+		Where(column+" = ?", oldLocation).
+		Update(column, newLocation)
+	if err := tx.Error; err != nil {
+		return errors.Wrap(err, "failed to update after service file location")
+	}
+	if tx.RowsAffected == 0 {
+		return errors.Wrapf(gorm.ErrRecordNotFound, "file location was changed [ after_service_seq = %d, file%d ]", afterServiceSeq, fileIdx)
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package repository_test
+
+import (
+	"buddle-server/model"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestAfterServiceRepository_Create(t *testing.T) {
+	c, repo := newTestRepository(t)
+
+	if err := repo.AfterService().Create(c, nil); err == nil {
+		t.Errorf("Create(nil) error = nil")
+	}
+
+	as := seedAfterService(t, c, repo, "홍길동", "01012345678", time.Time{}, "after-service-file/a")
+	if as.AfterServiceSeq == 0 {
+		t.Fatalf("Create() did not set after_service_seq")
+	}
+	if as.RegDate.IsZero() || !as.Modified.Equal(as.RegDate) {
+		t.Errorf("Create() regdate = %v, modified = %v", as.RegDate, as.Modified)
+	}
+}
+
+func TestAfterServiceRepository_FindAfterServiceInfo(t *testing.T) {
+	c, repo := newTestRepository(t)
+
+	older := seedAfterService(t, c, repo, "홍길동", "01012345678", time.Now().Add(-time.Hour))
+	newer := seedAfterService(t, c, repo, "홍길동", "01012345678", time.Now())
+	seedAfterService(t, c, repo, "홍길동", "01099999999", time.Now())
+
+	got, err := repo.AfterService().FindAfterServiceInfo(c, model.AfterServiceRequest{Name: "홍길동", Phone: "01012345678"})
+	if err != nil {
+		t.Fatalf("FindAfterServiceInfo() error = %+v", err)
+	}
+	if len(got) != 2 || got[0].AfterServiceSeq != newer.AfterServiceSeq || got[1].AfterServiceSeq != older.AfterServiceSeq {
+		t.Errorf("FindAfterServiceInfo() = %+v, want newest first", got)
+	}
+
+	// 이름과 전화번호가 모두 일치해야 한다.
+	if got, err = repo.AfterService().FindAfterServiceInfo(c, model.AfterServiceRequest{Name: "홍길동"}); err != nil || len(got) != 0 {
+		t.Errorf("FindAfterServiceInfo() without phone = %+v, %v", got, err)
+	}
+}
+
+func TestAfterServiceRepository_FindAfterServiceManagerInfo(t *testing.T) {
+	c, repo := newTestRepository(t)
+
+	seedAfterService(t, c, repo, "홍길동", "01012345678", time.Now().Add(-2*time.Hour))
+	seedAfterService(t, c, repo, "김철수", "01012345678", time.Now().Add(-time.Hour))
+	seedAfterService(t, c, repo, "홍길동", "01099999999", time.Now())
+
+	tests := []struct {
+		name  string
+		req   model.AfterServiceRequest
+		count int
+	}{
+		{name: "all", req: model.AfterServiceRequest{}, count: 3},
+		{name: "name", req: model.AfterServiceRequest{Name: "홍길동"}, count: 2},
+		{name: "phone", req: model.AfterServiceRequest{Phone: "01012345678"}, count: 2},
+		{name: "name and phone", req: model.AfterServiceRequest{Name: "김철수", Phone: "01012345678"}, count: 1},
+		{name: "no match", req: model.AfterServiceRequest{Name: "이영희"}, count: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.AfterService().FindAfterServiceManagerInfo(c, tt.req)
+			if err != nil {
+				t.Fatalf("FindAfterServiceManagerInfo() error = %+v", err)
+			}
+			if len(got) != tt.count {
+				t.Fatalf("FindAfterServiceManagerInfo() count = %d, want %d", len(got), tt.count)
+			}
+			for i := 1; i < len(got); i++ {
+				if got[i].RegDate.After(got[i-1].RegDate) {
+					t.Errorf("FindAfterServiceManagerInfo() is not ordered by regdate desc")
+				}
+			}
+		})
+	}
+}
+
+func TestAfterServiceRepository_GetAfterServiceBySeq(t *testing.T) {
+	c, repo := newTestRepository(t)
+	as := seedAfterService(t, c, repo, "홍길동", "01012345678", time.Now(), "after-service-file/a", "", "after-service-file/c")
+
+	got, err := repo.AfterService().GetAfterServiceBySeq(c, as.AfterServiceSeq)
+	if err != nil {
+		t.Fatalf("GetAfterServiceBySeq() error = %+v", err)
+	}
+	if got.Name != "홍길동" || got.File1S3Location != "after-service-file/a" || got.File2S3Location != "" || got.File3S3Location != "after-service-file/c" {
+		t.Errorf("GetAfterServiceBySeq() = %+v", got)
+	}
+
+	if _, err := repo.AfterService().GetAfterServiceBySeq(c, as.AfterServiceSeq+1); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetAfterServiceBySeq() unknown seq error = %v, want ErrRecordNotFound", err)
+	}
+	if _, err := repo.AfterService().GetAfterServiceBySeq(c, 0); err == nil {
+		t.Errorf("GetAfterServiceBySeq(0) error = nil")
+	}
+}
+
+func TestAfterServiceRepository_FileLocations(t *testing.T) {
+	c, repo := newTestRepository(t)
+
+	legacy := seedAfterService(t, c, repo, "홍길동", "01012345678", time.Now(), "", "after-service/2022-03-01/01012345678/file2")
+	seedAfterService(t, c, repo, "김철수", "01098765432", time.Now(), "after-service-file/a", "after-service-file/b")
+
+	found, err := repo.AfterService().FindFileLocations(c, []string{"after-service/2022-03-01/01012345678/file2", "after-service-file/b", "after-service-file/unknown"})
+	if err != nil {
+		t.Fatalf("FindFileLocations() error = %+v", err)
+	}
+	sort.Strings(found)
+	if len(found) != 2 || found[0] != "after-service-file/b" || found[1] != "after-service/2022-03-01/01012345678/file2" {
+		t.Errorf("FindFileLocations() = %v", found)
+	}
+
+	legacies, err := repo.AfterService().FindLegacyFileAfterServices(c, "after-service-file", 0, 10)
+	if err != nil {
+		t.Fatalf("FindLegacyFileAfterServices() error = %+v", err)
+	}
+	if len(legacies) != 1 || legacies[0].AfterServiceSeq != legacy.AfterServiceSeq {
+		t.Fatalf("FindLegacyFileAfterServices() = %+v", legacies)
+	}
+
+	if err := repo.AfterService().UpdateFileLocation(c, legacy.AfterServiceSeq, 2, legacy.File2S3Location, "after-service-file/new"); err != nil {
+		t.Fatalf("UpdateFileLocation() error = %+v", err)
+	}
+	if legacies, err = repo.AfterService().FindLegacyFileAfterServices(c, "after-service-file", 0, 10); err != nil || len(legacies) != 0 {
+		t.Errorf("FindLegacyFileAfterServices() after update = %+v, %v", legacies, err)
+	}
+
+	if err := repo.AfterService().UpdateFileLocation(c, legacy.AfterServiceSeq, 2, legacy.File2S3Location, "after-service-file/other"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("UpdateFileLocation() stale location error = %v, want ErrRecordNotFound", err)
+	}
+	if err := repo.AfterService().UpdateFileLocation(c, legacy.AfterServiceSeq, 6, "", "after-service-file/other"); err == nil {
+		t.Errorf("UpdateFileLocation() invalid file index error = nil")
+	}
+}
+
+func TestAfterServiceRepository_FindAfterServicesByProductSeq(t *testing.T) {
+	c, repo := newTestRepository(t)
+	product := seedProduct(t, c, repo, "BK0001", model.ProductTypeBabyBottleWasher)
+	productRegist := seedProductRegist(t, c, repo, product, "홍길동", "01012345678", time.Now())
+
+	linked := make([]*model.AfterService, 0, 2)
+	for _, regdate := range []time.Time{time.Now().Add(-time.Hour), time.Now()} {
+		as := &model.AfterService{
+			ProductSeq:       product.ProductSeq,
+			ProductRegistSeq: productRegist.ProductRegistSeq,
+			Name:             "홍길동",
+			Phone:            "01012345678",
+			ProductType:      product.ProductType,
+			PurchaseDate:     productRegist.PurchaseDate,
+			RegDate:          regdate,
+		}
+		if err := repo.AfterService().Create(c, as); err != nil {
+			t.Fatalf("Create() error = %+v", err)
+		}
+		linked = append(linked, as)
+	}
+	seedAfterService(t, c, repo, "홍길동", "01012345678", time.Now())
+
+	got, err := repo.AfterService().FindAfterServicesByProductSeq(c, product.ProductSeq)
+	if err != nil {
+		t.Fatalf("FindAfterServicesByProductSeq() error = %+v", err)
+	}
+	if len(got) != 2 || got[0].AfterServiceSeq != linked[1].AfterServiceSeq || got[1].AfterServiceSeq != linked[0].AfterServiceSeq {
+		t.Fatalf("FindAfterServicesByProductSeq() = %+v", got)
+	}
+	if got[0].SerialNo != "BK0001" || got[0].ProductRegistSeq != productRegist.ProductRegistSeq || got[0].ProductNameKo != "버들아이 젖병세척기" {
+		t.Errorf("FindAfterServicesByProductSeq()[0] = %+v", got[0])
+	}
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"buddle-server/internal/db"
+	"buddle-server/model"
+	"context"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// CatalogRepository 는 code 를 키로 하는 카탈로그 테이블( 제품 모델, 판매 채널 )의 공통 CRUD.
+type CatalogRepository interface {
+	Create(c context.Context, entry model.CatalogEntry) error
+	Update(c context.Context, entry model.CatalogEntry) error
+	Delete(c context.Context, code int) error
+	Exists(c context.Context, code int) (bool, error)
+	InUse(c context.Context, code int) (bool, error)
+}
+
+// catalogRepository 는 table 에 대한 CatalogRepository.
+type catalogRepository struct {
+	name      string        // 에러 메시지에 사용하는 이름 ( product model )
+	table     string        // 카탈로그 테이블
+	refColumn string        // 다른 테이블에서 code 를 참조하는 컬럼
+	refTables []interface{} // code 를 참조하는 테이블
+}
+
+func (r catalogRepository) Create(c context.Context, entry model.CatalogEntry) error {
+	switch {
+	case c == nil:
+		return errors.New("nil context")
+	case entry == nil:
+		return errors.Errorf("%s is nil", r.name)
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get db connection")
+	}
+
+	entry.SetRegdate(time.Now())
+
+	return translateError(conn.Table(r.table).Create(entry).Error)
+}
+
+func (r catalogRepository) Update(c context.Context, entry model.CatalogEntry) error {
+	switch {
+	case c == nil:
+		return errors.New("nil context")
+	case entry == nil:
+		return errors.Errorf("%s is nil", r.name)
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get db connection")
+	}
+
+	if err := conn.Table(r.table).Where("code = ?", entry.CatalogCode()).Updates(entry.ToUpdateMap()).Error; err != nil {
+		return errors.Wrapf(err, "failed to update %s", r.name)
+	}
+
+	return nil
+}
+
+func (r catalogRepository) Delete(c context.Context, code int) error {
+	if c == nil {
+		return errors.New("nil context")
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get db connection")
+	}
+
+	tx := conn.Exec("DELETE FROM "+r.table+" WHERE code = ?", code)
+	if err := tx.Error; err != nil {
+		return errors.Wrapf(err, "failed to delete %s", r.name)
+	}
+	if tx.RowsAffected == 0 {
+		return errors.Wrapf(gorm.ErrRecordNotFound, "%s(%d) does not exist", r.name, code)
+	}
+
+	return nil
+}
+
+func (r catalogRepository) Exists(c context.Context, code int) (bool, error) {
+	if c == nil {
+		return false, errors.New("nil context")
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get db connection")
+	}
+
+	var count int64
+	if err := conn.Table(r.table).Where("code = ?", code).Count(&count).Error; err != nil {
+		return false, errors.Wrapf(err, "failed to get %s(%d)", r.name, code)
+	}
+
+	return count > 0, nil
+}
+
+// InUse 는 refTables 의 행이 code 를 참조하는지 확인한다.
+func (r catalogRepository) InUse(c context.Context, code int) (bool, error) {
+	if c == nil {
+		return false, errors.New("nil context")
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get db connection")
+	}
+
+	for _, table := range r.refTables {
+		var count int64
+		if err := conn.Model(table).Where(r.refColumn+" = ?", code).Count(&count).Error; err != nil {
+			return false, errors.Wrapf(err, "failed to count %s references", r.name)
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// get 은 code 의 행을 dest 에 조회한다.
+func (r catalogRepository) get(c context.Context, code int, dest model.CatalogEntry) error {
+	if c == nil {
+		return errors.New("nil context")
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get db connection")
+	}
+
+	if err := conn.Where("code = ?", code).Take(dest).Error; err != nil {
+		return errors.Wrapf(err, "failed to get %s(%d)", r.name, code)
+	}
+
+	return nil
+}
+
+// find 는 code 순으로 목록을 dest 에 조회한다. activeOnly 이면 판매 중인 행만 조회한다.
+func (r catalogRepository) find(c context.Context, activeOnly bool, dest interface{}) error {
+	if c == nil {
+		return errors.New("nil context")
+	}
+
+	conn, err := db.ConnFromContext(c, db.ReadDBKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get db connection")
+	}
+
+	tx := conn.Order("code")
+	if activeOnly {
+		tx = tx.Where("active = ?", true)
+	}
+
+	if err := tx.Find(dest).Error; err != nil {
+		return errors.Wrapf(err, "failed to find %ss", r.name)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository_test
+
+import (
+	"buddle-server/model"
+	"buddle-server/repository"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestCatalogRepository(t *testing.T) {
+	tests := []struct {
+		name     string
+		catalog  func(repo repository.Repository) repository.CatalogRepository
+		newEntry func(code int, nameKo, nameEn string) model.CatalogEntry
+		refCode  int // 마이그레이션으로 등록된 code
+		refer    func(t *testing.T, c context.Context, repo repository.Repository)
+	}{
+		{
+			name:    "product model",
+			catalog: func(repo repository.Repository) repository.CatalogRepository { return repo.ProductModel() },
+			newEntry: func(code int, nameKo, nameEn string) model.CatalogEntry {
+				return &model.ProductModel{Code: model.ProductType(code), NameKo: nameKo, NameEn: nameEn}
+			},
+			refCode: int(model.ProductTypeBabyBottleWasher),
+			refer: func(t *testing.T, c context.Context, repo repository.Repository) {
+				seedProduct(t, c, repo, "BK0001", model.ProductTypeBabyBottleWasher)
+			},
+		},
+		{
+			name:    "sales channel",
+			catalog: func(repo repository.Repository) repository.CatalogRepository { return repo.SalesChannel() },
+			newEntry: func(code int, nameKo, nameEn string) model.CatalogEntry {
+				return &model.SalesChannel{Code: model.MarketType(code), NameKo: nameKo, NameEn: nameEn}
+			},
+			// seedProductRegist 는 쿠팡 채널로 등록한다.
+			refCode: int(model.MarketTypeCoupang),
+			refer: func(t *testing.T, c context.Context, repo repository.Repository) {
+				seedProductRegist(t, c, repo, seedProduct(t, c, repo, "BK0001", model.ProductTypeBabyBottleWasher), "홍길동", "01012345678", time.Now())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, repo := newTestRepository(t)
+			catalog := tt.catalog(repo)
+
+			if err := catalog.Create(c, tt.newEntry(10, "신규", "new")); err != nil {
+				t.Fatalf("Create() error = %+v", err)
+			}
+			if err := catalog.Create(c, tt.newEntry(10, "중복", "dup")); !errors.Is(err, repository.ErrDuplicateKey) {
+				t.Errorf("Create() duplicated code error = %v, want ErrDuplicateKey", err)
+			}
+			if err := catalog.Update(c, tt.newEntry(10, "수정", "modified")); err != nil {
+				t.Fatalf("Update() error = %+v", err)
+			}
+			if exists, err := catalog.Exists(c, 10); err != nil || !exists {
+				t.Errorf("Exists() = %v, %v, want true", exists, err)
+			}
+
+			if err := catalog.Delete(c, 10); err != nil {
+				t.Fatalf("Delete() error = %+v", err)
+			}
+			if exists, err := catalog.Exists(c, 10); err != nil || exists {
+				t.Errorf("Exists() after Delete() = %v, %v, want false", exists, err)
+			}
+			if err := catalog.Delete(c, 10); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("Delete() twice error = %v", err)
+			}
+
+			if inUse, err := catalog.InUse(c, tt.refCode); err != nil || inUse {
+				t.Errorf("InUse() = %v, %v, want false", inUse, err)
+			}
+			tt.refer(t, c, repo)
+			if inUse, err := catalog.InUse(c, tt.refCode); err != nil || !inUse {
+				t.Errorf("InUse() = %v, %v, want true", inUse, err)
+			}
+		})
+	}
+}
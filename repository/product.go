@@ -2,11 +2,12 @@ package repository
 
 import (
 	"buddle-server/internal/db"
+	"buddle-server/internal/i18n"
 	"buddle-server/model"
 	"context"
-	"database/sql"
 	"fmt"
 	"github.com/pkg/errors" //nolint:goimports
+	"gorm.io/gorm"
 	"time"
 )
 
@@ -14,15 +15,30 @@ type ProductRepository interface {
 	Create(c context.Context, product *model.Product) error
 	CreateProductRegist(c context.Context, productRegist *model.ProductRegist) error
 	CancelProductAuth(c context.Context, productRegistSeq int64) error
+	TransferProductAuth(c context.Context, productRegistSeq int64) error
+	ResubmitProductAuth(c context.Context, productRegistSeq int64) error
+	ApproveProductAuth(c context.Context, productRegistSeq int64) error
+	RejectProductAuth(c context.Context, productRegistSeq int64, reason string) error
 	ModProductAuth(c context.Context, productRegist *model.ProductRegist) error
 	GetProductBySerial(c context.Context, serial string, productType model.ProductType) (*model.Product, error)
+	FindProductsBySerial(c context.Context, serial string) ([]*model.Product, error)
 	GetProductRegistByProductSeq(c context.Context, productSeq int64) (*model.ProductRegist, error)
 	GetProductRegistBySeq(c context.Context, productRegistSeq int64) (*model.ProductRegist, error)
 	FindProductManageInfo(c context.Context, req model.ProductManageRequest) (model.ProductManageInfos, error)
-	GetProductAuthInfo(c context.Context, req model.ProductAuthRequest) (*model.ProductAuthInfo, error)
+	FindProductAuthInfos(c context.Context, req model.ProductAuthRequest) (model.ProductAuthInfos, error)
+	GetProductAuthInfoBySeq(c context.Context, productRegistSeq int64) (*model.ProductAuthInfo, error)
+	FindReceiptLocations(c context.Context, locations []string) ([]string, error)
+	FindLegacyReceiptProductRegists(c context.Context, keyPrefix string, afterSeq int64, limit int) ([]*model.ProductRegist, error)
+	UpdateReceiptLocation(c context.Context, productRegistSeq int64, oldLocation, newLocation string) error
 	UpdateProduct(c context.Context) error
 }
 
+// activeProductAuthStatuses 는 제품당 하나만 존재할 수 있는 인증 상태 ( unique 인덱스 조건과 같다 )
+var activeProductAuthStatuses = []model.ProductAuthStatus{model.ProductAuthStatusOK, model.ProductAuthStatusPending}
+
+// customerProductAuthStatuses 는 고객의 제품 인증 목록에 표시하는 인증 상태
+var customerProductAuthStatuses = []model.ProductAuthStatus{model.ProductAuthStatusOK, model.ProductAuthStatusPending, model.ProductAuthStatusRejected}
+
 type productRepository struct{}
 
 func NewProductRepository() ProductRepository {
@@ -48,7 +64,7 @@ func (r productRepository) Create(c context.Context, product *model.Product) err
 
 	product.Modified = product.RegDate
 
-	return conn.Create(product).Error
+	return translateError(conn.Create(product).Error)
 }
 
 func (r productRepository) UpdateProduct(c context.Context) error {
@@ -75,12 +91,59 @@ func (r productRepository) CreateProductRegist(c context.Context, productRegist
 	}
 
 	productRegist.Modified = productRegist.Regdate
-	productRegist.Status = model.ProductAuthStatusOK
+	// 상태를 지정하지 않으면 관리자의 영수증 확인을 기다린다.
+	if productRegist.Status == model.ProductAuthStatusNone {
+		productRegist.Status = model.ProductAuthStatusPending
+	}
 
-	return conn.Create(productRegist).Error
+	return translateError(conn.Create(productRegist).Error)
 }
 
 func (r productRepository) CancelProductAuth(c context.Context, productRegistSeq int64) error {
+	if err := updateProductAuthStatus(c, productRegistSeq, model.ProductAuthStatusCancel); err != nil {
+		return errors.Wrap(err, "failed to cancel product auth")
+	}
+
+	return nil
+}
+
+// TransferProductAuth 는 제품 인증 정보를 소유권 이전 상태로 변경한다. 인증 정보는 이력으로 남는다.
+func (r productRepository) TransferProductAuth(c context.Context, productRegistSeq int64) error {
+	if err := updateProductAuthStatus(c, productRegistSeq, model.ProductAuthStatusTransferred); err != nil {
+		return errors.Wrap(err, "failed to transfer product auth")
+	}
+
+	return nil
+}
+
+// ResubmitProductAuth 는 제품 인증 정보를 다시 인증 대기 상태로 변경한다. 관리자가 영수증을 다시 확인한다.
+func (r productRepository) ResubmitProductAuth(c context.Context, productRegistSeq int64) error {
+	if err := updateProductAuthStatus(c, productRegistSeq, model.ProductAuthStatusPending); err != nil {
+		return errors.Wrap(err, "failed to resubmit product auth")
+	}
+
+	return nil
+}
+
+// ApproveProductAuth 는 인증 대기 중인 제품 인증 정보를 인증 완료로 변경한다.
+// 인증 대기 상태가 아니면 gorm.ErrRecordNotFound 에러를 반환한다.
+func (r productRepository) ApproveProductAuth(c context.Context, productRegistSeq int64) error {
+	return reviewProductAuth(c, productRegistSeq, map[string]interface{}{
+		"status":        model.ProductAuthStatusOK,
+		"reject_reason": "",
+	})
+}
+
+// RejectProductAuth 는 인증 대기 중인 제품 인증 정보를 반려한다.
+// 인증 대기 상태가 아니면 gorm.ErrRecordNotFound 에러를 반환한다.
+func (r productRepository) RejectProductAuth(c context.Context, productRegistSeq int64, reason string) error {
+	return reviewProductAuth(c, productRegistSeq, map[string]interface{}{
+		"status":        model.ProductAuthStatusRejected,
+		"reject_reason": reason,
+	})
+}
+
+func reviewProductAuth(c context.Context, productRegistSeq int64, updates map[string]interface{}) error {
 	switch {
 	case c == nil:
 		return errors.New("nil context")
@@ -93,16 +156,41 @@ func (r productRepository) CancelProductAuth(c context.Context, productRegistSeq
 		return errors.Wrap(err, "failed to get db connection")
 	}
 
-	if err := conn.Exec("UPDATE product_regist SET status = @ProductAuthStatusCancel, modified = @modified WHERE product_regist_seq = @productRegistSeq",
-		sql.Named("ProductAuthStatusCancel", model.ProductAuthStatusCancel),
-		sql.Named("modified", time.Now()),
-		sql.Named("productRegistSeq", productRegistSeq)).Error; err != nil {
-		return errors.Wrap(err, "failed to cancel product auth")
+	updates["modified"] = time.Now()
+	tx := conn.Model(&model.ProductRegist{}).
+		Where("product_regist_seq = ? AND status = ?", productRegistSeq, model.ProductAuthStatusPending).
+		Updates(updates)
+	if err := tx.Error; err != nil {
+		return errors.Wrap(err, "failed to review product auth")
+	}
+	if tx.RowsAffected == 0 {
+		return errors.Wrapf(gorm.ErrRecordNotFound, "pending product regist(%d) does not exist", productRegistSeq)
 	}
 
 	return nil
 }
 
+func updateProductAuthStatus(c context.Context, productRegistSeq int64, status model.ProductAuthStatus) error {
+	switch {
+	case c == nil:
+		return errors.New("nil context")
+	case productRegistSeq == 0:
+		return errors.New("product sequence is invalid")
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get db connection")
+	}
+
+	return conn.Model(&model.ProductRegist{}).
+		Where("product_regist_seq = ?", productRegistSeq).
+		Updates(map[string]interface{}{
+			"status":   status,
+			"modified": time.Now(),
+		}).Error
+}
+
 func (r productRepository) ModProductAuth(c context.Context, productRegist *model.ProductRegist) error {
 	switch {
 	case c == nil:
@@ -146,6 +234,28 @@ func (r productRepository) GetProductBySerial(c context.Context, serial string,
 	return product, nil
 }
 
+// FindProductsBySerial 은 제품 종류와 관계없이 시리얼 번호가 일치하는 제품을 조회한다.
+func (r productRepository) FindProductsBySerial(c context.Context, serial string) ([]*model.Product, error) {
+	switch {
+	case c == nil:
+		return nil, errors.New("nil context")
+	case serial == "":
+		return nil, errors.New("serial number is required")
+	}
+
+	conn, err := db.ConnFromContext(c, db.ReadDBKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get db connection")
+	}
+
+	var products []*model.Product
+	if err := conn.Where("serial_no = ?", serial).Order("product_type").Find(&products).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to find products by serial no")
+	}
+
+	return products, nil
+}
+
 func (r productRepository) GetProductRegistByProductSeq(c context.Context, productSeq int64) (*model.ProductRegist, error) {
 	switch {
 	case c == nil:
@@ -160,7 +270,7 @@ func (r productRepository) GetProductRegistByProductSeq(c context.Context, produ
 	}
 
 	productRegist := new(model.ProductRegist)
-	if err := conn.Where("product_seq = ? AND status = ?", productSeq, model.ProductAuthStatusOK).Take(&productRegist).Error; err != nil {
+	if err := conn.Where("product_seq = ? AND status IN ?", productSeq, activeProductAuthStatuses).Take(&productRegist).Error; err != nil {
 		return nil, errors.Wrap(err, "failed to get product regist by product sequence")
 	}
 
@@ -193,7 +303,7 @@ func (r productRepository) FindProductManageInfo(c context.Context, req model.Pr
 		return nil, errors.New("nil context")
 	}
 
-	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	conn, err := db.ConnFromContext(c, db.ReadDBKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get db connection")
 	}
@@ -210,18 +320,20 @@ func (r productRepository) FindProductManageInfo(c context.Context, req model.Pr
 			"pr.addr",
 			"pr.addr_detail",
 			"pr.market_type",
+			"pr.order_no",
 			"pr.purchase_date",
+			"pm.warranty_months",
 			"pr.regdate AS product_regist_regdate",
 			"pr.status",
-			"CONCAT(pr.name, '(', pr.phone, ') 영수증') AS filename",
+			"pr.reject_reason",
+			"pr.receipt_ocr",
 		},
-	)
+	).Joins("LEFT JOIN product_model pm ON pm.code = p.product_type")
 
 	switch req.AuthStatus {
-	case model.ProductAuthStatusOK:
-		tx.Joins("INNER JOIN product_regist pr ON p.product_seq = pr.product_seq AND pr.status = ?", model.ProductAuthStatusOK)
-	case model.ProductAuthStatusCancel:
-		tx.Joins("INNER JOIN product_regist pr ON p.product_seq = pr.product_seq AND pr.status = ?", model.ProductAuthStatusCancel)
+	case model.ProductAuthStatusOK, model.ProductAuthStatusCancel, model.ProductAuthStatusTransferred,
+		model.ProductAuthStatusPending, model.ProductAuthStatusRejected:
+		tx.Joins("INNER JOIN product_regist pr ON p.product_seq = pr.product_seq AND pr.status = ?", req.AuthStatus)
 	default:
 		tx.Joins("LEFT JOIN product_regist pr ON p.product_seq = pr.product_seq")
 	}
@@ -242,45 +354,172 @@ func (r productRepository) FindProductManageInfo(c context.Context, req model.Pr
 		tx.Limit(req.Limit).Offset(req.Offset)
 	}
 
+	// 인증 대기 목록은 영수증 확인 순서( 먼저 인증한 순 )로 조회한다.
+	if req.AuthStatus == model.ProductAuthStatusPending {
+		tx.Order("pr.regdate").Order("pr.product_regist_seq")
+	} else {
+		tx.Order("pr.name")
+	}
+
 	result := make(model.ProductManageInfos, 0)
-	if err := tx.Order("pr.name").Scan(&result).Error; err != nil {
+	if err := tx.Scan(&result).Error; err != nil {
 		return nil, errors.Wrap(err, "failed to execute find product manage info list query")
 	}
 
+	for i := range result {
+		if result[i].ProductRegistSeq != 0 {
+			result[i].Filename = model.ReceiptFilename(result[i].Name, result[i].Phone, i18n.DefaultLanguage)
+		}
+	}
+
 	return result, nil
 }
 
-func (r productRepository) GetProductAuthInfo(c context.Context, req model.ProductAuthRequest) (*model.ProductAuthInfo, error) {
+// FindProductAuthInfos 는 이름, 휴대폰 번호가 일치하는 고객의 인증 완료, 인증 대기, 반려된 제품 인증 정보를 인증일 역순으로 조회한다.
+func (r productRepository) FindProductAuthInfos(c context.Context, req model.ProductAuthRequest) (model.ProductAuthInfos, error) {
 	if c == nil {
 		return nil, errors.New("nil context")
 	}
 
-	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	conn, err := db.ConnFromContext(c, db.ReadDBKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get db connection")
 	}
 
-	tx := conn.Table("product p").
+	tx := withProductAuthInfo(conn).
+		Where("pr.name = ?", req.Name).
+		// ProductAuthRequest.Matches 와 같이 휴대폰 번호의 '-' 는 무시한다.
+		Where("REPLACE(pr.phone, '-', '') = ?", model.NormalizePhone(req.Phone)).
+		Where("pr.status IN ?", customerProductAuthStatuses).
+		Order("pr.regdate desc")
+
+	result := make(model.ProductAuthInfos, 0)
+	if err := tx.Scan(&result).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to find product auth infos")
+	}
+
+	return result, nil
+}
+
+func (r productRepository) GetProductAuthInfoBySeq(c context.Context, productRegistSeq int64) (*model.ProductAuthInfo, error) {
+	switch {
+	case c == nil:
+		return nil, errors.New("nil context")
+	case productRegistSeq == 0:
+		return nil, errors.New("product regist sequence is required")
+	}
+
+	conn, err := db.ConnFromContext(c, db.ReadDBKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get db connection")
+	}
+
+	result := new(model.ProductAuthInfo)
+	if err := withProductAuthInfo(conn).Where("pr.product_regist_seq = ?", productRegistSeq).Take(result).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to get product auth info(%d)", productRegistSeq)
+	}
+
+	return result, nil
+}
+
+// withProductAuthInfo 는 제품 인증 정보( alias pr )를 제품, 제품 모델, 판매 채널 정보와 함께 조회한다.
+func withProductAuthInfo(conn *gorm.DB) *gorm.DB {
+	return conn.Table("product_regist pr").
 		Select(
 			[]string{
+				"pr.product_regist_seq",
+				"pr.status",
+				"pr.reject_reason",
 				"pr.name",
 				"pr.phone",
 				"p.product_type",
 				"pr.market_type",
+				"pr.order_no",
 				"pr.purchase_date",
 				"p.serial_no",
+				"pm.warranty_months",
+				"pm.name_ko AS product_name_ko",
+				"pm.name_en AS product_name_en",
+				"sc.name_ko AS market_name_ko",
+				"sc.name_en AS market_name_en",
 			},
 		).
-		Joins("INNER JOIN product_regist pr ON p.product_seq = pr.product_seq").
-		Where("pr.name = ?", req.Name).
-		Where("pr.phone = ?", req.Phone).
-		Order("pr.regdate desc").
-		Limit(1)
+		Joins("INNER JOIN product p ON p.product_seq = pr.product_seq").
+		Joins("LEFT JOIN product_model pm ON pm.code = p.product_type").
+		Joins("LEFT JOIN sales_channel sc ON sc.code = pr.market_type")
+}
 
-	result := model.ProductAuthInfo{}
-	if err := tx.Order("pr.name").Scan(&result).Error; err != nil {
-		return nil, errors.Wrap(err, "failed to execute find product manage info list query")
+func (r productRepository) FindReceiptLocations(c context.Context, locations []string) ([]string, error) {
+	if c == nil {
+		return nil, errors.New("nil context")
+	}
+
+	result := make([]string, 0)
+	if len(locations) == 0 {
+		return result, nil
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get db connection")
+	}
+
+	if err := conn.Model(&model.ProductRegist{}).Where("receipt_s3_location IN ?", locations).Distinct().Pluck("receipt_s3_location", &result).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to find receipt locations")
+	}
+
+	return result, nil
+}
+
+// FindLegacyReceiptProductRegists 는 keyPrefix 하위가 아닌 위치의 영수증을 가진 인증정보를 afterSeq 이후부터 조회한다.
+func (r productRepository) FindLegacyReceiptProductRegists(c context.Context, keyPrefix string, afterSeq int64, limit int) ([]*model.ProductRegist, error) {
+	switch {
+	case c == nil:
+		return nil, errors.New("nil context")
+	case keyPrefix == "":
+		return nil, errors.New("key prefix is required")
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get db connection")
+	}
+
+	result := make([]*model.ProductRegist, 0)
+	if err := conn.Where("product_regist_seq > ?", afterSeq).
+		Where("receipt_s3_location <> '' AND receipt_s3_location NOT LIKE ?", keyPrefix+"/%").
+		Order("product_regist_seq").
+		Limit(limit).
+		Find(&result).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to find legacy receipt product regists")
+	}
+
+	return result, nil
+}
+
+func (r productRepository) UpdateReceiptLocation(c context.Context, productRegistSeq int64, oldLocation, newLocation string) error {
+	switch {
+	case c == nil:
+		return errors.New("nil context")
+	case productRegistSeq == 0:
+		return errors.New("product regist sequence is required")
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get db connection")
 	}
 
-	return &result, nil
+	tx := conn.Model(&model.ProductRegist{}).
+		Where("product_regist_seq = ?", productRegistSeq).
+		Where("receipt_s3_location = ?", oldLocation).
+		Update("receipt_s3_location", newLocation)
+	if err := tx.Error; err != nil {
+		return errors.Wrap(err, "failed to update receipt location")
+	}
+	if tx.RowsAffected == 0 {
+		return errors.Wrapf(gorm.ErrRecordNotFound, "receipt location was changed [ product_regist_seq = %d ]", productRegistSeq)
+	}
+
+	return nil
 }
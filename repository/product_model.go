@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"buddle-server/model"
+	"context"
+)
+
+type ProductModelRepository interface {
+	CatalogRepository
+	GetProductModel(c context.Context, code model.ProductType) (*model.ProductModel, error)
+	FindProductModels(c context.Context, activeOnly bool) (model.ProductModels, error)
+}
+
+type productModelRepository struct {
+	catalogRepository
+}
+
+// NewProductModelRepository 는 제품 모델 repository 를 생성한다. 제품 시리얼, A/S 신청이 제품 모델을 참조한다.
+func NewProductModelRepository() ProductModelRepository {
+	return &productModelRepository{catalogRepository{
+		name:      "product model",
+		table:     model.ProductModel{}.TableName(),
+		refColumn: "product_type",
+		refTables: []interface{}{&model.Product{}, &model.AfterService{}},
+	}}
+}
+
+func (r productModelRepository) GetProductModel(c context.Context, code model.ProductType) (*model.ProductModel, error) {
+	productModel := new(model.ProductModel)
+	if err := r.get(c, int(code), productModel); err != nil {
+		return nil, err
+	}
+
+	return productModel, nil
+}
+
+func (r productModelRepository) FindProductModels(c context.Context, activeOnly bool) (model.ProductModels, error) {
+	result := make(model.ProductModels, 0)
+	if err := r.find(c, activeOnly, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package repository_test
+
+import (
+	"buddle-server/model"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestProductModelRepository(t *testing.T) {
+	c, repo := newTestRepository(t)
+
+	// 마이그레이션으로 기존 ProductType 이 등록되어 있다.
+	seeded, err := repo.ProductModel().FindProductModels(c, true)
+	if err != nil {
+		t.Fatalf("FindProductModels() error = %+v", err)
+	}
+	if len(seeded) != 4 || seeded[2].Code != model.ProductTypeBabyBottleWasher || seeded[2].NameKo != "버들아이 젖병세척기" {
+		t.Fatalf("FindProductModels() = %+v", seeded)
+	}
+
+	productModel := &model.ProductModel{Code: 10, NameKo: "버들 분유포트", NameEn: "Buddle Formula Kettle", WarrantyMonths: 24}
+	if err := repo.ProductModel().Create(c, productModel); err != nil {
+		t.Fatalf("Create() error = %+v", err)
+	}
+
+	productModel.ModelNo = "BD-K100"
+	productModel.SerialPrefix, productModel.SerialLength, productModel.SerialCharset, productModel.SerialCheckDigit = "BK", 8, model.SerialCharsetAlphanumeric, true
+	if err := repo.ProductModel().Update(c, productModel); err != nil {
+		t.Fatalf("Update() error = %+v", err)
+	}
+	got, err := repo.ProductModel().GetProductModel(c, 10)
+	if err != nil {
+		t.Fatalf("GetProductModel() error = %+v", err)
+	}
+	if got.ModelNo != "BD-K100" || got.Active || got.WarrantyMonths != 24 || got.SerialRule() != productModel.SerialRule() {
+		t.Errorf("GetProductModel() = %+v", got)
+	}
+	if _, err := repo.ProductModel().GetProductModel(c, 11); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetProductModel() unknown code error = %v", err)
+	}
+
+	// 판매 중지 모델은 판매 중 목록에서 제외된다.
+	if active, _ := repo.ProductModel().FindProductModels(c, true); len(active) != 4 {
+		t.Errorf("FindProductModels(active) = %d models, want 4", len(active))
+	}
+	if all, _ := repo.ProductModel().FindProductModels(c, false); len(all) != 5 {
+		t.Errorf("FindProductModels(all) = %d models, want 5", len(all))
+	}
+}
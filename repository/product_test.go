@@ -0,0 +1,448 @@
+package repository_test
+
+import (
+	"buddle-server/model"
+	"buddle-server/repository"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestProductRepository_Create(t *testing.T) {
+	c, repo := newTestRepository(t)
+
+	product := seedProduct(t, c, repo, "BM0001", model.ProductTypePowderMilkMaker)
+	if product.ProductSeq == 0 {
+		t.Fatalf("Create() did not set product_seq")
+	}
+	if product.RegDate.IsZero() || !product.Modified.Equal(product.RegDate) {
+		t.Errorf("Create() regdate = %v, modified = %v", product.RegDate, product.Modified)
+	}
+
+	// 같은 시리얼이라도 제품 종류가 다르면 등록할 수 있다.
+	seedProduct(t, c, repo, "BM0001", model.ProductTypeBabyBottleWasher)
+
+	err := repo.Product().Create(c, &model.Product{SerialNo: "BM0001", ProductType: model.ProductTypePowderMilkMaker})
+	if !errors.Is(err, repository.ErrDuplicateKey) {
+		t.Errorf("Create() duplicated serial error = %v, want ErrDuplicateKey", err)
+	}
+}
+
+func TestProductRepository_CreateProductRegist(t *testing.T) {
+	c, repo := newTestRepository(t)
+	product := seedProduct(t, c, repo, "BM0001", model.ProductTypePowderMilkMaker)
+
+	if err := repo.Product().CreateProductRegist(c, &model.ProductRegist{}); err == nil {
+		t.Errorf("CreateProductRegist() without product_seq error = nil")
+	}
+
+	productRegist := seedProductRegist(t, c, repo, product, "홍길동", "01012345678", time.Time{})
+	if productRegist.ProductRegistSeq == 0 {
+		t.Fatalf("CreateProductRegist() did not set product_regist_seq")
+	}
+	if productRegist.Status != model.ProductAuthStatusOK {
+		t.Errorf("CreateProductRegist() status = %d, want %d", productRegist.Status, model.ProductAuthStatusOK)
+	}
+
+	// 인증 완료 또는 인증 대기 상태의 정보는 제품당 하나만 존재할 수 있다.
+	pending := &model.ProductRegist{ProductSeq: product.ProductSeq, Name: "김철수", PurchaseDate: time.Now()}
+	err := repo.Product().CreateProductRegist(c, pending)
+	if !errors.Is(err, repository.ErrDuplicateKey) {
+		t.Fatalf("CreateProductRegist() duplicated active regist error = %v, want ErrDuplicateKey", err)
+	}
+	// 상태를 지정하지 않으면 인증 대기 상태로 저장된다.
+	if pending.Status != model.ProductAuthStatusPending {
+		t.Errorf("CreateProductRegist() default status = %d, want %d", pending.Status, model.ProductAuthStatusPending)
+	}
+
+	// 취소 후에는 다시 인증할 수 있다.
+	if err := repo.Product().CancelProductAuth(c, productRegist.ProductRegistSeq); err != nil {
+		t.Fatalf("CancelProductAuth() error = %+v", err)
+	}
+	seedProductRegist(t, c, repo, product, "김철수", "01098765432", time.Time{})
+}
+
+func TestProductRepository_CancelProductAuth(t *testing.T) {
+	c, repo := newTestRepository(t)
+	product := seedProduct(t, c, repo, "BM0001", model.ProductTypePowderMilkMaker)
+	productRegist := seedProductRegist(t, c, repo, product, "홍길동", "01012345678", time.Now().Add(-time.Hour))
+
+	if err := repo.Product().CancelProductAuth(c, 0); err == nil {
+		t.Errorf("CancelProductAuth(0) error = nil")
+	}
+	if err := repo.Product().CancelProductAuth(c, productRegist.ProductRegistSeq); err != nil {
+		t.Fatalf("CancelProductAuth() error = %+v", err)
+	}
+
+	got, err := repo.Product().GetProductRegistBySeq(c, productRegist.ProductRegistSeq)
+	if err != nil {
+		t.Fatalf("GetProductRegistBySeq() error = %+v", err)
+	}
+	if got.Status != model.ProductAuthStatusCancel {
+		t.Errorf("status = %d, want %d", got.Status, model.ProductAuthStatusCancel)
+	}
+	if !got.Modified.After(productRegist.Modified) {
+		t.Errorf("modified = %v, want after %v", got.Modified, productRegist.Modified)
+	}
+}
+
+func TestProductRepository_ModProductAuth(t *testing.T) {
+	c, repo := newTestRepository(t)
+	product := seedProduct(t, c, repo, "BM0001", model.ProductTypePowderMilkMaker)
+	productRegist := seedProductRegist(t, c, repo, product, "홍길동", "01012345678", time.Now())
+
+	purchaseDate := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	mod := &model.ProductRegist{
+		ProductRegistSeq: productRegist.ProductRegistSeq,
+		Name:             "홍길순",
+		Phone:            "01011112222",
+		Addr:             "부산시",
+		AddrDetail:       "202호",
+		PurchaseDate:     purchaseDate,
+	}
+	if err := repo.Product().ModProductAuth(c, mod); err != nil {
+		t.Fatalf("ModProductAuth() error = %+v", err)
+	}
+
+	got, err := repo.Product().GetProductRegistBySeq(c, productRegist.ProductRegistSeq)
+	if err != nil {
+		t.Fatalf("GetProductRegistBySeq() error = %+v", err)
+	}
+	if got.Name != "홍길순" || got.Phone != "01011112222" || got.Addr != "부산시" || got.AddrDetail != "202호" || !got.PurchaseDate.Equal(purchaseDate) {
+		t.Errorf("ModProductAuth() result = %+v", got)
+	}
+	if got.Status != model.ProductAuthStatusOK || got.ReceiptS3Location != productRegist.ReceiptS3Location || !got.Regdate.Equal(productRegist.Regdate) {
+		t.Errorf("ModProductAuth() changed status, receipt or regdate: %+v", got)
+	}
+
+	if err := repo.Product().ModProductAuth(c, &model.ProductRegist{}); err == nil {
+		t.Errorf("ModProductAuth() without product_regist_seq error = nil")
+	}
+}
+
+func TestProductRepository_GetProductBySerial(t *testing.T) {
+	c, repo := newTestRepository(t)
+	product := seedProduct(t, c, repo, "BM0001", model.ProductTypePowderMilkMakerSmart)
+
+	got, err := repo.Product().GetProductBySerial(c, "BM0001", model.ProductTypePowderMilkMakerSmart)
+	if err != nil {
+		t.Fatalf("GetProductBySerial() error = %+v", err)
+	}
+	if got.ProductSeq != product.ProductSeq {
+		t.Errorf("GetProductBySerial() product_seq = %d, want %d", got.ProductSeq, product.ProductSeq)
+	}
+
+	if _, err := repo.Product().GetProductBySerial(c, "BM0001", model.ProductTypeSmartChopper); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetProductBySerial() other type error = %v, want ErrRecordNotFound", err)
+	}
+	if _, err := repo.Product().GetProductBySerial(c, "", model.ProductTypeSmartChopper); err == nil {
+		t.Errorf("GetProductBySerial() empty serial error = nil")
+	}
+}
+
+func TestProductRepository_FindProductsBySerial(t *testing.T) {
+	c, repo := newTestRepository(t)
+	seedProduct(t, c, repo, "BM0001", model.ProductTypeSmartChopper)
+	seedProduct(t, c, repo, "BM0001", model.ProductTypePowderMilkMaker)
+	seedProduct(t, c, repo, "BM0002", model.ProductTypePowderMilkMaker)
+
+	got, err := repo.Product().FindProductsBySerial(c, "BM0001")
+	if err != nil {
+		t.Fatalf("FindProductsBySerial() error = %+v", err)
+	}
+	if len(got) != 2 || got[0].ProductType != model.ProductTypePowderMilkMaker || got[1].ProductType != model.ProductTypeSmartChopper {
+		t.Errorf("FindProductsBySerial() = %+v", got)
+	}
+
+	if got, err := repo.Product().FindProductsBySerial(c, "BM0003"); err != nil || len(got) != 0 {
+		t.Errorf("FindProductsBySerial() unknown serial = %+v, %v", got, err)
+	}
+	if _, err := repo.Product().FindProductsBySerial(c, ""); err == nil {
+		t.Errorf("FindProductsBySerial() empty serial error = nil")
+	}
+}
+
+func TestProductRepository_GetProductRegistByProductSeq(t *testing.T) {
+	c, repo := newTestRepository(t)
+	product := seedProduct(t, c, repo, "BM0001", model.ProductTypePowderMilkMaker)
+	canceled := seedProductRegist(t, c, repo, product, "홍길동", "01012345678", time.Now().Add(-time.Hour))
+	if err := repo.Product().CancelProductAuth(c, canceled.ProductRegistSeq); err != nil {
+		t.Fatalf("CancelProductAuth() error = %+v", err)
+	}
+
+	// 인증 완료 상태의 정보만 조회한다.
+	if _, err := repo.Product().GetProductRegistByProductSeq(c, product.ProductSeq); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetProductRegistByProductSeq() with only canceled regist error = %v, want ErrRecordNotFound", err)
+	}
+
+	active := seedProductRegist(t, c, repo, product, "김철수", "01098765432", time.Now())
+	got, err := repo.Product().GetProductRegistByProductSeq(c, product.ProductSeq)
+	if err != nil {
+		t.Fatalf("GetProductRegistByProductSeq() error = %+v", err)
+	}
+	if got.ProductRegistSeq != active.ProductRegistSeq {
+		t.Errorf("GetProductRegistByProductSeq() = %d, want %d", got.ProductRegistSeq, active.ProductRegistSeq)
+	}
+}
+
+func TestProductRepository_GetProductRegistBySeq(t *testing.T) {
+	c, repo := newTestRepository(t)
+	product := seedProduct(t, c, repo, "BM0001", model.ProductTypePowderMilkMaker)
+	productRegist := seedProductRegist(t, c, repo, product, "홍길동", "01012345678", time.Now())
+
+	got, err := repo.Product().GetProductRegistBySeq(c, productRegist.ProductRegistSeq)
+	if err != nil {
+		t.Fatalf("GetProductRegistBySeq() error = %+v", err)
+	}
+	if got.Name != "홍길동" || got.ProductSeq != product.ProductSeq || got.ReceiptS3Location != "receipt/BM0001" {
+		t.Errorf("GetProductRegistBySeq() = %+v", got)
+	}
+
+	if _, err := repo.Product().GetProductRegistBySeq(c, productRegist.ProductRegistSeq+1); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetProductRegistBySeq() unknown seq error = %v, want ErrRecordNotFound", err)
+	}
+}
+
+func TestProductRepository_FindProductManageInfo(t *testing.T) {
+	c, repo := newTestRepository(t)
+
+	registered := seedProduct(t, c, repo, "BM0001", model.ProductTypePowderMilkMaker)
+	seedProductRegist(t, c, repo, registered, "홍길동", "01012345678", time.Now())
+
+	canceled := seedProduct(t, c, repo, "BM0002", model.ProductTypePowderMilkMaker)
+	canceledRegist := seedProductRegist(t, c, repo, canceled, "김철수", "01098765432", time.Now())
+	if err := repo.Product().CancelProductAuth(c, canceledRegist.ProductRegistSeq); err != nil {
+		t.Fatalf("CancelProductAuth() error = %+v", err)
+	}
+
+	seedProduct(t, c, repo, "BW0001", model.ProductTypeBabyBottleWasher)
+
+	tests := []struct {
+		name    string
+		req     model.ProductManageRequest
+		serials []string
+	}{
+		{name: "all products with left join", req: model.ProductManageRequest{}, serials: []string{"BM0001", "BM0002", "BW0001"}},
+		{name: "registered", req: model.ProductManageRequest{AuthStatus: model.ProductAuthStatusOK}, serials: []string{"BM0001"}},
+		{name: "canceled", req: model.ProductManageRequest{AuthStatus: model.ProductAuthStatusCancel}, serials: []string{"BM0002"}},
+		{name: "name prefix", req: model.ProductManageRequest{Name: "홍"}, serials: []string{"BM0001"}},
+		{name: "phone prefix", req: model.ProductManageRequest{Phone: "010987"}, serials: []string{"BM0002"}},
+		{name: "serial prefix", req: model.ProductManageRequest{SerialNo: "BM"}, serials: []string{"BM0001", "BM0002"}},
+		{name: "status and serial", req: model.ProductManageRequest{AuthStatus: model.ProductAuthStatusOK, SerialNo: "BW"}, serials: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.Product().FindProductManageInfo(c, tt.req)
+			if err != nil {
+				t.Fatalf("FindProductManageInfo() error = %+v", err)
+			}
+
+			serials := make([]string, 0, len(got))
+			for _, info := range got {
+				serials = append(serials, info.SerialNo)
+			}
+			sort.Strings(serials)
+			if len(serials) != len(tt.serials) {
+				t.Fatalf("FindProductManageInfo() serials = %v, want %v", serials, tt.serials)
+			}
+			for i := range serials {
+				if serials[i] != tt.serials[i] {
+					t.Fatalf("FindProductManageInfo() serials = %v, want %v", serials, tt.serials)
+				}
+			}
+		})
+	}
+
+	t.Run("joined columns", func(t *testing.T) {
+		got, err := repo.Product().FindProductManageInfo(c, model.ProductManageRequest{})
+		if err != nil {
+			t.Fatalf("FindProductManageInfo() error = %+v", err)
+		}
+		for _, info := range got {
+			switch info.SerialNo {
+			case "BM0001":
+				if info.Name != "홍길동" || info.Status != model.ProductAuthStatusOK || info.MarketType != model.MarketTypeCoupang || info.Filename != "홍길동(01012345678) 영수증" {
+					t.Errorf("registered product info = %+v", info)
+				}
+			case "BW0001":
+				if info.ProductRegistSeq != 0 || info.Name != "" || info.Filename != "" {
+					t.Errorf("unregistered product info = %+v", info)
+				}
+			}
+		}
+	})
+
+	t.Run("limit and offset", func(t *testing.T) {
+		first, err := repo.Product().FindProductManageInfo(c, model.ProductManageRequest{Limit: 2})
+		if err != nil {
+			t.Fatalf("FindProductManageInfo() error = %+v", err)
+		}
+		rest, err := repo.Product().FindProductManageInfo(c, model.ProductManageRequest{Limit: 2, Offset: 2})
+		if err != nil {
+			t.Fatalf("FindProductManageInfo() error = %+v", err)
+		}
+		if len(first) != 2 || len(rest) != 1 {
+			t.Errorf("FindProductManageInfo() pages = %d, %d, want 2, 1", len(first), len(rest))
+		}
+	})
+}
+
+func TestProductRepository_FindProductAuthInfos(t *testing.T) {
+	c, repo := newTestRepository(t)
+
+	older := seedProduct(t, c, repo, "BM0001", model.ProductTypePowderMilkMaker)
+	olderRegist := seedProductRegist(t, c, repo, older, "홍길동", "01012345678", time.Now().Add(-48*time.Hour))
+	newer := seedProduct(t, c, repo, "BW0001", model.ProductTypeBabyBottleWasher)
+	seedProductRegist(t, c, repo, newer, "홍길동", "01012345678", time.Now().Add(-time.Hour))
+	dashed := seedProduct(t, c, repo, "BW0004", model.ProductTypeBabyBottleWasher)
+	seedProductRegist(t, c, repo, dashed, "홍길동", "010-1234-5678", time.Now().Add(-24*time.Hour))
+	canceled := seedProduct(t, c, repo, "BW0003", model.ProductTypeBabyBottleWasher)
+	canceledRegist := seedProductRegist(t, c, repo, canceled, "홍길동", "01012345678", time.Now())
+	if err := repo.Product().CancelProductAuth(c, canceledRegist.ProductRegistSeq); err != nil {
+		t.Fatalf("CancelProductAuth() error = %+v", err)
+	}
+	other := seedProduct(t, c, repo, "BW0002", model.ProductTypeBabyBottleWasher)
+	seedProductRegist(t, c, repo, other, "홍길동", "01099999999", time.Now())
+
+	// 이름, 전화번호가 일치하는 인증 완료된 정보를 최근 순으로 조회한다. 전화번호의 '-' 는 무시한다.
+	got, err := repo.Product().FindProductAuthInfos(c, model.ProductAuthRequest{Name: "홍길동", Phone: "01012345678"})
+	if err != nil {
+		t.Fatalf("FindProductAuthInfos() error = %+v", err)
+	}
+	if len(got) != 3 || got[0].SerialNo != "BW0001" || got[1].SerialNo != "BW0004" || got[2].SerialNo != "BM0001" {
+		t.Fatalf("FindProductAuthInfos() = %+v, want BW0001, BW0004, BM0001", got)
+	}
+	if got[2].ProductRegistSeq != olderRegist.ProductRegistSeq || got[2].MarketType != model.MarketTypeCoupang || got[2].WarrantyMonths != 12 || got[2].ProductNameKo == "" {
+		t.Errorf("FindProductAuthInfos()[2] = %+v", got[2])
+	}
+	if dashedGot, err := repo.Product().FindProductAuthInfos(c, model.ProductAuthRequest{Name: "홍길동", Phone: "010-1234-5678"}); err != nil || len(dashedGot) != 3 {
+		t.Errorf("FindProductAuthInfos() dashed phone = %d infos, %v, want 3", len(dashedGot), err)
+	}
+
+	got, err = repo.Product().FindProductAuthInfos(c, model.ProductAuthRequest{Name: "홍길동", Phone: "01000000000"})
+	if err != nil || len(got) != 0 {
+		t.Errorf("FindProductAuthInfos() unknown customer = %+v, %v, want empty", got, err)
+	}
+}
+
+func TestProductRepository_GetProductAuthInfoBySeq(t *testing.T) {
+	c, repo := newTestRepository(t)
+	product := seedProduct(t, c, repo, "BM0001", model.ProductTypePowderMilkMaker)
+	productRegist := seedProductRegist(t, c, repo, product, "홍길동", "01012345678", time.Now())
+
+	got, err := repo.Product().GetProductAuthInfoBySeq(c, productRegist.ProductRegistSeq)
+	if err != nil {
+		t.Fatalf("GetProductAuthInfoBySeq() error = %+v", err)
+	}
+	if got.SerialNo != "BM0001" || got.Status != model.ProductAuthStatusOK || got.Name != "홍길동" {
+		t.Errorf("GetProductAuthInfoBySeq() = %+v", got)
+	}
+
+	if _, err := repo.Product().GetProductAuthInfoBySeq(c, productRegist.ProductRegistSeq+1); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetProductAuthInfoBySeq() unknown seq error = %v, want ErrRecordNotFound", err)
+	}
+}
+
+func TestProductRepository_ReviewProductAuth(t *testing.T) {
+	c, repo := newTestRepository(t)
+
+	newPending := func(serialNo, name string, regdate time.Time) *model.ProductRegist {
+		t.Helper()
+
+		product := seedProduct(t, c, repo, serialNo, model.ProductTypePowderMilkMaker)
+		productRegist := &model.ProductRegist{ProductSeq: product.ProductSeq, Name: name, Phone: "01012345678", PurchaseDate: time.Now(), Regdate: regdate}
+		if err := repo.Product().CreateProductRegist(c, productRegist); err != nil {
+			t.Fatalf("CreateProductRegist() error = %+v", err)
+		}
+		return productRegist
+	}
+	later := newPending("BM0002", "김철수", time.Now())
+	earlier := newPending("BM0001", "홍길동", time.Now().Add(-time.Hour))
+
+	// 인증 대기 목록은 먼저 인증한 순으로 조회한다.
+	queue, err := repo.Product().FindProductManageInfo(c, model.ProductManageRequest{AuthStatus: model.ProductAuthStatusPending})
+	if err != nil {
+		t.Fatalf("FindProductManageInfo() error = %+v", err)
+	}
+	if len(queue) != 2 || queue[0].ProductRegistSeq != earlier.ProductRegistSeq || queue[1].ProductRegistSeq != later.ProductRegistSeq {
+		t.Fatalf("FindProductManageInfo(pending) = %+v", queue)
+	}
+
+	// 인증 대기 중인 제품은 다시 인증할 수 없다.
+	err = repo.Product().CreateProductRegist(c, &model.ProductRegist{ProductSeq: earlier.ProductSeq, Name: "이영희", PurchaseDate: time.Now()})
+	if !errors.Is(err, repository.ErrDuplicateKey) {
+		t.Fatalf("CreateProductRegist() with pending regist error = %v, want ErrDuplicateKey", err)
+	}
+
+	if err := repo.Product().ApproveProductAuth(c, earlier.ProductRegistSeq); err != nil {
+		t.Fatalf("ApproveProductAuth() error = %+v", err)
+	}
+	if err := repo.Product().RejectProductAuth(c, later.ProductRegistSeq, "영수증의 제품명이 다릅니다."); err != nil {
+		t.Fatalf("RejectProductAuth() error = %+v", err)
+	}
+	// 인증 대기 상태가 아니면 변경하지 않는다.
+	if err := repo.Product().RejectProductAuth(c, earlier.ProductRegistSeq, "중복"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("RejectProductAuth() approved regist error = %v, want ErrRecordNotFound", err)
+	}
+
+	approved, err := repo.Product().GetProductAuthInfoBySeq(c, earlier.ProductRegistSeq)
+	if err != nil {
+		t.Fatalf("GetProductAuthInfoBySeq() error = %+v", err)
+	}
+	if approved.Status != model.ProductAuthStatusOK || approved.RejectReason != "" {
+		t.Errorf("approved = %+v", approved)
+	}
+	rejected, err := repo.Product().GetProductAuthInfoBySeq(c, later.ProductRegistSeq)
+	if err != nil {
+		t.Fatalf("GetProductAuthInfoBySeq() error = %+v", err)
+	}
+	if rejected.Status != model.ProductAuthStatusRejected || rejected.RejectReason != "영수증의 제품명이 다릅니다." {
+		t.Errorf("rejected = %+v", rejected)
+	}
+
+	// 반려된 제품은 다시 인증할 수 있다.
+	newRegist := &model.ProductRegist{ProductSeq: later.ProductSeq, Name: "김철수", PurchaseDate: time.Now()}
+	if err := repo.Product().CreateProductRegist(c, newRegist); err != nil {
+		t.Fatalf("CreateProductRegist() after reject error = %+v", err)
+	}
+}
+
+func TestProductRepository_ReceiptLocations(t *testing.T) {
+	c, repo := newTestRepository(t)
+
+	legacy := seedProduct(t, c, repo, "BM0001", model.ProductTypePowderMilkMaker)
+	legacyRegist := seedProductRegist(t, c, repo, legacy, "홍길동", "01012345678", time.Now())
+	if err := repo.Product().UpdateReceiptLocation(c, legacyRegist.ProductRegistSeq, legacyRegist.ReceiptS3Location, "2022-03-01/1"); err != nil {
+		t.Fatalf("UpdateReceiptLocation() error = %+v", err)
+	}
+	current := seedProduct(t, c, repo, "BM0002", model.ProductTypePowderMilkMaker)
+	seedProductRegist(t, c, repo, current, "김철수", "01098765432", time.Now())
+
+	found, err := repo.Product().FindReceiptLocations(c, []string{"2022-03-01/1", "receipt/BM0002", "receipt/unknown"})
+	if err != nil {
+		t.Fatalf("FindReceiptLocations() error = %+v", err)
+	}
+	sort.Strings(found)
+	if len(found) != 2 || found[0] != "2022-03-01/1" || found[1] != "receipt/BM0002" {
+		t.Errorf("FindReceiptLocations() = %v", found)
+	}
+
+	legacies, err := repo.Product().FindLegacyReceiptProductRegists(c, "receipt", 0, 10)
+	if err != nil {
+		t.Fatalf("FindLegacyReceiptProductRegists() error = %+v", err)
+	}
+	if len(legacies) != 1 || legacies[0].ProductRegistSeq != legacyRegist.ProductRegistSeq {
+		t.Errorf("FindLegacyReceiptProductRegists() = %+v", legacies)
+	}
+	if legacies, err = repo.Product().FindLegacyReceiptProductRegists(c, "receipt", legacyRegist.ProductRegistSeq, 10); err != nil || len(legacies) != 0 {
+		t.Errorf("FindLegacyReceiptProductRegists() after last seq = %+v, %v", legacies, err)
+	}
+
+	// 다른 요청이 위치를 변경한 경우 덮어쓰지 않는다.
+	if err := repo.Product().UpdateReceiptLocation(c, legacyRegist.ProductRegistSeq, "2022-03-01/999", "receipt/new"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("UpdateReceiptLocation() stale location error = %v, want ErrRecordNotFound", err)
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"buddle-server/internal/db"
+	"buddle-server/model"
+	"context"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type ProductTransferRepository interface {
+	Create(c context.Context, transfer *model.ProductTransfer) error
+	GetPendingTransfer(c context.Context, productSeq int64, transferCode string) (*model.ProductTransfer, error)
+	CancelPendingTransfers(c context.Context, fromProductRegistSeq int64) error
+	Complete(c context.Context, productTransferSeq, toProductRegistSeq int64) error
+}
+
+type productTransferRepository struct{}
+
+func NewProductTransferRepository() ProductTransferRepository {
+	return &productTransferRepository{}
+}
+
+func (r productTransferRepository) Create(c context.Context, transfer *model.ProductTransfer) error {
+	switch {
+	case c == nil:
+		return errors.New("nil context")
+	case transfer == nil:
+		return errors.New("product transfer is nil")
+	case transfer.ProductSeq == 0 || transfer.FromProductRegistSeq == 0:
+		return errors.New("product sequence is invalid")
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get db connection")
+	}
+
+	if transfer.Regdate.IsZero() {
+		transfer.Regdate = time.Now()
+	}
+
+	transfer.Modified = transfer.Regdate
+	transfer.Status = model.ProductTransferStatusPending
+
+	return translateError(conn.Create(transfer).Error)
+}
+
+// GetPendingTransfer 는 제품의 이전 코드가 일치하는 대기 중인 이전 요청을 조회한다. ( 만료 여부는 확인하지 않음 )
+func (r productTransferRepository) GetPendingTransfer(c context.Context, productSeq int64, transferCode string) (*model.ProductTransfer, error) {
+	switch {
+	case c == nil:
+		return nil, errors.New("nil context")
+	case productSeq == 0:
+		return nil, errors.New("product sequence is required")
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get db connection")
+	}
+
+	transfer := new(model.ProductTransfer)
+	if err := conn.Where("product_seq = ? AND transfer_code = ? AND status = ?", productSeq, transferCode, model.ProductTransferStatusPending).
+		Take(transfer).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to get pending product transfer")
+	}
+
+	return transfer, nil
+}
+
+// CancelPendingTransfers 는 인증 정보의 대기 중인 이전 요청을 모두 취소한다.
+func (r productTransferRepository) CancelPendingTransfers(c context.Context, fromProductRegistSeq int64) error {
+	switch {
+	case c == nil:
+		return errors.New("nil context")
+	case fromProductRegistSeq == 0:
+		return errors.New("product regist sequence is required")
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get db connection")
+	}
+
+	if err := conn.Model(&model.ProductTransfer{}).
+		Where("from_product_regist_seq = ? AND status = ?", fromProductRegistSeq, model.ProductTransferStatusPending).
+		Updates(map[string]interface{}{
+			"status":   model.ProductTransferStatusCancel,
+			"modified": time.Now(),
+		}).Error; err != nil {
+		return errors.Wrap(err, "failed to cancel pending product transfers")
+	}
+
+	return nil
+}
+
+// Complete 는 이전 요청을 새 소유자의 인증 정보( toProductRegistSeq )로 완료 처리한다.
+func (r productTransferRepository) Complete(c context.Context, productTransferSeq, toProductRegistSeq int64) error {
+	switch {
+	case c == nil:
+		return errors.New("nil context")
+	case productTransferSeq == 0 || toProductRegistSeq == 0:
+		return errors.New("sequence is invalid")
+	}
+
+	conn, err := db.ConnFromContext(c, db.WriteDBKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get db connection")
+	}
+
+	if err := conn.Model(&model.ProductTransfer{}).
+		Where("product_transfer_seq = ?", productTransferSeq).
+		Updates(map[string]interface{}{
+			"to_product_regist_seq": toProductRegistSeq,
+			"status":                model.ProductTransferStatusDone,
+			"modified":              time.Now(),
+		}).Error; err != nil {
+		return errors.Wrap(err, "failed to complete product transfer")
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package repository_test
+
+import (
+	"buddle-server/model"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestProductTransferRepository(t *testing.T) {
+	c, repo := newTestRepository(t)
+	product := seedProduct(t, c, repo, "BM0001", model.ProductTypePowderMilkMaker)
+	from := seedProductRegist(t, c, repo, product, "홍길동", "01012345678", time.Time{})
+
+	newTransfer := func(code string) *model.ProductTransfer {
+		t.Helper()
+
+		transfer := &model.ProductTransfer{
+			ProductSeq:           product.ProductSeq,
+			FromProductRegistSeq: from.ProductRegistSeq,
+			TransferCode:         code,
+			ExpireDate:           time.Now().Add(model.ProductTransferExpiration),
+		}
+		if err := repo.ProductTransfer().Create(c, transfer); err != nil {
+			t.Fatalf("Create() error = %+v", err)
+		}
+		return transfer
+	}
+
+	if err := repo.ProductTransfer().Create(c, &model.ProductTransfer{TransferCode: "CODE0000"}); err == nil {
+		t.Errorf("Create() without product_seq error = nil")
+	}
+
+	first := newTransfer("CODE0001")
+	if first.ProductTransferSeq == 0 || first.Status != model.ProductTransferStatusPending {
+		t.Fatalf("Create() = %+v", first)
+	}
+
+	// 새 이전 요청 전에 기존 요청을 취소하면 이전 코드는 더 이상 조회되지 않는다.
+	if err := repo.ProductTransfer().CancelPendingTransfers(c, from.ProductRegistSeq); err != nil {
+		t.Fatalf("CancelPendingTransfers() error = %+v", err)
+	}
+	if _, err := repo.ProductTransfer().GetPendingTransfer(c, product.ProductSeq, "CODE0001"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetPendingTransfer() cancelled code error = %v, want ErrRecordNotFound", err)
+	}
+
+	second := newTransfer("CODE0002")
+	if _, err := repo.ProductTransfer().GetPendingTransfer(c, product.ProductSeq, "CODE0001"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetPendingTransfer() wrong code error = %v, want ErrRecordNotFound", err)
+	}
+	got, err := repo.ProductTransfer().GetPendingTransfer(c, product.ProductSeq, "CODE0002")
+	if err != nil {
+		t.Fatalf("GetPendingTransfer() error = %+v", err)
+	}
+	if got.ProductTransferSeq != second.ProductTransferSeq || got.FromProductRegistSeq != from.ProductRegistSeq {
+		t.Errorf("GetPendingTransfer() = %+v, want %+v", got, second)
+	}
+
+	// 양도인의 인증 정보는 이전 상태로 남고, 새 소유자가 인증할 수 있다.
+	if err := repo.Product().TransferProductAuth(c, from.ProductRegistSeq); err != nil {
+		t.Fatalf("TransferProductAuth() error = %+v", err)
+	}
+	transferred, err := repo.Product().GetProductRegistBySeq(c, from.ProductRegistSeq)
+	if err != nil {
+		t.Fatalf("GetProductRegistBySeq() error = %+v", err)
+	}
+	if transferred.Status != model.ProductAuthStatusTransferred {
+		t.Errorf("status = %d, want %d", transferred.Status, model.ProductAuthStatusTransferred)
+	}
+	to := seedProductRegist(t, c, repo, product, "김철수", "01098765432", time.Time{})
+
+	if err := repo.ProductTransfer().Complete(c, second.ProductTransferSeq, to.ProductRegistSeq); err != nil {
+		t.Fatalf("Complete() error = %+v", err)
+	}
+	if _, err := repo.ProductTransfer().GetPendingTransfer(c, product.ProductSeq, "CODE0002"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetPendingTransfer() completed code error = %v, want ErrRecordNotFound", err)
+	}
+
+	infos, err := repo.Product().FindProductManageInfo(c, model.ProductManageRequest{AuthStatus: model.ProductAuthStatusTransferred})
+	if err != nil {
+		t.Fatalf("FindProductManageInfo() error = %+v", err)
+	}
+	if len(infos) != 1 || infos[0].ProductRegistSeq != from.ProductRegistSeq {
+		t.Errorf("FindProductManageInfo(transferred) = %+v", infos)
+	}
+}
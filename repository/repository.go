@@ -1,17 +1,54 @@
 package repository
 
-import "github.com/pkg/errors"
+import (
+	"github.com/go-sql-driver/mysql"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// ErrDuplicateKey 는 unique 제약 조건 위반으로 저장에 실패한 경우
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// mysqlErDupEntry 는 MySQL 의 중복 키 에러 번호 (ER_DUP_ENTRY)
+const mysqlErDupEntry = 1062
+
+// sqliteUniqueConstraintFailed 는 SQLite 의 unique 제약 조건 위반 에러 메시지
+// ( sqlite3.Error 는 cgo 빌드에서만 정의되므로 메시지로 판단한다 )
+const sqliteUniqueConstraintFailed = "UNIQUE constraint failed"
+
+// translateError 는 DB 드라이버의 에러를 저장소 에러로 변환한다.
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErDupEntry {
+		return errors.Wrap(ErrDuplicateKey, mysqlErr.Message)
+	}
+	if strings.Contains(err.Error(), sqliteUniqueConstraintFailed) {
+		return errors.Wrap(ErrDuplicateKey, err.Error())
+	}
+
+	return err
+}
 
 type Repository interface {
 	Product() ProductRepository
 	User() UserRepository
 	AfterService() AfterServiceRepository
+	ProductModel() ProductModelRepository
+	SalesChannel() SalesChannelRepository
+	ProductTransfer() ProductTransferRepository
 }
 
 type repository struct {
-	product      ProductRepository
-	user         UserRepository
-	afterService AfterServiceRepository
+	product         ProductRepository
+	user            UserRepository
+	afterService    AfterServiceRepository
+	productModel    ProductModelRepository
+	salesChannel    SalesChannelRepository
+	productTransfer ProductTransferRepository
 }
 
 func (r repository) Product() ProductRepository {
@@ -26,6 +63,18 @@ func (r repository) AfterService() AfterServiceRepository {
 	return r.afterService
 }
 
+func (r repository) ProductModel() ProductModelRepository {
+	return r.productModel
+}
+
+func (r repository) SalesChannel() SalesChannelRepository {
+	return r.salesChannel
+}
+
+func (r repository) ProductTransfer() ProductTransferRepository {
+	return r.productTransfer
+}
+
 func (r repository) Validate() error {
 	switch {
 	case r.Product() == nil:
@@ -34,6 +83,12 @@ func (r repository) Validate() error {
 		return errors.New("user repository is nil")
 	case r.AfterService() == nil:
 		return errors.New("product repository is nil")
+	case r.ProductModel() == nil:
+		return errors.New("product model repository is nil")
+	case r.SalesChannel() == nil:
+		return errors.New("sales channel repository is nil")
+	case r.ProductTransfer() == nil:
+		return errors.New("product transfer repository is nil")
 	}
 
 	return nil
@@ -44,6 +99,9 @@ func NewRepository() (Repository, error) {
 		product: NewProductRepository(),
 		user:    NewUserRepository(),
 		afterService: NewAfterServiceRepository(),
+		productModel: NewProductModelRepository(),
+		salesChannel: NewSalesChannelRepository(),
+		productTransfer: NewProductTransferRepository(),
 	}
 
 	if err := r.Validate(); err != nil {
@@ -0,0 +1,89 @@
+package repository_test
+
+import (
+	"buddle-server/internal/db/dbtest"
+	"buddle-server/model"
+	"buddle-server/repository"
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestRepository(t *testing.T) (context.Context, repository.Repository) {
+	t.Helper()
+
+	c, _ := dbtest.New(t)
+	repo, err := repository.NewRepository()
+	if err != nil {
+		t.Fatalf("failed to create repository: %+v", err)
+	}
+
+	return c, repo
+}
+
+func seedProduct(t *testing.T, c context.Context, repo repository.Repository, serialNo string, productType model.ProductType) *model.Product {
+	t.Helper()
+
+	product := &model.Product{SerialNo: serialNo, ProductType: productType}
+	if err := repo.Product().Create(c, product); err != nil {
+		t.Fatalf("failed to seed product(%s): %+v", serialNo, err)
+	}
+
+	return product
+}
+
+func seedProductRegist(t *testing.T, c context.Context, repo repository.Repository, product *model.Product, name, phone string, regdate time.Time) *model.ProductRegist {
+	t.Helper()
+
+	productRegist := &model.ProductRegist{
+		ProductSeq:        product.ProductSeq,
+		Name:              name,
+		Phone:             phone,
+		Addr:              "서울시 강남구",
+		AddrDetail:        "101호",
+		MarketType:        model.MarketTypeCoupang,
+		PurchaseDate:      time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+		ReceiptS3Location: "receipt/" + product.SerialNo,
+		Status:            model.ProductAuthStatusOK,
+		Regdate:           regdate,
+	}
+	if err := repo.Product().CreateProductRegist(c, productRegist); err != nil {
+		t.Fatalf("failed to seed product regist(%s): %+v", product.SerialNo, err)
+	}
+
+	return productRegist
+}
+
+func seedAfterService(t *testing.T, c context.Context, repo repository.Repository, name, phone string, regdate time.Time, files ...string) *model.AfterService {
+	t.Helper()
+
+	as := &model.AfterService{
+		Name:         name,
+		Phone:        phone,
+		Addr:         "서울시 강남구",
+		AddrDetail:   "101호",
+		ProductType:  model.ProductTypeBabyBottleWasher,
+		MarketType:   model.MarketTypeNaver,
+		PurchaseDate: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+		Contents:     "전원이 켜지지 않습니다.",
+		RegDate:      regdate,
+	}
+	for i, file := range files {
+		*as.FileS3Locations()[i] = file
+	}
+	if err := repo.AfterService().Create(c, as); err != nil {
+		t.Fatalf("failed to seed after service(%s): %+v", name, err)
+	}
+
+	return as
+}
+
+func TestNewRepository(t *testing.T) {
+	repo, err := repository.NewRepository()
+	if err != nil {
+		t.Fatalf("NewRepository() error = %+v", err)
+	}
+	if repo.Product() == nil || repo.User() == nil || repo.AfterService() == nil {
+		t.Fatalf("NewRepository() returned nil sub repository")
+	}
+}
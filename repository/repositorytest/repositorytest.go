@@ -0,0 +1,442 @@
+// Package repositorytest 는 repository 인터페이스의 fake 를 제공한다.
+// Func 필드가 설정되지 않은 메서드를 호출하면 panic 이 발생한다.
+package repositorytest
+
+import (
+	"buddle-server/model"
+	"buddle-server/repository"
+	"context"
+	"fmt"
+)
+
+// Repository 는 repository.Repository 의 fake.
+type Repository struct {
+	ProductRepository         *ProductRepository
+	UserRepository            *UserRepository
+	AfterServiceRepository    *AfterServiceRepository
+	ProductModelRepository    *ProductModelRepository
+	SalesChannelRepository    *SalesChannelRepository
+	ProductTransferRepository *ProductTransferRepository
+}
+
+var _ repository.Repository = (*Repository)(nil)
+
+// NewRepository 는 하위 repository fake 가 모두 설정된 Repository 를 생성한다.
+func NewRepository() *Repository {
+	return &Repository{
+		ProductRepository:         new(ProductRepository),
+		UserRepository:            new(UserRepository),
+		AfterServiceRepository:    new(AfterServiceRepository),
+		ProductModelRepository:    new(ProductModelRepository),
+		SalesChannelRepository:    new(SalesChannelRepository),
+		ProductTransferRepository: new(ProductTransferRepository),
+	}
+}
+
+func (r *Repository) Product() repository.ProductRepository {
+	return r.ProductRepository
+}
+
+func (r *Repository) User() repository.UserRepository {
+	return r.UserRepository
+}
+
+func (r *Repository) AfterService() repository.AfterServiceRepository {
+	return r.AfterServiceRepository
+}
+
+func (r *Repository) ProductModel() repository.ProductModelRepository {
+	return r.ProductModelRepository
+}
+
+func (r *Repository) SalesChannel() repository.SalesChannelRepository {
+	return r.SalesChannelRepository
+}
+
+func (r *Repository) ProductTransfer() repository.ProductTransferRepository {
+	return r.ProductTransferRepository
+}
+
+func notImplemented(method string) string {
+	return fmt.Sprintf("repositorytest: %s is not set", method)
+}
+
+// ProductRepository 는 repository.ProductRepository 의 fake. 호출할 메서드의 Func 필드를 설정하여 사용한다.
+type ProductRepository struct {
+	CreateFunc                          func(c context.Context, product *model.Product) error
+	CreateProductRegistFunc             func(c context.Context, productRegist *model.ProductRegist) error
+	CancelProductAuthFunc               func(c context.Context, productRegistSeq int64) error
+	TransferProductAuthFunc             func(c context.Context, productRegistSeq int64) error
+	ResubmitProductAuthFunc             func(c context.Context, productRegistSeq int64) error
+	ApproveProductAuthFunc              func(c context.Context, productRegistSeq int64) error
+	RejectProductAuthFunc               func(c context.Context, productRegistSeq int64, reason string) error
+	ModProductAuthFunc                  func(c context.Context, productRegist *model.ProductRegist) error
+	GetProductBySerialFunc              func(c context.Context, serial string, productType model.ProductType) (*model.Product, error)
+	FindProductsBySerialFunc            func(c context.Context, serial string) ([]*model.Product, error)
+	GetProductRegistByProductSeqFunc    func(c context.Context, productSeq int64) (*model.ProductRegist, error)
+	GetProductRegistBySeqFunc           func(c context.Context, productRegistSeq int64) (*model.ProductRegist, error)
+	FindProductManageInfoFunc           func(c context.Context, req model.ProductManageRequest) (model.ProductManageInfos, error)
+	FindProductAuthInfosFunc            func(c context.Context, req model.ProductAuthRequest) (model.ProductAuthInfos, error)
+	GetProductAuthInfoBySeqFunc         func(c context.Context, productRegistSeq int64) (*model.ProductAuthInfo, error)
+	FindReceiptLocationsFunc            func(c context.Context, locations []string) ([]string, error)
+	FindLegacyReceiptProductRegistsFunc func(c context.Context, keyPrefix string, afterSeq int64, limit int) ([]*model.ProductRegist, error)
+	UpdateReceiptLocationFunc           func(c context.Context, productRegistSeq int64, oldLocation, newLocation string) error
+	UpdateProductFunc                   func(c context.Context) error
+}
+
+var _ repository.ProductRepository = (*ProductRepository)(nil)
+
+func (p *ProductRepository) Create(c context.Context, product *model.Product) error {
+	if p.CreateFunc == nil {
+		panic(notImplemented("ProductRepository.Create"))
+	}
+	return p.CreateFunc(c, product)
+}
+
+func (p *ProductRepository) CreateProductRegist(c context.Context, productRegist *model.ProductRegist) error {
+	if p.CreateProductRegistFunc == nil {
+		panic(notImplemented("ProductRepository.CreateProductRegist"))
+	}
+	return p.CreateProductRegistFunc(c, productRegist)
+}
+
+func (p *ProductRepository) CancelProductAuth(c context.Context, productRegistSeq int64) error {
+	if p.CancelProductAuthFunc == nil {
+		panic(notImplemented("ProductRepository.CancelProductAuth"))
+	}
+	return p.CancelProductAuthFunc(c, productRegistSeq)
+}
+
+func (p *ProductRepository) TransferProductAuth(c context.Context, productRegistSeq int64) error {
+	if p.TransferProductAuthFunc == nil {
+		panic(notImplemented("ProductRepository.TransferProductAuth"))
+	}
+	return p.TransferProductAuthFunc(c, productRegistSeq)
+}
+
+func (p *ProductRepository) ResubmitProductAuth(c context.Context, productRegistSeq int64) error {
+	if p.ResubmitProductAuthFunc == nil {
+		panic(notImplemented("ProductRepository.ResubmitProductAuth"))
+	}
+	return p.ResubmitProductAuthFunc(c, productRegistSeq)
+}
+
+func (p *ProductRepository) ApproveProductAuth(c context.Context, productRegistSeq int64) error {
+	if p.ApproveProductAuthFunc == nil {
+		panic(notImplemented("ProductRepository.ApproveProductAuth"))
+	}
+	return p.ApproveProductAuthFunc(c, productRegistSeq)
+}
+
+func (p *ProductRepository) RejectProductAuth(c context.Context, productRegistSeq int64, reason string) error {
+	if p.RejectProductAuthFunc == nil {
+		panic(notImplemented("ProductRepository.RejectProductAuth"))
+	}
+	return p.RejectProductAuthFunc(c, productRegistSeq, reason)
+}
+
+func (p *ProductRepository) ModProductAuth(c context.Context, productRegist *model.ProductRegist) error {
+	if p.ModProductAuthFunc == nil {
+		panic(notImplemented("ProductRepository.ModProductAuth"))
+	}
+	return p.ModProductAuthFunc(c, productRegist)
+}
+
+func (p *ProductRepository) GetProductBySerial(c context.Context, serial string, productType model.ProductType) (*model.Product, error) {
+	if p.GetProductBySerialFunc == nil {
+		panic(notImplemented("ProductRepository.GetProductBySerial"))
+	}
+	return p.GetProductBySerialFunc(c, serial, productType)
+}
+
+func (p *ProductRepository) FindProductsBySerial(c context.Context, serial string) ([]*model.Product, error) {
+	if p.FindProductsBySerialFunc == nil {
+		panic(notImplemented("ProductRepository.FindProductsBySerial"))
+	}
+	return p.FindProductsBySerialFunc(c, serial)
+}
+
+func (p *ProductRepository) GetProductRegistByProductSeq(c context.Context, productSeq int64) (*model.ProductRegist, error) {
+	if p.GetProductRegistByProductSeqFunc == nil {
+		panic(notImplemented("ProductRepository.GetProductRegistByProductSeq"))
+	}
+	return p.GetProductRegistByProductSeqFunc(c, productSeq)
+}
+
+func (p *ProductRepository) GetProductRegistBySeq(c context.Context, productRegistSeq int64) (*model.ProductRegist, error) {
+	if p.GetProductRegistBySeqFunc == nil {
+		panic(notImplemented("ProductRepository.GetProductRegistBySeq"))
+	}
+	return p.GetProductRegistBySeqFunc(c, productRegistSeq)
+}
+
+func (p *ProductRepository) FindProductManageInfo(c context.Context, req model.ProductManageRequest) (model.ProductManageInfos, error) {
+	if p.FindProductManageInfoFunc == nil {
+		panic(notImplemented("ProductRepository.FindProductManageInfo"))
+	}
+	return p.FindProductManageInfoFunc(c, req)
+}
+
+func (p *ProductRepository) FindProductAuthInfos(c context.Context, req model.ProductAuthRequest) (model.ProductAuthInfos, error) {
+	if p.FindProductAuthInfosFunc == nil {
+		panic(notImplemented("ProductRepository.FindProductAuthInfos"))
+	}
+	return p.FindProductAuthInfosFunc(c, req)
+}
+
+func (p *ProductRepository) GetProductAuthInfoBySeq(c context.Context, productRegistSeq int64) (*model.ProductAuthInfo, error) {
+	if p.GetProductAuthInfoBySeqFunc == nil {
+		panic(notImplemented("ProductRepository.GetProductAuthInfoBySeq"))
+	}
+	return p.GetProductAuthInfoBySeqFunc(c, productRegistSeq)
+}
+
+func (p *ProductRepository) FindReceiptLocations(c context.Context, locations []string) ([]string, error) {
+	if p.FindReceiptLocationsFunc == nil {
+		panic(notImplemented("ProductRepository.FindReceiptLocations"))
+	}
+	return p.FindReceiptLocationsFunc(c, locations)
+}
+
+func (p *ProductRepository) FindLegacyReceiptProductRegists(c context.Context, keyPrefix string, afterSeq int64, limit int) ([]*model.ProductRegist, error) {
+	if p.FindLegacyReceiptProductRegistsFunc == nil {
+		panic(notImplemented("ProductRepository.FindLegacyReceiptProductRegists"))
+	}
+	return p.FindLegacyReceiptProductRegistsFunc(c, keyPrefix, afterSeq, limit)
+}
+
+func (p *ProductRepository) UpdateReceiptLocation(c context.Context, productRegistSeq int64, oldLocation, newLocation string) error {
+	if p.UpdateReceiptLocationFunc == nil {
+		panic(notImplemented("ProductRepository.UpdateReceiptLocation"))
+	}
+	return p.UpdateReceiptLocationFunc(c, productRegistSeq, oldLocation, newLocation)
+}
+
+func (p *ProductRepository) UpdateProduct(c context.Context) error {
+	if p.UpdateProductFunc == nil {
+		panic(notImplemented("ProductRepository.UpdateProduct"))
+	}
+	return p.UpdateProductFunc(c)
+}
+
+// UserRepository 는 repository.UserRepository 의 fake. 호출할 메서드의 Func 필드를 설정하여 사용한다.
+type UserRepository struct {
+	CreateFunc      func(c context.Context, user *model.User) error
+	GetUserByIDFunc func(c context.Context, id string) (*model.User, error)
+}
+
+var _ repository.UserRepository = (*UserRepository)(nil)
+
+func (u *UserRepository) Create(c context.Context, user *model.User) error {
+	if u.CreateFunc == nil {
+		panic(notImplemented("UserRepository.Create"))
+	}
+	return u.CreateFunc(c, user)
+}
+
+func (u *UserRepository) GetUserByID(c context.Context, id string) (*model.User, error) {
+	if u.GetUserByIDFunc == nil {
+		panic(notImplemented("UserRepository.GetUserByID"))
+	}
+	return u.GetUserByIDFunc(c, id)
+}
+
+// AfterServiceRepository 는 repository.AfterServiceRepository 의 fake. 호출할 메서드의 Func 필드를 설정하여 사용한다.
+type AfterServiceRepository struct {
+	CreateFunc                        func(c context.Context, as *model.AfterService) error
+	FindAfterServiceInfoFunc          func(c context.Context, req model.AfterServiceRequest) ([]*model.AfterService, error)
+	FindAfterServiceManagerInfoFunc   func(c context.Context, req model.AfterServiceRequest) ([]*model.AfterService, error)
+	GetAfterServiceBySeqFunc          func(c context.Context, afterServiceSeq int64) (*model.AfterService, error)
+	FindAfterServicesByProductSeqFunc func(c context.Context, productSeq int64) ([]*model.AfterService, error)
+	FindFileLocationsFunc             func(c context.Context, locations []string) ([]string, error)
+	FindLegacyFileAfterServicesFunc   func(c context.Context, keyPrefix string, afterSeq int64, limit int) ([]*model.AfterService, error)
+	UpdateFileLocationFunc            func(c context.Context, afterServiceSeq int64, fileIdx int, oldLocation, newLocation string) error
+}
+
+var _ repository.AfterServiceRepository = (*AfterServiceRepository)(nil)
+
+func (a *AfterServiceRepository) Create(c context.Context, as *model.AfterService) error {
+	if a.CreateFunc == nil {
+		panic(notImplemented("AfterServiceRepository.Create"))
+	}
+	return a.CreateFunc(c, as)
+}
+
+func (a *AfterServiceRepository) FindAfterServiceInfo(c context.Context, req model.AfterServiceRequest) ([]*model.AfterService, error) {
+	if a.FindAfterServiceInfoFunc == nil {
+		panic(notImplemented("AfterServiceRepository.FindAfterServiceInfo"))
+	}
+	return a.FindAfterServiceInfoFunc(c, req)
+}
+
+func (a *AfterServiceRepository) FindAfterServiceManagerInfo(c context.Context, req model.AfterServiceRequest) ([]*model.AfterService, error) {
+	if a.FindAfterServiceManagerInfoFunc == nil {
+		panic(notImplemented("AfterServiceRepository.FindAfterServiceManagerInfo"))
+	}
+	return a.FindAfterServiceManagerInfoFunc(c, req)
+}
+
+func (a *AfterServiceRepository) GetAfterServiceBySeq(c context.Context, afterServiceSeq int64) (*model.AfterService, error) {
+	if a.GetAfterServiceBySeqFunc == nil {
+		panic(notImplemented("AfterServiceRepository.GetAfterServiceBySeq"))
+	}
+	return a.GetAfterServiceBySeqFunc(c, afterServiceSeq)
+}
+
+func (a *AfterServiceRepository) FindAfterServicesByProductSeq(c context.Context, productSeq int64) ([]*model.AfterService, error) {
+	if a.FindAfterServicesByProductSeqFunc == nil {
+		panic(notImplemented("AfterServiceRepository.FindAfterServicesByProductSeq"))
+	}
+	return a.FindAfterServicesByProductSeqFunc(c, productSeq)
+}
+
+func (a *AfterServiceRepository) FindFileLocations(c context.Context, locations []string) ([]string, error) {
+	if a.FindFileLocationsFunc == nil {
+		panic(notImplemented("AfterServiceRepository.FindFileLocations"))
+	}
+	return a.FindFileLocationsFunc(c, locations)
+}
+
+func (a *AfterServiceRepository) FindLegacyFileAfterServices(c context.Context, keyPrefix string, afterSeq int64, limit int) ([]*model.AfterService, error) {
+	if a.FindLegacyFileAfterServicesFunc == nil {
+		panic(notImplemented("AfterServiceRepository.FindLegacyFileAfterServices"))
+	}
+	return a.FindLegacyFileAfterServicesFunc(c, keyPrefix, afterSeq, limit)
+}
+
+func (a *AfterServiceRepository) UpdateFileLocation(c context.Context, afterServiceSeq int64, fileIdx int, oldLocation, newLocation string) error {
+	if a.UpdateFileLocationFunc == nil {
+		panic(notImplemented("AfterServiceRepository.UpdateFileLocation"))
+	}
+	return a.UpdateFileLocationFunc(c, afterServiceSeq, fileIdx, oldLocation, newLocation)
+}
+
+// CatalogRepository 는 repository.CatalogRepository 의 fake. ProductModelRepository, SalesChannelRepository 에 포함된다.
+type CatalogRepository struct {
+	CreateFunc func(c context.Context, entry model.CatalogEntry) error
+	UpdateFunc func(c context.Context, entry model.CatalogEntry) error
+	DeleteFunc func(c context.Context, code int) error
+	ExistsFunc func(c context.Context, code int) (bool, error)
+	InUseFunc  func(c context.Context, code int) (bool, error)
+}
+
+var _ repository.CatalogRepository = (*CatalogRepository)(nil)
+
+func (r *CatalogRepository) Create(c context.Context, entry model.CatalogEntry) error {
+	if r.CreateFunc == nil {
+		panic(notImplemented("CatalogRepository.Create"))
+	}
+	return r.CreateFunc(c, entry)
+}
+
+func (r *CatalogRepository) Update(c context.Context, entry model.CatalogEntry) error {
+	if r.UpdateFunc == nil {
+		panic(notImplemented("CatalogRepository.Update"))
+	}
+	return r.UpdateFunc(c, entry)
+}
+
+func (r *CatalogRepository) Delete(c context.Context, code int) error {
+	if r.DeleteFunc == nil {
+		panic(notImplemented("CatalogRepository.Delete"))
+	}
+	return r.DeleteFunc(c, code)
+}
+
+func (r *CatalogRepository) Exists(c context.Context, code int) (bool, error) {
+	if r.ExistsFunc == nil {
+		panic(notImplemented("CatalogRepository.Exists"))
+	}
+	return r.ExistsFunc(c, code)
+}
+
+func (r *CatalogRepository) InUse(c context.Context, code int) (bool, error) {
+	if r.InUseFunc == nil {
+		panic(notImplemented("CatalogRepository.InUse"))
+	}
+	return r.InUseFunc(c, code)
+}
+
+// ProductModelRepository 는 repository.ProductModelRepository 의 fake. 호출할 메서드의 Func 필드를 설정하여 사용한다.
+type ProductModelRepository struct {
+	CatalogRepository
+	GetProductModelFunc   func(c context.Context, code model.ProductType) (*model.ProductModel, error)
+	FindProductModelsFunc func(c context.Context, activeOnly bool) (model.ProductModels, error)
+}
+
+var _ repository.ProductModelRepository = (*ProductModelRepository)(nil)
+
+func (p *ProductModelRepository) GetProductModel(c context.Context, code model.ProductType) (*model.ProductModel, error) {
+	if p.GetProductModelFunc == nil {
+		panic(notImplemented("ProductModelRepository.GetProductModel"))
+	}
+	return p.GetProductModelFunc(c, code)
+}
+
+func (p *ProductModelRepository) FindProductModels(c context.Context, activeOnly bool) (model.ProductModels, error) {
+	if p.FindProductModelsFunc == nil {
+		panic(notImplemented("ProductModelRepository.FindProductModels"))
+	}
+	return p.FindProductModelsFunc(c, activeOnly)
+}
+
+// SalesChannelRepository 는 repository.SalesChannelRepository 의 fake. 호출할 메서드의 Func 필드를 설정하여 사용한다.
+type SalesChannelRepository struct {
+	CatalogRepository
+	GetSalesChannelFunc   func(c context.Context, code model.MarketType) (*model.SalesChannel, error)
+	FindSalesChannelsFunc func(c context.Context, activeOnly bool) (model.SalesChannels, error)
+}
+
+var _ repository.SalesChannelRepository = (*SalesChannelRepository)(nil)
+
+func (s *SalesChannelRepository) GetSalesChannel(c context.Context, code model.MarketType) (*model.SalesChannel, error) {
+	if s.GetSalesChannelFunc == nil {
+		panic(notImplemented("SalesChannelRepository.GetSalesChannel"))
+	}
+	return s.GetSalesChannelFunc(c, code)
+}
+
+func (s *SalesChannelRepository) FindSalesChannels(c context.Context, activeOnly bool) (model.SalesChannels, error) {
+	if s.FindSalesChannelsFunc == nil {
+		panic(notImplemented("SalesChannelRepository.FindSalesChannels"))
+	}
+	return s.FindSalesChannelsFunc(c, activeOnly)
+}
+
+// ProductTransferRepository 는 repository.ProductTransferRepository 의 fake. 호출할 메서드의 Func 필드를 설정하여 사용한다.
+type ProductTransferRepository struct {
+	CreateFunc                 func(c context.Context, transfer *model.ProductTransfer) error
+	GetPendingTransferFunc     func(c context.Context, productSeq int64, transferCode string) (*model.ProductTransfer, error)
+	CancelPendingTransfersFunc func(c context.Context, fromProductRegistSeq int64) error
+	CompleteFunc               func(c context.Context, productTransferSeq, toProductRegistSeq int64) error
+}
+
+var _ repository.ProductTransferRepository = (*ProductTransferRepository)(nil)
+
+func (t *ProductTransferRepository) Create(c context.Context, transfer *model.ProductTransfer) error {
+	if t.CreateFunc == nil {
+		panic(notImplemented("ProductTransferRepository.Create"))
+	}
+	return t.CreateFunc(c, transfer)
+}
+
+func (t *ProductTransferRepository) GetPendingTransfer(c context.Context, productSeq int64, transferCode string) (*model.ProductTransfer, error) {
+	if t.GetPendingTransferFunc == nil {
+		panic(notImplemented("ProductTransferRepository.GetPendingTransfer"))
+	}
+	return t.GetPendingTransferFunc(c, productSeq, transferCode)
+}
+
+func (t *ProductTransferRepository) CancelPendingTransfers(c context.Context, fromProductRegistSeq int64) error {
+	if t.CancelPendingTransfersFunc == nil {
+		panic(notImplemented("ProductTransferRepository.CancelPendingTransfers"))
+	}
+	return t.CancelPendingTransfersFunc(c, fromProductRegistSeq)
+}
+
+func (t *ProductTransferRepository) Complete(c context.Context, productTransferSeq, toProductRegistSeq int64) error {
+	if t.CompleteFunc == nil {
+		panic(notImplemented("ProductTransferRepository.Complete"))
+	}
+	return t.CompleteFunc(c, productTransferSeq, toProductRegistSeq)
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"buddle-server/model"
+	"context"
+)
+
+type SalesChannelRepository interface {
+	CatalogRepository
+	GetSalesChannel(c context.Context, code model.MarketType) (*model.SalesChannel, error)
+	FindSalesChannels(c context.Context, activeOnly bool) (model.SalesChannels, error)
+}
+
+type salesChannelRepository struct {
+	catalogRepository
+}
+
+// NewSalesChannelRepository 는 판매 채널 repository 를 생성한다. 제품 인증, A/S 신청이 판매 채널을 참조한다.
+func NewSalesChannelRepository() SalesChannelRepository {
+	return &salesChannelRepository{catalogRepository{
+		name:      "sales channel",
+		table:     model.SalesChannel{}.TableName(),
+		refColumn: "market_type",
+		refTables: []interface{}{&model.ProductRegist{}, &model.AfterService{}},
+	}}
+}
+
+func (r salesChannelRepository) GetSalesChannel(c context.Context, code model.MarketType) (*model.SalesChannel, error) {
+	salesChannel := new(model.SalesChannel)
+	if err := r.get(c, int(code), salesChannel); err != nil {
+		return nil, err
+	}
+
+	return salesChannel, nil
+}
+
+func (r salesChannelRepository) FindSalesChannels(c context.Context, activeOnly bool) (model.SalesChannels, error) {
+	result := make(model.SalesChannels, 0)
+	if err := r.find(c, activeOnly, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
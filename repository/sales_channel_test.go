@@ -0,0 +1,50 @@
+package repository_test
+
+import (
+	"buddle-server/model"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestSalesChannelRepository(t *testing.T) {
+	c, repo := newTestRepository(t)
+
+	// 마이그레이션으로 기존 MarketType 이 등록되어 있다.
+	seeded, err := repo.SalesChannel().FindSalesChannels(c, true)
+	if err != nil {
+		t.Fatalf("FindSalesChannels() error = %+v", err)
+	}
+	if len(seeded) != 4 || seeded[1].Code != model.MarketTypeCoupang || seeded[1].NameKo != "쿠팡" || seeded[1].NameEn != "Coupang" {
+		t.Fatalf("FindSalesChannels() = %+v", seeded)
+	}
+
+	salesChannel := &model.SalesChannel{Code: 10, NameKo: "자사몰", NameEn: "Official store"}
+	if err := repo.SalesChannel().Create(c, salesChannel); err != nil {
+		t.Fatalf("Create() error = %+v", err)
+	}
+
+	salesChannel.OrderNoRequired = true
+	if err := repo.SalesChannel().Update(c, salesChannel); err != nil {
+		t.Fatalf("Update() error = %+v", err)
+	}
+	got, err := repo.SalesChannel().GetSalesChannel(c, 10)
+	if err != nil {
+		t.Fatalf("GetSalesChannel() error = %+v", err)
+	}
+	if !got.OrderNoRequired || got.Active || got.NameEn != "Official store" {
+		t.Errorf("GetSalesChannel() = %+v", got)
+	}
+	if _, err := repo.SalesChannel().GetSalesChannel(c, 11); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetSalesChannel() unknown code error = %v", err)
+	}
+
+	// 판매 중지 채널은 판매 중 목록에서 제외된다.
+	if active, _ := repo.SalesChannel().FindSalesChannels(c, true); len(active) != 4 {
+		t.Errorf("FindSalesChannels(active) = %d channels, want 4", len(active))
+	}
+	if all, _ := repo.SalesChannel().FindSalesChannels(c, false); len(all) != 5 {
+		t.Errorf("FindSalesChannels(all) = %d channels, want 5", len(all))
+	}
+}
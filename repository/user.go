@@ -37,7 +37,7 @@ func (u userRepository) Create(c context.Context, user *model.User) error {
 	toDay := time.Now()
 	user.Regdate = toDay
 
-	if err := conn.Create(&user).Error; err != nil {
+	if err := translateError(conn.Create(&user).Error); err != nil {
 		return errors.Wrapf(err, "failed to create user [ user = %+v ]", user)
 	}
 
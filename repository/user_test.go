@@ -0,0 +1,41 @@
+package repository_test
+
+import (
+	"buddle-server/model"
+	"buddle-server/repository"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestUserRepository(t *testing.T) {
+	c, repo := newTestRepository(t)
+
+	user := &model.User{Id: "admin", Password: "hashed", UserType: model.UserTypeAdmin, Name: "관리자"}
+	if err := repo.User().Create(c, user); err != nil {
+		t.Fatalf("Create() error = %+v", err)
+	}
+	if user.UserSeq == 0 || user.Regdate.IsZero() {
+		t.Errorf("Create() user = %+v", user)
+	}
+
+	got, err := repo.User().GetUserByID(c, "admin")
+	if err != nil {
+		t.Fatalf("GetUserByID() error = %+v", err)
+	}
+	if got.UserSeq != user.UserSeq || got.Password != "hashed" || got.Name != "관리자" {
+		t.Errorf("GetUserByID() = %+v", got)
+	}
+
+	if _, err := repo.User().GetUserByID(c, "unknown"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("GetUserByID() unknown id error = %v, want ErrRecordNotFound", err)
+	}
+	if _, err := repo.User().GetUserByID(c, ""); err == nil {
+		t.Errorf("GetUserByID(\"\") error = nil")
+	}
+
+	if err := repo.User().Create(c, &model.User{Id: "admin", Password: "other"}); !errors.Is(err, repository.ErrDuplicateKey) {
+		t.Errorf("Create() duplicated id error = %v, want ErrDuplicateKey", err)
+	}
+}
@@ -5,135 +5,155 @@ import (
 	"buddle-server/model"
 	"buddle-server/repository"
 	"context"
-	"fmt"
 	"github.com/pkg/errors"
-	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
-	"io"
 	"os"
 	"time"
 )
 
 type AfterService interface {
-	Create(c context.Context, as *model.AfterService, file1 io.Reader, file2 io.Reader, file3 io.Reader, file4 io.Reader, file5 io.Reader) (*model.Response, error)
+	Create(c context.Context, as *model.AfterService, files []*UploadFile) (*model.Response, error)
 	FindAfterServiceInfo(c context.Context, req model.AfterServiceRequest) (*model.Response, error)
 	FindAfterServiceManagerInfo(c context.Context, req model.AfterServiceRequest) (*model.Response, error)
+	FindProductAfterServices(c context.Context, productSeq int64) (*model.Response, error)
 	DownloadFile(c context.Context, afterServiceSeq, fileIdx int64, file *os.File) (*model.AfterService, error)
 }
 
 type afterService struct {
 	repo       repository.Repository
-	fileBucket *s3.S3
+	fileBucket s3.Storage
 }
 
-func NewAfterService(repo repository.Repository, fileBucket *s3.S3) (AfterService, error) {
+func NewAfterService(repo repository.Repository, fileBucket s3.Storage) (AfterService, error) {
 	if repo == nil {
 		return nil, errors.New("repository is nil")
 	}
 	return &afterService{repo: repo, fileBucket: fileBucket}, nil
 }
 
-func (s afterService) Create(c context.Context, as *model.AfterService, file1 io.Reader, file2 io.Reader, file3 io.Reader, file4 io.Reader, file5 io.Reader) (*model.Response, error) {
+func (s afterService) Create(c context.Context, as *model.AfterService, files []*UploadFile) (*model.Response, error) {
 	switch {
 	case c == nil:
-		return model.SimpleFail(), errors.New("nil context")
+		return nil, errors.New("nil context")
 	case as == nil:
-		return model.SimpleFail(), errors.New("nil request params")
+		return nil, errors.New("nil request params")
 	case s.fileBucket == nil:
-		return model.SimpleFail(), errors.New("s3 file bucket is nil")
+		return nil, errors.New("s3 file bucket is nil")
+	case len(files) > model.AfterServiceMaxFiles:
+		return nil, model.NewError(model.ResponseErrorCodeTooManyFiles)
 	}
 
-	if file1 != nil {
-		// s3 업로드
-		s3location := fmt.Sprintf("after-service/%s/%s/file1", time.Now().Format("2006-01-02"), as.Phone)
-		if err := s.fileBucket.Upload(c, s3location, file1); err != nil {
-			logrus.Errorf("failed to upload s3 object: objectKey=%s, bucketName=%s err:%+v", s3location, s.fileBucket.BucketName(), err)
+	// 시리얼 번호를 입력한 경우 인증된 제품에 연결하고 구매 정보는 제품 인증 정보를 사용한다.
+	if as.SerialNo != "" {
+		if err := s.linkProductRegist(c, as); err != nil {
+			return nil, err
 		}
-		as.File1S3Location = s3location
+	} else if err := validateMarketType(c, s.repo, as.MarketType, as.OrderNo); err != nil {
+		return nil, err
 	}
 
-	if file2 != nil {
-		// s3 업로드
-		s3location := fmt.Sprintf("after-service/%s/%s/file2", time.Now().Format("2006-01-02"), as.Phone)
-		if err := s.fileBucket.Upload(c, s3location, file2); err != nil {
-			logrus.Errorf("failed to upload s3 object: objectKey=%s, bucketName=%s err:%+v", s3location, s.fileBucket.BucketName(), err)
-		}
-		as.File2S3Location = s3location
+	productModel, err := validateProductType(c, s.repo, "product_type", as.ProductType, false)
+	if err != nil {
+		return nil, err
 	}
 
-	if file3 != nil {
-		// s3 업로드
-		s3location := fmt.Sprintf("after-service/%s/%s/file3", time.Now().Format("2006-01-02"), as.Phone)
-		if err := s.fileBucket.Upload(c, s3location, file3); err != nil {
-			logrus.Errorf("failed to upload s3 object: objectKey=%s, bucketName=%s err:%+v", s3location, s.fileBucket.BucketName(), err)
-		}
-		as.File3S3Location = s3location
-	}
+	// 신청 시점 기준 무상 보증 여부 ( 신청서의 구매일, 제품 모델의 보증 기간 )
+	as.WarrantyStatus = model.NewWarranty(as.PurchaseDate, productModel.WarrantyMonths, time.Now()).Status
 
-	if file4 != nil {
-		// s3 업로드
-		s3location := fmt.Sprintf("after-service/%s/%s/file4", time.Now().Format("2006-01-02"), as.Phone)
-		if err := s.fileBucket.Upload(c, s3location, file4); err != nil {
-			logrus.Errorf("failed to upload s3 object: objectKey=%s, bucketName=%s err:%+v", s3location, s.fileBucket.BucketName(), err)
+	locations, checksums := as.FileS3Locations(), as.FileSha256s()
+	uploaded := make([]string, 0, len(files))
+
+	for i, file := range files {
+		if file == nil {
+			continue
 		}
-		as.File4S3Location = s3location
-	}
 
-	if file5 != nil {
 		// s3 업로드
-		s3location := fmt.Sprintf("after-service/%s/%s/file5", time.Now().Format("2006-01-02"), as.Phone)
-		if err := s.fileBucket.Upload(c, s3location, file5); err != nil {
-			logrus.Errorf("failed to upload s3 object: objectKey=%s, bucketName=%s err:%+v", s3location, s.fileBucket.BucketName(), err)
+		s3location, checksum, err := uploadFile(c, s.fileBucket, AfterServiceFileKeyPrefix, file)
+		if err != nil {
+			removeUploadedFiles(s.fileBucket, uploaded...)
+			return nil, model.NewError(model.ResponseErrorCodeUploadFailed).WithCause(errors.Wrapf(err, "failed to upload after service file%d", i+1))
 		}
-		as.File5S3Location = s3location
+		*locations[i] = s3location
+		*checksums[i] = checksum
+		uploaded = append(uploaded, s3location)
 	}
 
 	if err := s.repo.AfterService().Create(c, as); err != nil {
-		return nil, errors.Wrap(err, "failed to create product regist")
+		removeUploadedFiles(s.fileBucket, uploaded...)
+		return nil, errors.Wrap(err, "failed to create after service")
 	}
 
 	return model.SimpleSuccess(), nil
 }
 
+// linkProductRegist 는 시리얼 번호의 제품 인증 정보 중 신청자의 이름, 연락처와 일치하는 인증 정보를 A/S 신청에 연결한다.
+func (s afterService) linkProductRegist(c context.Context, as *model.AfterService) error {
+	product, err := s.repo.Product().GetProductBySerial(c, as.SerialNo, as.ProductType)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.NewError(model.ResponseErrorCodeProductNotExist).WithCause(err)
+		}
+		return errors.Wrapf(err, "failed to get product by serial(%s)", as.SerialNo)
+	}
+
+	productRegist, err := s.repo.Product().GetProductRegistByProductSeq(c, product.ProductSeq)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.NewError(model.ResponseErrorCodeProductAuthNotMatched).WithCause(err)
+		}
+		return errors.Wrapf(err, "failed to get product regist by product seq(%d)", product.ProductSeq)
+	}
+	// 다른 사람이 인증한 제품의 정보는 사용할 수 없다.
+	if !(model.ProductAuthRequest{Name: as.Name, Phone: as.Phone}).Matches(productRegist.Name, productRegist.Phone) {
+		return model.NewError(model.ResponseErrorCodeProductAuthNotMatched)
+	}
+	// 인증 완료된 정보만 사용한다. 영수증 확인이 끝나지 않은 인증 정보는 사용할 수 없다.
+	switch productRegist.Status {
+	case model.ProductAuthStatusOK:
+	case model.ProductAuthStatusPending:
+		return model.NewError(model.ResponseErrorCodeProductRegistPending)
+	default:
+		return model.NewError(model.ResponseErrorCodeProductAuthNotMatched)
+	}
+
+	as.LinkProductRegist(product, productRegist)
+
+	return nil
+}
+
 func (s afterService) DownloadFile(c context.Context, afterServiceSeq, fileIdx int64, file *os.File) (*model.AfterService, error) {
 	switch {
 	case c == nil:
 		return nil, errors.New("nil context")
-	case afterServiceSeq == 0:
-		return nil, errors.New("invalid sequence")
 	case file == nil:
 		return nil, errors.New("file is nil")
 	case s.fileBucket == nil:
 		return nil, errors.New("s3 file bucket is nil")
+	case afterServiceSeq <= 0:
+		return nil, model.NewError(model.ResponseErrorCodeInvalidRequest)
+	case fileIdx < 1 || fileIdx > model.AfterServiceMaxFiles:
+		return nil, model.NewError(model.ResponseErrorCodeInvalidFileIndex)
 	}
 
 	asInfo, err := s.repo.AfterService().GetAfterServiceBySeq(c, afterServiceSeq)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.NewError(model.ResponseErrorCodeAfterServiceNotExist).WithCause(err)
+		}
 		return nil, errors.Wrapf(err, "failed to get after service info by seq(%d)", afterServiceSeq)
 	}
 
-	var s3Location string
-	switch fileIdx {
-	case 1:
-		s3Location = asInfo.File1S3Location
-	case 2:
-		s3Location = asInfo.File2S3Location
-	case 3:
-		s3Location = asInfo.File3S3Location
-	case 4:
-		s3Location = asInfo.File4S3Location
-	case 5:
-		s3Location = asInfo.File5S3Location
-	default:
-		return nil, errors.Wrap(err, "failed to download file")
-	}
-
+	s3Location, checksum := *asInfo.FileS3Locations()[fileIdx-1], *asInfo.FileSha256s()[fileIdx-1]
 	if s3Location == "" {
-		return nil, nil // 다운로드 받을 파일이 없음.
+		return nil, model.NewError(model.ResponseErrorCodeFileNotFound)
 	}
 
-	if _, err := s.fileBucket.Download(c, file, s3Location); err != nil {
-		return nil, errors.Wrapf(err, "failed to download file [ s3 location : %+v ]", s3Location)
+	if _, err := s.fileBucket.Download(c, file, s3Location, s3.VerifyChecksum(checksum)); err != nil {
+		if errors.Is(err, s3.ErrObjectNotFound) {
+			return nil, model.NewError(model.ResponseErrorCodeFileNotFound).WithCause(err)
+		}
+		return nil, model.NewError(model.ResponseErrorCodeDownloadFailed).WithCause(errors.Wrapf(err, "failed to download file [ s3 location : %+v ]", s3Location))
 	}
 
 	return asInfo, nil
@@ -146,20 +166,13 @@ func (s afterService) FindAfterServiceInfo(c context.Context, req model.AfterSer
 
 	data, err := s.repo.AfterService().FindAfterServiceInfo(c, req)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return &model.Response{
-				Success: false,
-				Message: "일치하는 데이터가 없습니다.",
-			}, nil
-		}
 		return nil, errors.Wrap(err, "failed to get after service info")
 	}
+	if len(data) == 0 {
+		return nil, model.NewError(model.ResponseErrorCodeAfterServiceNotMatched)
+	}
 
-	return &model.Response{
-		Success: true,
-		Message: "성공하였습니다.",
-		Data:    data,
-	}, nil
+	return model.NewSuccess(model.ResponseMessageSuccess, model.AfterServices(data)), nil
 }
 
 func (s afterService) FindAfterServiceManagerInfo(c context.Context, req model.AfterServiceRequest) (*model.Response, error) {
@@ -169,18 +182,25 @@ func (s afterService) FindAfterServiceManagerInfo(c context.Context, req model.A
 
 	data, err := s.repo.AfterService().FindAfterServiceManagerInfo(c, req)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return &model.Response{
-				Success: false,
-				Message: "일치하는 데이터가 없습니다.",
-			}, nil
-		}
 		return nil, errors.Wrap(err, "failed to get after service manager info")
 	}
 
-	return &model.Response{
-		Success: true,
-		Message: "성공하였습니다.",
-		Data:    data,
-	}, nil
+	return model.NewSuccess(model.ResponseMessageSuccess, model.AfterServices(data)), nil
+}
+
+// FindProductAfterServices 는 제품의 모든 A/S 신청 이력을 조회한다. ( 관리자용 )
+func (s afterService) FindProductAfterServices(c context.Context, productSeq int64) (*model.Response, error) {
+	switch {
+	case c == nil:
+		return nil, errors.New("nil context")
+	case productSeq <= 0:
+		return nil, model.NewError(model.ResponseErrorCodeInvalidRequest)
+	}
+
+	data, err := s.repo.AfterService().FindAfterServicesByProductSeq(c, productSeq)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find after services of product(%d)", productSeq)
+	}
+
+	return model.NewSuccess(model.ResponseMessageSuccess, model.AfterServices(data)), nil
 }
@@ -0,0 +1,52 @@
+package service_test
+
+import (
+	"buddle-server/internal/s3/s3test"
+	"buddle-server/model"
+	"buddle-server/repository/repositorytest"
+	"buddle-server/service"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestAfterService_CreateWithUnapprovedRegist(t *testing.T) {
+	tests := []struct {
+		status model.ProductAuthStatus
+		want   model.ResponseErrorCode
+	}{
+		{status: model.ProductAuthStatusPending, want: model.ResponseErrorCodeProductRegistPending},
+		{status: model.ProductAuthStatusRejected, want: model.ResponseErrorCodeProductAuthNotMatched},
+		{status: model.ProductAuthStatusCancel, want: model.ResponseErrorCodeProductAuthNotMatched},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("status %d", tt.status), func(t *testing.T) {
+			// 인증 정보를 연결하지 못하면 A/S 신청을 저장하지 않는다. ( AfterServiceRepository fake 미설정 )
+			repo := repositorytest.NewRepository()
+			repo.ProductRepository.GetProductBySerialFunc = func(c context.Context, serial string, productType model.ProductType) (*model.Product, error) {
+				return &model.Product{ProductSeq: 1, SerialNo: serial, ProductType: productType}, nil
+			}
+			repo.ProductRepository.GetProductRegistByProductSeqFunc = func(c context.Context, productSeq int64) (*model.ProductRegist, error) {
+				return &model.ProductRegist{ProductRegistSeq: 1, ProductSeq: productSeq, Name: "홍길동", Phone: "01012345678", Status: tt.status}, nil
+			}
+
+			afterService, err := service.NewAfterService(repo, s3test.NewMemory())
+			if err != nil {
+				t.Fatalf("failed to create after service: %+v", err)
+			}
+
+			as := &model.AfterService{Name: "홍길동", Phone: "010-1234-5678", SerialNo: "SN-001"}
+			_, err = afterService.Create(context.Background(), as, nil)
+			var domainErr *model.Error
+			if !errors.As(err, &domainErr) || domainErr.ErrorCode != tt.want {
+				t.Fatalf("err = %v, want %s", err, tt.want)
+			}
+			if as.ProductRegistSeq != 0 {
+				t.Errorf("linked product regist = %d", as.ProductRegistSeq)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"buddle-server/model"
+	"buddle-server/repository"
+	"context"
+	"github.com/pkg/errors"
+)
+
+// CatalogService 는 카탈로그( 제품 모델, 판매 채널 )의 공통 등록, 수정, 삭제.
+type CatalogService interface {
+	Create(c context.Context, entry model.CatalogEntry) error
+	Update(c context.Context, entry model.CatalogEntry) error
+	Delete(c context.Context, code int) error
+}
+
+// catalogErrors 는 카탈로그마다 다른 응답 에러 코드
+type catalogErrors struct {
+	dupl     model.ResponseErrorCode // 이미 등록된 code
+	notExist model.ResponseErrorCode // 등록되지 않은 code
+	inUse    model.ResponseErrorCode // 다른 테이블이 참조하는 code
+}
+
+type catalogService struct {
+	name    string // 에러 메시지에 사용하는 이름 ( product model )
+	catalog repository.CatalogRepository
+	errs    catalogErrors
+}
+
+func (s catalogService) Create(c context.Context, entry model.CatalogEntry) error {
+	switch {
+	case c == nil:
+		return errors.New("nil context")
+	case entry == nil:
+		return errors.Errorf("nil %s", s.name)
+	}
+
+	if err := s.catalog.Create(c, entry); err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return model.NewError(s.errs.dupl).WithCause(err)
+		}
+		return errors.Wrapf(err, "failed to create %s", s.name)
+	}
+
+	return nil
+}
+
+func (s catalogService) Update(c context.Context, entry model.CatalogEntry) error {
+	switch {
+	case c == nil:
+		return errors.New("nil context")
+	case entry == nil:
+		return errors.Errorf("nil %s", s.name)
+	}
+
+	if err := s.checkExists(c, entry.CatalogCode()); err != nil {
+		return err
+	}
+
+	return s.catalog.Update(c, entry)
+}
+
+// Delete 는 카탈로그 행을 삭제한다. 다른 테이블이 참조하는 행은 판매 중지( active )로만 변경할 수 있다.
+func (s catalogService) Delete(c context.Context, code int) error {
+	if c == nil {
+		return errors.New("nil context")
+	}
+
+	if err := s.checkExists(c, code); err != nil {
+		return err
+	}
+
+	inUse, err := s.catalog.InUse(c, code)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check %s(%d) references", s.name, code)
+	}
+	if inUse {
+		return model.NewError(s.errs.inUse)
+	}
+
+	return s.catalog.Delete(c, code)
+}
+
+func (s catalogService) checkExists(c context.Context, code int) error {
+	exists, err := s.catalog.Exists(c, code)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get %s(%d)", s.name, code)
+	}
+	if !exists {
+		return model.NewError(s.errs.notExist)
+	}
+
+	return nil
+}
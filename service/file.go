@@ -0,0 +1,61 @@
+package service
+
+import (
+	"buddle-server/internal/s3"
+	"context"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"io"
+	"time"
+)
+
+// 파일 종류별 객체 키 prefix
+const (
+	ReceiptKeyPrefix          = "receipt"
+	AfterServiceFileKeyPrefix = "after-service-file"
+)
+
+// UploadFile 은 사용자가 업로드한 파일과 원본 파일명
+type UploadFile struct {
+	Filename string
+	Body     io.Reader
+}
+
+// uploadFile 은 prefix 하위의 임의의 키로 파일을 업로드하고 객체 키와 SHA-256 을 반환한다.
+// 원본 파일명은 객체 메타데이터로 보관한다.
+func uploadFile(c context.Context, fileBucket s3.Storage, prefix string, file *UploadFile) (key string, checksum string, err error) {
+	switch {
+	case fileBucket == nil:
+		return "", "", errors.New("s3 file bucket is nil")
+	case file == nil || file.Body == nil:
+		return "", "", errors.New("nil upload file")
+	}
+
+	if key, err = s3.NewObjectKey(prefix); err != nil {
+		return "", "", errors.Wrap(err, "failed to create object key")
+	}
+
+	if checksum, err = fileBucket.Upload(c, key, file.Body, s3.SetMetadata(map[string]string{s3.MetadataOriginalFilename: file.Filename})); err != nil {
+		return "", "", errors.Wrapf(err, "failed to upload file [ objectKey = %s, bucketName = %s ]", key, fileBucket.BucketName())
+	}
+
+	return key, checksum, nil
+}
+
+// removeUploadedFiles 는 DB 저장에 실패한 요청의 업로드 파일을 삭제한다.
+// 요청 context 가 이미 취소되었을 수 있으므로 별도의 context 를 사용하며,
+// 삭제에 실패한 객체는 로그로 남겨 정리 대상이 되도록 한다.
+func removeUploadedFiles(fileBucket s3.Storage, keys ...string) {
+	if fileBucket == nil {
+		return
+	}
+
+	c, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	for _, key := range keys {
+		if err := fileBucket.Delete(c, key); err != nil {
+			logrus.Errorf("failed to delete orphaned s3 object: objectKey=%s, bucketName=%s err:%+v", key, fileBucket.BucketName(), err)
+		}
+	}
+}
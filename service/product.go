@@ -1,12 +1,15 @@
 package service
 
 import (
+	"buddle-server/internal/db"
+	"buddle-server/internal/i18n"
+	"buddle-server/internal/ocr"
 	"buddle-server/internal/s3"
 	"buddle-server/model"
 	"buddle-server/repository"
+	"bytes"
 	"context"
 	"encoding/csv"
-	"fmt"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -14,30 +17,38 @@ import (
 	"os"
 	"strconv"
 	"strings"
-	"time"
 )
 
 type ProductService interface {
 	CreateProduct(c context.Context, csvReader *csv.Reader) (int64, int64, error)
-	AuthProduct(c context.Context, productRegist *model.ProductRegist, file io.Reader) (*model.Response, error)
-	ModAuthProduct(c context.Context, productRegist *model.ProductRegist) error
-	CancelAuthProduct(c context.Context, productRegistSeq int64) error
+	AuthProduct(c context.Context, productRegist *model.ProductRegist, receipt *UploadFile) (*model.Response, error)
+	ModAuthProduct(c context.Context, req model.ProductAuthRequest, productRegist *model.ProductRegist) error
+	CancelAuthProduct(c context.Context, req model.ProductAuthRequest, productRegistSeq int64) error
 	GetAuthProductInfo(c context.Context, req model.ProductAuthRequest) (*model.Response, error)
+	GetAuthProductInfoBySeq(c context.Context, req model.ProductAuthRequest, productRegistSeq int64) (*model.Response, error)
 	DownloadReceipt(c context.Context, productRegistSeq int64, file *os.File) (*model.ProductRegist, error)
 	FindProductManageInfo(c context.Context, req model.ProductManageRequest) (model.ProductManageInfos, error)
+	FindProductReviews(c context.Context, req model.ProductManageRequest) (model.ProductManageInfos, error)
+	ApproveProductAuth(c context.Context, productRegistSeq int64) error
+	RejectProductAuth(c context.Context, productRegistSeq int64, req model.ProductRejectRequest) error
+	StartTransfer(c context.Context, req model.ProductAuthRequest, productRegistSeq int64) (*model.Response, error)
+	StartTransferByAdmin(c context.Context, productRegistSeq int64) (*model.Response, error)
+	ClaimTransfer(c context.Context, claim *model.ProductTransferClaim) (*model.Response, error)
+	CheckSerial(c context.Context, serial string, productType *model.ProductType) (*model.Response, error)
 }
 
 type productService struct {
 	repo       repository.Repository
-	fileBucket *s3.S3
+	fileBucket s3.Storage
+	receiptOCR ocr.Provider // 영수증 OCR, nil 이면 사용하지 않음
 }
 
-func NewProductService(repo repository.Repository, fileBucket *s3.S3) (ProductService, error) {
+func NewProductService(repo repository.Repository, fileBucket s3.Storage, receiptOCR ocr.Provider) (ProductService, error) {
 	if repo == nil {
 		return nil, errors.New("repository is nil")
 	}
 
-	return &productService{repo: repo, fileBucket: fileBucket}, nil
+	return &productService{repo: repo, fileBucket: fileBucket, receiptOCR: receiptOCR}, nil
 }
 
 func (s productService) CreateProduct(c context.Context, csvReader *csv.Reader) (success int64, failure int64, err error) {
@@ -50,10 +61,26 @@ func (s productService) CreateProduct(c context.Context, csvReader *csv.Reader)
 
 	rows, err := csvReader.ReadAll()
 	if err != nil {
-		return 0, 0, errors.Wrap(err, "failed to read upload csv file")
+		return 0, 0, model.NewError(model.ResponseErrorCodeInvalidCSV).WithCause(errors.Wrap(err, "failed to read upload csv file"))
+	}
+
+	// 판매 중인 제품 모델의 시리얼만 등록한다.
+	productModels, err := s.repo.ProductModel().FindProductModels(c, true)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to find active product models")
+	}
+	activeModels := make(map[model.ProductType]*model.ProductModel, len(productModels))
+	for _, productModel := range productModels {
+		activeModels[productModel.Code] = productModel
 	}
 
 	for i, row := range rows {
+		if len(row) < 2 {
+			logrus.Errorf("invalid csv row [ row = %+v ]", row)
+			failure++
+			continue
+		}
+
 		for j := range row {
 			// 모든공백제거
 			rows[i][j] = strings.ReplaceAll(rows[i][j], " ", "")
@@ -71,7 +98,24 @@ func (s productService) CreateProduct(c context.Context, csvReader *csv.Reader)
 			ProductType: model.ProductType(productType),
 		}
 
+		productModel, ok := activeModels[product.ProductType]
+		if !ok {
+			logrus.Warnf("unknown or inactive product model [ serial_no = %s, product_type = %d ]", product.SerialNo, product.ProductType)
+			failure++
+			continue
+		}
+		if reason := productModel.SerialRule().Check(product.SerialNo); reason != nil {
+			logrus.Warnf("invalid serial format [ serial_no = %s, product_type = %d, reason = %s ]", product.SerialNo, product.ProductType, reason.In(i18n.DefaultLanguage))
+			failure++
+			continue
+		}
+
 		if err := s.repo.Product().Create(c, product); err != nil {
+			if errors.Is(err, repository.ErrDuplicateKey) {
+				logrus.Warnf("duplicated product [ serial_no = %s, product_type = %d ]", product.SerialNo, product.ProductType)
+				failure++
+				continue
+			}
 			logrus.Errorf("failed to create product info [ err = %+v ]", err)
 			failure++
 			continue
@@ -83,70 +127,126 @@ func (s productService) CreateProduct(c context.Context, csvReader *csv.Reader)
 	return
 }
 
-func (s productService) CancelAuthProduct(c context.Context, productRegistSeq int64) error {
+// CancelAuthProduct 는 req 고객의 제품 인증을 취소한다.
+func (s productService) CancelAuthProduct(c context.Context, req model.ProductAuthRequest, productRegistSeq int64) error {
 	if c == nil {
 		return errors.New("nil context")
 	}
 
-	return s.repo.Product().CancelProductAuth(c, productRegistSeq)
+	productRegist, err := s.getOwnedProductRegist(c, req, productRegistSeq)
+	if err != nil {
+		return err
+	}
+	// 인증 완료, 인증 대기 상태만 취소할 수 있다.
+	if !productRegist.Status.Active() {
+		return model.NewError(model.ResponseErrorCodeInvalidStatusTransition)
+	}
+
+	// 취소된 인증 정보의 이전 코드는 사용할 수 없다.
+	return db.Transaction(c, func(c context.Context) error {
+		if err := s.repo.ProductTransfer().CancelPendingTransfers(c, productRegistSeq); err != nil {
+			return err
+		}
+		return s.repo.Product().CancelProductAuth(c, productRegistSeq)
+	})
+}
+
+// getOwnedProductRegist 는 req 고객( 이름, 휴대폰 번호 )의 제품 인증 정보를 조회한다.
+// 다른 고객의 인증 정보이면 model.ErrNotFound 에러를 반환한다.
+func (s productService) getOwnedProductRegist(c context.Context, req model.ProductAuthRequest, productRegistSeq int64) (*model.ProductRegist, error) {
+	productRegist, err := s.getProductRegist(c, productRegistSeq)
+	if err != nil {
+		return nil, err
+	}
+	if !req.Matches(productRegist.Name, productRegist.Phone) {
+		return nil, model.NewError(model.ResponseErrorCodeProductAuthNotMatched)
+	}
+
+	return productRegist, nil
 }
 
-func (s productService) AuthProduct(c context.Context, productRegist *model.ProductRegist, file io.Reader) (*model.Response, error) {
+// getProductRegist 는 제품 인증 정보를 조회한다. 존재하지 않으면 model.ErrNotFound 에러를 반환한다.
+func (s productService) getProductRegist(c context.Context, productRegistSeq int64) (*model.ProductRegist, error) {
+	if productRegistSeq <= 0 {
+		return nil, model.NewError(model.ResponseErrorCodeInvalidRequest)
+	}
+
+	productRegist, err := s.repo.Product().GetProductRegistBySeq(c, productRegistSeq)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.NewError(model.ResponseErrorCodeProductRegistNotExist).WithCause(err)
+		}
+		return nil, errors.Wrapf(err, "failed to get product regist by seq(%d)", productRegistSeq)
+	}
+
+	return productRegist, nil
+}
+
+func (s productService) AuthProduct(c context.Context, productRegist *model.ProductRegist, receipt *UploadFile) (*model.Response, error) {
 	switch {
 	case c == nil:
-		return model.SimpleFail(), errors.New("nil context")
+		return nil, errors.New("nil context")
 	case productRegist == nil:
-		return model.SimpleFail(), errors.New("nil request params")
-	case file == nil:
-		return model.SimpleFail(), errors.New("nil receipt file")
+		return nil, errors.New("nil request params")
+	case receipt == nil:
+		return nil, errors.New("nil receipt file")
+	case s.fileBucket == nil:
+		return nil, errors.New("s3 file bucket is nil")
 	}
 
-	// 시리얼 번호 인증
-	product, err := s.repo.Product().GetProductBySerial(c, productRegist.SerialNo, productRegist.ProductType)
+	// 시리얼 번호 인증 ( 제품 종류를 선택하지 않으면 시리얼 번호로 찾는다 )
+	product, err := s.getRegistProduct(c, productRegist)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return &model.Response{
-				Success:   false,
-				Message:   "시리얼 번호가 잘못 되었습니다.",
-				ErrorCode: model.ResponseErrorCodeProductNotExist,
-			}, nil
-		}
-		return model.SimpleFail(), errors.Wrap(err, "failed to auth product")
+		return nil, err
+	}
+	if err := validateMarketType(c, s.repo, productRegist.MarketType, productRegist.OrderNo); err != nil {
+		return nil, err
 	}
 
-	// 기존 인증이 존재하는지 확인 ( 인증 완료 된 것 중에서만 찾음 )
+	// 기존 인증이 존재하는지 확인 ( 인증 완료, 인증 대기 중인 것 중에서만 찾음 )
 	originProductRegist, err := s.repo.Product().GetProductRegistByProductSeq(c, product.ProductSeq)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, errors.Wrap(err, "failed to get product regist by product sequence")
 	}
 
 	if originProductRegist != nil && originProductRegist.ProductRegistSeq > 0 {
-		return &model.Response{
-			Success:   false,
-			Message:   "이미 등록된 제품입니다.",
-			ErrorCode: model.ResponseErrorCodeDuplProduct,
-		}, nil
+		return nil, model.NewError(model.ResponseErrorCodeDuplProduct)
+	}
+
+	// OCR 을 사용하면 업로드하는 영수증을 함께 읽어 둔다.
+	var receiptBody bytes.Buffer
+	if s.receiptOCR != nil {
+		receipt = &UploadFile{Filename: receipt.Filename, Body: io.TeeReader(receipt.Body, &receiptBody)}
 	}
 
 	// s3 업로드
-	s3location := fmt.Sprintf("%s/%d", time.Now().Format("2006-01-02"), product.ProductSeq)
-	if s.fileBucket != nil {
-		if err := s.fileBucket.Upload(c, s3location, file); err != nil {
-			logrus.Errorf("failed to upload s3 object: objectKey=%s, bucketName=%s err:%+v", s3location, s.fileBucket.BucketName(), err)
-		}
+	s3location, checksum, err := uploadFile(c, s.fileBucket, ReceiptKeyPrefix, receipt)
+	if err != nil {
+		return nil, model.NewError(model.ResponseErrorCodeUploadFailed).WithCause(errors.Wrap(err, "failed to upload receipt"))
+	}
+	if s.receiptOCR != nil {
+		productRegist.ReceiptOCR = s.recognizeReceipt(c, &receiptBody)
 	}
 
 	productRegist.ProductSeq = product.ProductSeq
+	productRegist.Status = model.ProductAuthStatusPending // 관리자가 영수증을 확인한 후 인증 완료
 	productRegist.ReceiptS3Location = s3location
+	productRegist.ReceiptSha256 = checksum
 
 	if err := s.repo.Product().CreateProductRegist(c, productRegist); err != nil {
+		removeUploadedFiles(s.fileBucket, s3location)
+		// 동시에 같은 제품을 인증하는 경우 DB 의 unique 제약으로 중복을 막는다.
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return nil, model.NewError(model.ResponseErrorCodeDuplProduct).WithCause(err)
+		}
 		return nil, errors.Wrap(err, "failed to create product regist")
 	}
 
 	return model.SimpleSuccess(), nil
 }
 
-func (s productService) ModAuthProduct(c context.Context, productRegist *model.ProductRegist) error {
+// ModAuthProduct 는 req 고객의 제품 인증 정보를 변경한다.
+func (s productService) ModAuthProduct(c context.Context, req model.ProductAuthRequest, productRegist *model.ProductRegist) error {
 	switch {
 	case c == nil:
 		return errors.New("nil context")
@@ -154,51 +254,88 @@ func (s productService) ModAuthProduct(c context.Context, productRegist *model.P
 		return errors.New("nil product regist")
 	}
 
-	return s.repo.Product().ModProductAuth(c, productRegist)
+	current, err := s.getOwnedProductRegist(c, req, productRegist.ProductRegistSeq)
+	if err != nil {
+		return err
+	}
+	// 인증 완료, 인증 대기 상태만 변경할 수 있다.
+	if !current.Status.Active() {
+		return model.NewError(model.ResponseErrorCodeInvalidStatusTransition)
+	}
+	// 인증 완료 후 구매일을 바꾸면 보증 기간이 달라지므로 다시 영수증을 확인한다.
+	resubmit := current.Status == model.ProductAuthStatusOK && productRegist.PurchaseDate.Format("2006-01-02") != current.PurchaseDate.Format("2006-01-02")
+
+	return db.Transaction(c, func(c context.Context) error {
+		if err := s.repo.Product().ModProductAuth(c, productRegist); err != nil {
+			return err
+		}
+		if resubmit {
+			return s.repo.Product().ResubmitProductAuth(c, productRegist.ProductRegistSeq)
+		}
+		return nil
+	})
 }
 
+// GetAuthProductInfo 는 req 고객의 인증 완료, 인증 대기, 반려된 모든 제품 인증 정보를 조회한다.
 func (s productService) GetAuthProductInfo(c context.Context, req model.ProductAuthRequest) (*model.Response, error) {
 	if c == nil {
 		return nil, errors.New("nil context")
 	}
 
-	data, err := s.repo.Product().GetProductAuthInfo(c, req)
+	data, err := s.repo.Product().FindProductAuthInfos(c, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find product auth infos")
+	}
+	if len(data) == 0 {
+		return nil, model.NewError(model.ResponseErrorCodeProductAuthNotMatched)
+	}
+
+	return model.NewSuccess(model.ResponseMessageSuccess, data), nil
+}
+
+// GetAuthProductInfoBySeq 는 req 고객의 제품 인증 정보 하나를 조회한다. ( 취소된 인증 포함 )
+func (s productService) GetAuthProductInfoBySeq(c context.Context, req model.ProductAuthRequest, productRegistSeq int64) (*model.Response, error) {
+	if c == nil {
+		return nil, errors.New("nil context")
+	}
+	if productRegistSeq <= 0 {
+		return nil, model.NewError(model.ResponseErrorCodeInvalidRequest)
+	}
+
+	data, err := s.repo.Product().GetProductAuthInfoBySeq(c, productRegistSeq)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get product auth info")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.NewError(model.ResponseErrorCodeProductRegistNotExist).WithCause(err)
+		}
+		return nil, errors.Wrapf(err, "failed to get product auth info(%d)", productRegistSeq)
 	}
-	if data.Name == "" {
-		return &model.Response{
-			Success: false,
-			Message: "일치하는 데이터가 없습니다.",
-		}, nil
+	if !req.Matches(data.Name, data.Phone) {
+		return nil, model.NewError(model.ResponseErrorCodeProductAuthNotMatched)
 	}
 
-	return &model.Response{
-		Success: true,
-		Message: "성공하였습니다.",
-		Data:    data,
-	}, nil
+	return model.NewSuccess(model.ResponseMessageSuccess, data), nil
 }
 
 func (s productService) DownloadReceipt(c context.Context, productRegistSeq int64, file *os.File) (*model.ProductRegist, error) {
 	switch {
 	case c == nil:
 		return nil, errors.New("nil context")
-	case productRegistSeq == 0:
-		return nil, errors.New("invalid product regist sequence")
 	case file == nil:
 		return nil, errors.New("file is nil")
+	case s.fileBucket == nil:
+		return nil, errors.New("s3 file bucket is nil")
 	}
 
-	productRegistInfo, err := s.repo.Product().GetProductRegistBySeq(c, productRegistSeq)
+	productRegistInfo, err := s.getProductRegist(c, productRegistSeq)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get product regist by seq(%d)", productRegistSeq)
+		return nil, err
 	}
 
-	if s.fileBucket != nil {
-		if _, err := s.fileBucket.Download(c, file, productRegistInfo.ReceiptS3Location); err != nil {
-			return nil, errors.Wrapf(err, "failed to download file [ s3 location : %+v ]", productRegistInfo.ReceiptS3Location)
+	if _, err := s.fileBucket.Download(c, file, productRegistInfo.ReceiptS3Location, s3.VerifyChecksum(productRegistInfo.ReceiptSha256)); err != nil {
+		if errors.Is(err, s3.ErrObjectNotFound) {
+			return nil, model.NewError(model.ResponseErrorCodeFileNotFound).WithCause(err)
 		}
+		return nil, model.NewError(model.ResponseErrorCodeDownloadFailed).WithCause(errors.Wrapf(err, "failed to download file [ s3 location : %+v ]", productRegistInfo.ReceiptS3Location))
 	}
 
 	return productRegistInfo, nil
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"buddle-server/model"
+	"buddle-server/repository"
+	"context"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type ProductModelService interface {
+	CatalogService
+	FindProductModels(c context.Context, activeOnly bool) (*model.Response, error)
+}
+
+type productModelService struct {
+	catalogService
+	repo repository.Repository
+}
+
+func NewProductModelService(repo repository.Repository) (ProductModelService, error) {
+	if repo == nil {
+		return nil, errors.New("repository is nil")
+	}
+
+	return &productModelService{
+		catalogService: catalogService{
+			name:    "product model",
+			catalog: repo.ProductModel(),
+			errs: catalogErrors{
+				dupl:     model.ResponseErrorCodeDuplProductModel,
+				notExist: model.ResponseErrorCodeProductModelNotExist,
+				inUse:    model.ResponseErrorCodeProductModelInUse,
+			},
+		},
+		repo: repo,
+	}, nil
+}
+
+func (s productModelService) FindProductModels(c context.Context, activeOnly bool) (*model.Response, error) {
+	if c == nil {
+		return nil, errors.New("nil context")
+	}
+
+	data, err := s.repo.ProductModel().FindProductModels(c, activeOnly)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find product models")
+	}
+
+	return model.NewSuccess(model.ResponseMessageSuccess, data), nil
+}
+
+// validateProductType 은 field 의 제품 종류가 제품 모델 테이블에 있는지 확인하고 제품 모델을 반환한다.
+// activeOnly 이면 판매 중인 모델만 허용한다.
+func validateProductType(c context.Context, repo repository.Repository, field string, productType model.ProductType, activeOnly bool) (*model.ProductModel, error) {
+	productModel, err := repo.ProductModel().GetProductModel(c, productType)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, model.NewProductTypeError(field).WithCause(err)
+		}
+		return nil, errors.Wrapf(err, "failed to get product model(%d)", productType)
+	}
+	if activeOnly && !productModel.Active {
+		return nil, model.NewProductTypeError(field)
+	}
+
+	return productModel, nil
+}
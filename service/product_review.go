@@ -0,0 +1,66 @@
+package service
+
+import (
+	"buddle-server/model"
+	"context"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// FindProductReviews 는 영수증 확인을 기다리는 제품 인증 정보를 먼저 인증한 순으로 조회한다.
+func (s productService) FindProductReviews(c context.Context, req model.ProductManageRequest) (model.ProductManageInfos, error) {
+	if c == nil {
+		return nil, errors.New("nil context")
+	}
+
+	req.AuthStatus = model.ProductAuthStatusPending
+
+	return s.repo.Product().FindProductManageInfo(c, req)
+}
+
+// ApproveProductAuth 는 영수증을 확인한 제품 인증 정보를 인증 완료로 변경한다.
+func (s productService) ApproveProductAuth(c context.Context, productRegistSeq int64) error {
+	if c == nil {
+		return errors.New("nil context")
+	}
+
+	if err := s.checkPendingProductRegist(c, productRegistSeq); err != nil {
+		return err
+	}
+
+	return reviewError(s.repo.Product().ApproveProductAuth(c, productRegistSeq))
+}
+
+// RejectProductAuth 는 영수증 확인에 실패한 제품 인증 정보를 반려한다. 반려 사유는 고객에게 표시된다.
+func (s productService) RejectProductAuth(c context.Context, productRegistSeq int64, req model.ProductRejectRequest) error {
+	if c == nil {
+		return errors.New("nil context")
+	}
+
+	if err := s.checkPendingProductRegist(c, productRegistSeq); err != nil {
+		return err
+	}
+
+	return reviewError(s.repo.Product().RejectProductAuth(c, productRegistSeq, req.Reason))
+}
+
+// checkPendingProductRegist 는 제품 인증 정보가 인증 대기 상태인지 확인한다.
+func (s productService) checkPendingProductRegist(c context.Context, productRegistSeq int64) error {
+	productRegist, err := s.getProductRegist(c, productRegistSeq)
+	if err != nil {
+		return err
+	}
+	if productRegist.Status != model.ProductAuthStatusPending {
+		return model.NewError(model.ResponseErrorCodeInvalidStatusTransition)
+	}
+
+	return nil
+}
+
+// reviewError 는 확인 중 다른 요청으로 상태가 변경된 경우를 상태 변경 에러로 변환한다.
+func reviewError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.NewError(model.ResponseErrorCodeInvalidStatusTransition).WithCause(err)
+	}
+	return err
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"buddle-server/internal/i18n"
+	"buddle-server/model"
+	"context"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// CheckSerial 은 시리얼 번호가 제품 모델의 형식에 맞는지, 어떤 제품의 시리얼인지 확인한다.
+// 제품 인증과 같이 판매 중지 모델도 포함하고, 등록된 제품의 시리얼은 형식과 관계없이 유효하다.
+// productType 이 nil 이 아니면 해당 제품 모델의 형식만 확인한다.
+func (s productService) CheckSerial(c context.Context, serial string, productType *model.ProductType) (*model.Response, error) {
+	if c == nil {
+		return nil, errors.New("nil context")
+	}
+
+	serial = model.NormalizeSerial(serial)
+	if serial == "" {
+		return nil, model.NewRequiredError("serial_no")
+	}
+
+	productModels, err := s.repo.ProductModel().FindProductModels(c, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find product models")
+	}
+	if productType != nil {
+		productModels = filterProductModel(productModels, *productType)
+		if len(productModels) == 0 {
+			return nil, model.NewProductTypeError("product_type")
+		}
+	}
+
+	// 등록된 제품의 시리얼이면 해당 제품 모델만 알려준다.
+	// ( 형식은 등록 이후에 추가, 변경될 수 있으므로 등록되지 않은 시리얼의 사유로만 사용한다 )
+	products, err := s.repo.Product().FindProductsBySerial(c, serial)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find products by serial")
+	}
+	var registered model.ProductModels
+	for _, product := range products {
+		registered = append(registered, filterProductModel(productModels, product.ProductType)...)
+	}
+	if len(registered) > 0 {
+		return model.NewSuccess(model.ResponseMessageSuccess, model.NewSerialCheckResult(serial, true, nil, registered)), nil
+	}
+
+	matched, reason := matchSerialRules(productModels, serial, productType != nil)
+	return model.NewSuccess(model.ResponseMessageSuccess, model.NewSerialCheckResult(serial, false, reason, matched)), nil
+}
+
+// matchSerialRules 는 시리얼 번호 형식에 맞는 제품 모델과, 맞는 모델이 없으면 형식 오류 사유를 반환한다.
+// 형식이 정의된 모델을 우선하고, 없으면 형식 제한이 없는 모델을 후보로 한다.
+// 접두사가 일치하는 모델의 형식이 맞지 않으면 해당 모델의 오타로 본다.
+func matchSerialRules(productModels model.ProductModels, serial string, selected bool) (model.ProductModels, i18n.Messages) {
+	var matched, unrestricted model.ProductModels
+	var reason i18n.Messages
+	for _, productModel := range productModels {
+		rule := productModel.SerialRule()
+		if r := rule.Check(serial); r != nil {
+			// 접두사가 일치하거나 제품 모델을 선택한 경우에만 구체적인 사유를 알려준다.
+			if reason == nil && (selected || (rule.Prefix != "" && strings.HasPrefix(serial, rule.Prefix))) {
+				reason = r
+			}
+			continue
+		}
+		if rule.Defined() {
+			matched = append(matched, productModel)
+		} else {
+			unrestricted = append(unrestricted, productModel)
+		}
+	}
+	if len(matched) == 0 && reason == nil {
+		matched = unrestricted
+	}
+	if len(matched) > 0 {
+		reason = nil
+	}
+
+	return matched, reason
+}
+
+// getRegistProduct 는 제품 인증할 제품을 시리얼 번호로 조회한다.
+// 제품 종류를 선택하지 않으면 시리얼 번호로 제품 종류를 찾아 productRegist 에 설정하고,
+// 여러 종류에 같은 시리얼 번호가 있으면 제품 종류 선택을 요청한다.
+// 등록된 제품의 시리얼은 형식과 관계없이 인증할 수 있고, 형식은 제품이 없을 때 사유로만 알려준다.
+func (s productService) getRegistProduct(c context.Context, productRegist *model.ProductRegist) (*model.Product, error) {
+	productRegist.SerialNo = model.NormalizeSerial(productRegist.SerialNo)
+
+	var product *model.Product
+	if productRegist.ProductType == nil {
+		products, err := s.repo.Product().FindProductsBySerial(c, productRegist.SerialNo)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find products by serial")
+		}
+
+		switch len(products) {
+		case 0:
+			return nil, s.unknownSerialError(c, productRegist.SerialNo)
+		case 1:
+			product = products[0]
+			productType := product.ProductType
+			productRegist.ProductType = &productType
+		default:
+			return nil, model.NewProductTypeRequiredError("product_type")
+		}
+	}
+
+	productModel, err := validateProductType(c, s.repo, "product_type", *productRegist.ProductType, false)
+	if err != nil {
+		return nil, err
+	}
+	if product != nil {
+		return product, nil
+	}
+
+	product, err = s.repo.Product().GetProductBySerial(c, productRegist.SerialNo, *productRegist.ProductType)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 오타는 형식 오류 사유로 알려준다.
+			if err := checkSerialRule("serial_no", productRegist.SerialNo, productModel); err != nil {
+				return nil, err
+			}
+			return nil, model.NewError(model.ResponseErrorCodeProductNotExist)
+		}
+		return nil, errors.Wrap(err, "failed to auth product")
+	}
+
+	return product, nil
+}
+
+// unknownSerialError 는 등록되지 않은 시리얼 번호의 에러. 제품 모델의 형식에 맞지 않으면 형식 오류 사유를 알려준다.
+func (s productService) unknownSerialError(c context.Context, serial string) error {
+	productModels, err := s.repo.ProductModel().FindProductModels(c, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to find product models")
+	}
+	if _, reason := matchSerialRules(productModels, serial, false); reason != nil {
+		return model.NewSerialFormatError("serial_no", reason)
+	}
+
+	return model.NewError(model.ResponseErrorCodeProductNotExist)
+}
+
+// checkSerialRule 은 시리얼 번호가 제품 모델의 형식에 맞는지 확인한다.
+func checkSerialRule(field, serial string, productModel *model.ProductModel) error {
+	if reason := productModel.SerialRule().Check(serial); reason != nil {
+		return model.NewSerialFormatError(field, reason)
+	}
+	return nil
+}
+
+func filterProductModel(productModels model.ProductModels, productType model.ProductType) model.ProductModels {
+	for _, productModel := range productModels {
+		if productModel.Code == productType {
+			return model.ProductModels{productModel}
+		}
+	}
+	return nil
+}
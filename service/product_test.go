@@ -0,0 +1,274 @@
+package service_test
+
+import (
+	"buddle-server/internal/s3/s3test"
+	"buddle-server/model"
+	"buddle-server/repository"
+	"buddle-server/repository/repositorytest"
+	"buddle-server/service"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+func newUploadFile(content string) *service.UploadFile {
+	return &service.UploadFile{Filename: "receipt.jpg", Body: strings.NewReader(content)}
+}
+
+// setProductModels 는 제품 모델 조회 fake 를 설정한다.
+func setProductModels(repo *repositorytest.Repository, productModels ...*model.ProductModel) {
+	repo.ProductModelRepository.GetProductModelFunc = func(c context.Context, code model.ProductType) (*model.ProductModel, error) {
+		for _, productModel := range productModels {
+			if productModel.Code == code {
+				return productModel, nil
+			}
+		}
+		return nil, errors.Wrap(gorm.ErrRecordNotFound, "get product model")
+	}
+	repo.ProductModelRepository.FindProductModelsFunc = func(c context.Context, activeOnly bool) (model.ProductModels, error) {
+		result := make(model.ProductModels, 0)
+		for _, productModel := range productModels {
+			if productModel.Active || !activeOnly {
+				result = append(result, productModel)
+			}
+		}
+		return result, nil
+	}
+}
+
+// setSalesChannels 는 판매 채널 조회 fake 를 설정한다.
+func setSalesChannels(repo *repositorytest.Repository, salesChannels ...*model.SalesChannel) {
+	repo.SalesChannelRepository.GetSalesChannelFunc = func(c context.Context, code model.MarketType) (*model.SalesChannel, error) {
+		for _, salesChannel := range salesChannels {
+			if salesChannel.Code == code {
+				return salesChannel, nil
+			}
+		}
+		return nil, errors.Wrap(gorm.ErrRecordNotFound, "get sales channel")
+	}
+}
+
+func TestProductService_CreateProduct(t *testing.T) {
+	repo := repositorytest.NewRepository()
+	setProductModels(repo,
+		&model.ProductModel{Code: 0, Active: true},
+		&model.ProductModel{Code: 1, Active: false},
+		&model.ProductModel{Code: 2, Active: true, SerialPrefix: "BM", SerialLength: 8, SerialCharset: model.SerialCharsetAlphanumeric, SerialCheckDigit: true},
+	)
+
+	created := make([]string, 0)
+	repo.ProductRepository.CreateFunc = func(c context.Context, product *model.Product) error {
+		created = append(created, product.SerialNo)
+		return nil
+	}
+
+	productService, err := service.NewProductService(repo, s3test.NewMemory(), nil)
+	if err != nil {
+		t.Fatalf("failed to create product service: %+v", err)
+	}
+
+	// 판매 중지(1), 존재하지 않는(9) 모델, 형식이 잘못된 행과 시리얼 형식( 체크 디지트 )이 맞지 않는 행은 실패로 센다.
+	csvData := "SN-001,0\nSN-002,1\nSN-003,9\nSN-004,x\nBM123454,2\nBM123455,2\n"
+	success, failure, err := productService.CreateProduct(context.Background(), csv.NewReader(strings.NewReader(csvData)))
+	if err != nil {
+		t.Fatalf("CreateProduct: %+v", err)
+	}
+	if success != 2 || failure != 4 || len(created) != 2 || created[0] != "SN-001" || created[1] != "BM123454" {
+		t.Fatalf("success = %d, failure = %d, created = %v", success, failure, created)
+	}
+}
+
+func TestProductService_AuthProduct(t *testing.T) {
+	c := context.Background()
+	productRegist := func() *model.ProductRegist {
+		productType := model.ProductTypePowderMilkMaker
+		return &model.ProductRegist{Name: "홍길동", Phone: "01012345678", SerialNo: "SN-001", ProductType: &productType}
+	}
+
+	t.Run("unknown product type", func(t *testing.T) {
+		repo := repositorytest.NewRepository()
+		setProductModels(repo)
+
+		productService, err := service.NewProductService(repo, s3test.NewMemory(), nil)
+		if err != nil {
+			t.Fatalf("failed to create product service: %+v", err)
+		}
+
+		_, err = productService.AuthProduct(c, productRegist(), newUploadFile("receipt"))
+		var domainErr *model.Error
+		if !errors.As(err, &domainErr) || !errors.Is(err, model.ErrValidation) || len(domainErr.Fields) != 1 || domainErr.Fields[0].Field != "product_type" {
+			t.Fatalf("err = %v, want product_type validation error", err)
+		}
+	})
+
+	t.Run("nil file bucket", func(t *testing.T) {
+		// 영수증을 저장할 수 없으면 제품을 조회하기 전에 실패한다. ( repository fake 미설정 )
+		productService, err := service.NewProductService(repositorytest.NewRepository(), nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create product service: %+v", err)
+		}
+
+		if _, err := productService.AuthProduct(c, productRegist(), newUploadFile("receipt")); err == nil {
+			t.Fatalf("AuthProduct without file bucket error = nil")
+		}
+	})
+
+	t.Run("unknown serial", func(t *testing.T) {
+		repo := repositorytest.NewRepository()
+		setProductModels(repo, &model.ProductModel{Code: 0})
+		setSalesChannels(repo, &model.SalesChannel{Code: 0})
+		repo.ProductRepository.GetProductBySerialFunc = func(c context.Context, serial string, productType model.ProductType) (*model.Product, error) {
+			return nil, errors.Wrap(gorm.ErrRecordNotFound, "get product")
+		}
+		storage := s3test.NewMemory()
+
+		productService, err := service.NewProductService(repo, storage, nil)
+		if err != nil {
+			t.Fatalf("failed to create product service: %+v", err)
+		}
+
+		_, err = productService.AuthProduct(c, productRegist(), newUploadFile("receipt"))
+		var domainErr *model.Error
+		if !errors.As(err, &domainErr) || !errors.Is(err, model.ErrNotFound) || domainErr.ErrorCode != model.ResponseErrorCodeProductNotExist {
+			t.Fatalf("err = %v, want not found error", err)
+		}
+		if keys := storage.Keys(); len(keys) != 0 {
+			t.Fatalf("stored objects = %v, want none", keys)
+		}
+	})
+
+	newRepository := func(createErr error) *repositorytest.Repository {
+		repo := repositorytest.NewRepository()
+		setProductModels(repo, &model.ProductModel{Code: 0})
+		setSalesChannels(repo, &model.SalesChannel{Code: 0}, &model.SalesChannel{Code: 4, OrderNoRequired: true})
+		repo.ProductRepository.GetProductBySerialFunc = func(c context.Context, serial string, productType model.ProductType) (*model.Product, error) {
+			return &model.Product{ProductSeq: 1, SerialNo: serial, ProductType: productType}, nil
+		}
+		repo.ProductRepository.GetProductRegistByProductSeqFunc = func(c context.Context, productSeq int64) (*model.ProductRegist, error) {
+			return nil, gorm.ErrRecordNotFound
+		}
+		repo.ProductRepository.CreateProductRegistFunc = func(c context.Context, productRegist *model.ProductRegist) error {
+			return createErr
+		}
+		return repo
+	}
+
+	t.Run("success", func(t *testing.T) {
+		storage := s3test.NewMemory()
+		productService, err := service.NewProductService(newRepository(nil), storage, nil)
+		if err != nil {
+			t.Fatalf("failed to create product service: %+v", err)
+		}
+
+		req := productRegist()
+		req.Status = model.ProductAuthStatusOK // 고객이 인증 상태를 지정할 수 없다.
+		resp, err := productService.AuthProduct(c, req, newUploadFile("receipt"))
+		if err != nil {
+			t.Fatalf("AuthProduct: %+v", err)
+		}
+		if !resp.Success {
+			t.Fatalf("resp = %+v", resp)
+		}
+		if body, ok := storage.Object(req.ReceiptS3Location); !ok || string(body) != "receipt" {
+			t.Fatalf("receipt(%s) was not stored", req.ReceiptS3Location)
+		}
+		if req.ProductSeq != 1 || req.ReceiptSha256 == "" || req.Status != model.ProductAuthStatusPending {
+			t.Fatalf("product regist = %+v", req)
+		}
+	})
+
+	t.Run("product type from serial", func(t *testing.T) {
+		repo := newRepository(nil)
+		setProductModels(repo,
+			&model.ProductModel{Code: 0},
+			&model.ProductModel{Code: 2},
+			&model.ProductModel{Code: 3, SerialPrefix: "BM", SerialLength: 8, SerialCharset: model.SerialCharsetAlphanumeric, SerialCheckDigit: true},
+		)
+		repo.ProductRepository.FindProductsBySerialFunc = func(c context.Context, serial string) ([]*model.Product, error) {
+			switch serial {
+			case "SN-002":
+				return []*model.Product{{ProductSeq: 2, SerialNo: serial, ProductType: 2}}, nil
+			case "SN-003":
+				return []*model.Product{{ProductSeq: 3, SerialNo: serial, ProductType: 0}, {ProductSeq: 4, SerialNo: serial, ProductType: 2}}, nil
+			}
+			return nil, nil
+		}
+		productService, err := service.NewProductService(repo, s3test.NewMemory(), nil)
+		if err != nil {
+			t.Fatalf("failed to create product service: %+v", err)
+		}
+
+		// 제품 종류를 선택하지 않으면 시리얼 번호로 찾는다.
+		req := productRegist()
+		req.SerialNo, req.ProductType = "SN-002", nil
+		if _, err := productService.AuthProduct(c, req, newUploadFile("receipt")); err != nil {
+			t.Fatalf("AuthProduct: %+v", err)
+		}
+		if req.ProductSeq != 2 || req.ProductType == nil || *req.ProductType != 2 {
+			t.Fatalf("product regist = %+v", req)
+		}
+
+		// 여러 종류에 같은 시리얼 번호가 있으면 제품 종류를 선택해야 한다.
+		req = productRegist()
+		req.SerialNo, req.ProductType = "SN-003", nil
+		_, err = productService.AuthProduct(c, req, newUploadFile("receipt"))
+		var domainErr *model.Error
+		if !errors.As(err, &domainErr) || len(domainErr.Fields) != 1 || domainErr.Fields[0].Field != "product_type" || domainErr.Fields[0].Rule != model.ValidationRuleRequired {
+			t.Fatalf("err = %v, want product_type required error", err)
+		}
+
+		// 등록되지 않은 시리얼은 제품 모델의 형식에 맞지 않으면 형식 오류를 알려준다.
+		req.SerialNo = "BM123455"
+		_, err = productService.AuthProduct(c, req, newUploadFile("receipt"))
+		if !errors.As(err, &domainErr) || len(domainErr.Fields) != 1 || domainErr.Fields[0].Field != "serial_no" || domainErr.Fields[0].Rule != model.ValidationRuleFormat {
+			t.Fatalf("err = %v, want serial_no format error", err)
+		}
+
+		req.SerialNo = "SN-404"
+		_, err = productService.AuthProduct(c, req, newUploadFile("receipt"))
+		if !errors.As(err, &domainErr) || domainErr.ErrorCode != model.ResponseErrorCodeProductNotExist {
+			t.Fatalf("err = %v, want product not exist error", err)
+		}
+	})
+
+	t.Run("order number required by sales channel", func(t *testing.T) {
+		productService, err := service.NewProductService(newRepository(nil), s3test.NewMemory(), nil)
+		if err != nil {
+			t.Fatalf("failed to create product service: %+v", err)
+		}
+
+		req := productRegist()
+		req.MarketType = 4
+		_, err = productService.AuthProduct(c, req, newUploadFile("receipt"))
+		var domainErr *model.Error
+		if !errors.As(err, &domainErr) || len(domainErr.Fields) != 1 || domainErr.Fields[0].Field != "order_no" || domainErr.Fields[0].Rule != model.ValidationRuleRequired {
+			t.Fatalf("err = %v, want order_no required error", err)
+		}
+
+		req.OrderNo = "2022030112345"
+		if _, err := productService.AuthProduct(c, req, newUploadFile("receipt")); err != nil {
+			t.Fatalf("AuthProduct with order number: %+v", err)
+		}
+	})
+
+	t.Run("duplicated regist removes uploaded receipt", func(t *testing.T) {
+		storage := s3test.NewMemory()
+		productService, err := service.NewProductService(newRepository(errors.Wrap(repository.ErrDuplicateKey, "create product regist")), storage, nil)
+		if err != nil {
+			t.Fatalf("failed to create product service: %+v", err)
+		}
+
+		_, err = productService.AuthProduct(c, productRegist(), newUploadFile("receipt"))
+		var domainErr *model.Error
+		if !errors.As(err, &domainErr) || !errors.Is(err, model.ErrConflict) || domainErr.ErrorCode != model.ResponseErrorCodeDuplProduct {
+			t.Fatalf("err = %v, want conflict error", err)
+		}
+		if keys := storage.Keys(); len(keys) != 0 {
+			t.Fatalf("stored objects = %v, want none", keys)
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"buddle-server/internal/db"
+	"buddle-server/model"
+	"buddle-server/repository"
+	"context"
+	"crypto/rand"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// transferCodeChars 는 이전 코드에 사용하는 문자 ( 혼동되는 0, O, 1, I 제외 )
+const transferCodeChars = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+const transferCodeLength = 8
+
+// StartTransfer 는 req 고객의 제품 인증 정보에 대한 소유권 이전을 요청하고 이전 코드를 발급한다.
+func (s productService) StartTransfer(c context.Context, req model.ProductAuthRequest, productRegistSeq int64) (*model.Response, error) {
+	if c == nil {
+		return nil, errors.New("nil context")
+	}
+
+	productRegist, err := s.getOwnedProductRegist(c, req, productRegistSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.startTransfer(c, productRegist)
+}
+
+// StartTransferByAdmin 은 관리자가 제품 인증 정보의 소유권 이전을 요청하고 이전 코드를 발급한다.
+func (s productService) StartTransferByAdmin(c context.Context, productRegistSeq int64) (*model.Response, error) {
+	if c == nil {
+		return nil, errors.New("nil context")
+	}
+
+	productRegist, err := s.getProductRegist(c, productRegistSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.startTransfer(c, productRegist)
+}
+
+// startTransfer 는 기존의 대기 중인 이전 요청을 취소하고 새 이전 코드를 발급한다.
+func (s productService) startTransfer(c context.Context, productRegist *model.ProductRegist) (*model.Response, error) {
+	// 인증 완료 상태만 이전할 수 있다.
+	switch productRegist.Status {
+	case model.ProductAuthStatusOK:
+	case model.ProductAuthStatusPending:
+		return nil, model.NewError(model.ResponseErrorCodeProductRegistPending)
+	default:
+		return nil, model.NewError(model.ResponseErrorCodeInvalidStatusTransition)
+	}
+
+	code, err := newTransferCode()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	transfer := &model.ProductTransfer{
+		ProductSeq:           productRegist.ProductSeq,
+		FromProductRegistSeq: productRegist.ProductRegistSeq,
+		TransferCode:         code,
+		ExpireDate:           now.Add(model.ProductTransferExpiration),
+		Regdate:              now,
+	}
+
+	if err := db.Transaction(c, func(c context.Context) error {
+		if err := s.repo.ProductTransfer().CancelPendingTransfers(c, productRegist.ProductRegistSeq); err != nil {
+			return err
+		}
+		return s.repo.ProductTransfer().Create(c, transfer)
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to create product transfer")
+	}
+
+	return model.NewSuccess(model.ResponseMessageSuccess, transfer), nil
+}
+
+// ClaimTransfer 는 새 소유자가 시리얼 번호, 이전 코드로 제품을 인증한다.
+// 양도인의 인증 정보는 소유권 이전 상태로 남는다.
+func (s productService) ClaimTransfer(c context.Context, claim *model.ProductTransferClaim) (*model.Response, error) {
+	switch {
+	case c == nil:
+		return nil, errors.New("nil context")
+	case claim == nil:
+		return nil, errors.New("nil request params")
+	}
+
+	err := db.Transaction(c, func(c context.Context) error {
+		// 제품 인증과 같이 시리얼 번호의 공백은 무시하고, 제품 종류를 선택하지 않으면 시리얼 번호로 찾는다.
+		product, err := s.getRegistProduct(c, &model.ProductRegist{SerialNo: claim.SerialNo, ProductType: claim.ProductType})
+		if err != nil {
+			return err
+		}
+
+		code := strings.ToUpper(strings.TrimSpace(claim.TransferCode))
+		transfer, err := s.repo.ProductTransfer().GetPendingTransfer(c, product.ProductSeq, code)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.NewError(model.ResponseErrorCodeProductTransferNotExist)
+			}
+			return errors.Wrap(err, "failed to get pending product transfer")
+		}
+		if transfer.Expired(time.Now()) {
+			return model.NewError(model.ResponseErrorCodeProductTransferNotExist)
+		}
+
+		from, err := s.getProductRegist(c, transfer.FromProductRegistSeq)
+		if err != nil {
+			return err
+		}
+		if from.Status != model.ProductAuthStatusOK {
+			return model.NewError(model.ResponseErrorCodeInvalidStatusTransition)
+		}
+
+		if err := s.repo.Product().TransferProductAuth(c, from.ProductRegistSeq); err != nil {
+			return err
+		}
+
+		productRegist := claim.ProductRegist(product, from)
+		if err := s.repo.Product().CreateProductRegist(c, productRegist); err != nil {
+			if errors.Is(err, repository.ErrDuplicateKey) {
+				return model.NewError(model.ResponseErrorCodeDuplProduct).WithCause(err)
+			}
+			return errors.Wrap(err, "failed to create product regist")
+		}
+
+		return s.repo.ProductTransfer().Complete(c, transfer.ProductTransferSeq, productRegist.ProductRegistSeq)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.SimpleSuccess(), nil
+}
+
+// newTransferCode 는 추측할 수 없는 임의의 이전 코드를 생성한다.
+func newTransferCode() (string, error) {
+	b := make([]byte, transferCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate transfer code")
+	}
+
+	for i := range b {
+		b[i] = transferCodeChars[int(b[i])%len(transferCodeChars)]
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"buddle-server/internal/ocr"
+	"buddle-server/model"
+	"context"
+	"github.com/sirupsen/logrus"
+	"io"
+	"strings"
+)
+
+// recognizeReceipt 는 영수증에서 구매일, 판매처, 금액 후보를 추출한다.
+// OCR 은 관리자의 영수증 확인을 돕는 용도이므로 실패해도 제품 인증은 계속 진행한다.
+func (s productService) recognizeReceipt(c context.Context, receipt io.Reader) *model.ReceiptCandidates {
+	text, err := s.receiptOCR.Recognize(c, receipt)
+	if err != nil {
+		logrus.Warnf("failed to recognize receipt [ provider = %s, err = %+v ]", s.receiptOCR.Name(), err)
+		return nil
+	}
+
+	parsed := ocr.ParseReceipt(text)
+	candidates := &model.ReceiptCandidates{
+		Provider:  s.receiptOCR.Name(),
+		Merchants: parsed.Merchants,
+		Amounts:   parsed.Amounts,
+	}
+	for _, date := range parsed.Dates {
+		candidates.PurchaseDates = append(candidates.PurchaseDates, date.Format("2006-01-02"))
+	}
+
+	// 판매 중지된 채널의 영수증도 있으므로 모든 판매 채널의 이름을 찾는다.
+	salesChannels, err := s.repo.SalesChannel().FindSalesChannels(c, false)
+	if err != nil {
+		logrus.Warnf("failed to find sales channels for receipt ocr [ err = %+v ]", err)
+		return candidates
+	}
+	lowerText := strings.ToLower(text)
+	for _, salesChannel := range salesChannels {
+		for _, name := range []string{salesChannel.NameKo, salesChannel.NameEn} {
+			if name != "" && strings.Contains(lowerText, strings.ToLower(name)) {
+				candidates.MarketTypes = append(candidates.MarketTypes, salesChannel.Code)
+				break
+			}
+		}
+	}
+
+	return candidates
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"buddle-server/model"
+	"buddle-server/repository"
+	"context"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"strings"
+)
+
+type SalesChannelService interface {
+	CatalogService
+	FindSalesChannels(c context.Context, activeOnly bool) (*model.Response, error)
+}
+
+type salesChannelService struct {
+	catalogService
+	repo repository.Repository
+}
+
+func NewSalesChannelService(repo repository.Repository) (SalesChannelService, error) {
+	if repo == nil {
+		return nil, errors.New("repository is nil")
+	}
+
+	return &salesChannelService{
+		catalogService: catalogService{
+			name:    "sales channel",
+			catalog: repo.SalesChannel(),
+			errs: catalogErrors{
+				dupl:     model.ResponseErrorCodeDuplSalesChannel,
+				notExist: model.ResponseErrorCodeSalesChannelNotExist,
+				inUse:    model.ResponseErrorCodeSalesChannelInUse,
+			},
+		},
+		repo: repo,
+	}, nil
+}
+
+func (s salesChannelService) FindSalesChannels(c context.Context, activeOnly bool) (*model.Response, error) {
+	if c == nil {
+		return nil, errors.New("nil context")
+	}
+
+	data, err := s.repo.SalesChannel().FindSalesChannels(c, activeOnly)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find sales channels")
+	}
+
+	return model.NewSuccess(model.ResponseMessageSuccess, data), nil
+}
+
+// validateMarketType 은 구매처가 판매 채널 테이블에 있는지, 주문번호가 필수인 채널이면 주문번호가 입력되었는지 확인한다.
+func validateMarketType(c context.Context, repo repository.Repository, marketType model.MarketType, orderNo string) error {
+	salesChannel, err := repo.SalesChannel().GetSalesChannel(c, marketType)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.NewMarketTypeError("market_type").WithCause(err)
+		}
+		return errors.Wrapf(err, "failed to get sales channel(%d)", marketType)
+	}
+	if salesChannel.OrderNoRequired && strings.TrimSpace(orderNo) == "" {
+		return model.NewRequiredError("order_no")
+	}
+
+	return nil
+}
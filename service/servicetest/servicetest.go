@@ -0,0 +1,270 @@
+// Package servicetest 는 service 인터페이스의 fake 를 제공한다.
+// Func 필드가 설정되지 않은 메서드를 호출하면 panic 이 발생한다.
+package servicetest
+
+import (
+	"buddle-server/model"
+	"buddle-server/service"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+)
+
+func notImplemented(method string) string {
+	return fmt.Sprintf("servicetest: %s is not set", method)
+}
+
+// ProductService 는 service.ProductService 의 fake. 호출할 메서드의 Func 필드를 설정하여 사용한다.
+type ProductService struct {
+	CreateProductFunc           func(c context.Context, csvReader *csv.Reader) (int64, int64, error)
+	AuthProductFunc             func(c context.Context, productRegist *model.ProductRegist, receipt *service.UploadFile) (*model.Response, error)
+	ModAuthProductFunc          func(c context.Context, req model.ProductAuthRequest, productRegist *model.ProductRegist) error
+	CancelAuthProductFunc       func(c context.Context, req model.ProductAuthRequest, productRegistSeq int64) error
+	GetAuthProductInfoFunc      func(c context.Context, req model.ProductAuthRequest) (*model.Response, error)
+	GetAuthProductInfoBySeqFunc func(c context.Context, req model.ProductAuthRequest, productRegistSeq int64) (*model.Response, error)
+	DownloadReceiptFunc         func(c context.Context, productRegistSeq int64, file *os.File) (*model.ProductRegist, error)
+	FindProductManageInfoFunc   func(c context.Context, req model.ProductManageRequest) (model.ProductManageInfos, error)
+	FindProductReviewsFunc      func(c context.Context, req model.ProductManageRequest) (model.ProductManageInfos, error)
+	ApproveProductAuthFunc      func(c context.Context, productRegistSeq int64) error
+	RejectProductAuthFunc       func(c context.Context, productRegistSeq int64, req model.ProductRejectRequest) error
+	StartTransferFunc           func(c context.Context, req model.ProductAuthRequest, productRegistSeq int64) (*model.Response, error)
+	StartTransferByAdminFunc    func(c context.Context, productRegistSeq int64) (*model.Response, error)
+	ClaimTransferFunc           func(c context.Context, claim *model.ProductTransferClaim) (*model.Response, error)
+	CheckSerialFunc             func(c context.Context, serial string, productType *model.ProductType) (*model.Response, error)
+}
+
+var _ service.ProductService = (*ProductService)(nil)
+
+func (p *ProductService) CreateProduct(c context.Context, csvReader *csv.Reader) (int64, int64, error) {
+	if p.CreateProductFunc == nil {
+		panic(notImplemented("ProductService.CreateProduct"))
+	}
+	return p.CreateProductFunc(c, csvReader)
+}
+
+func (p *ProductService) AuthProduct(c context.Context, productRegist *model.ProductRegist, receipt *service.UploadFile) (*model.Response, error) {
+	if p.AuthProductFunc == nil {
+		panic(notImplemented("ProductService.AuthProduct"))
+	}
+	return p.AuthProductFunc(c, productRegist, receipt)
+}
+
+func (p *ProductService) ModAuthProduct(c context.Context, req model.ProductAuthRequest, productRegist *model.ProductRegist) error {
+	if p.ModAuthProductFunc == nil {
+		panic(notImplemented("ProductService.ModAuthProduct"))
+	}
+	return p.ModAuthProductFunc(c, req, productRegist)
+}
+
+func (p *ProductService) CancelAuthProduct(c context.Context, req model.ProductAuthRequest, productRegistSeq int64) error {
+	if p.CancelAuthProductFunc == nil {
+		panic(notImplemented("ProductService.CancelAuthProduct"))
+	}
+	return p.CancelAuthProductFunc(c, req, productRegistSeq)
+}
+
+func (p *ProductService) GetAuthProductInfo(c context.Context, req model.ProductAuthRequest) (*model.Response, error) {
+	if p.GetAuthProductInfoFunc == nil {
+		panic(notImplemented("ProductService.GetAuthProductInfo"))
+	}
+	return p.GetAuthProductInfoFunc(c, req)
+}
+
+func (p *ProductService) GetAuthProductInfoBySeq(c context.Context, req model.ProductAuthRequest, productRegistSeq int64) (*model.Response, error) {
+	if p.GetAuthProductInfoBySeqFunc == nil {
+		panic(notImplemented("ProductService.GetAuthProductInfoBySeq"))
+	}
+	return p.GetAuthProductInfoBySeqFunc(c, req, productRegistSeq)
+}
+
+func (p *ProductService) DownloadReceipt(c context.Context, productRegistSeq int64, file *os.File) (*model.ProductRegist, error) {
+	if p.DownloadReceiptFunc == nil {
+		panic(notImplemented("ProductService.DownloadReceipt"))
+	}
+	return p.DownloadReceiptFunc(c, productRegistSeq, file)
+}
+
+func (p *ProductService) FindProductManageInfo(c context.Context, req model.ProductManageRequest) (model.ProductManageInfos, error) {
+	if p.FindProductManageInfoFunc == nil {
+		panic(notImplemented("ProductService.FindProductManageInfo"))
+	}
+	return p.FindProductManageInfoFunc(c, req)
+}
+
+func (p *ProductService) FindProductReviews(c context.Context, req model.ProductManageRequest) (model.ProductManageInfos, error) {
+	if p.FindProductReviewsFunc == nil {
+		panic(notImplemented("ProductService.FindProductReviews"))
+	}
+	return p.FindProductReviewsFunc(c, req)
+}
+
+func (p *ProductService) ApproveProductAuth(c context.Context, productRegistSeq int64) error {
+	if p.ApproveProductAuthFunc == nil {
+		panic(notImplemented("ProductService.ApproveProductAuth"))
+	}
+	return p.ApproveProductAuthFunc(c, productRegistSeq)
+}
+
+func (p *ProductService) RejectProductAuth(c context.Context, productRegistSeq int64, req model.ProductRejectRequest) error {
+	if p.RejectProductAuthFunc == nil {
+		panic(notImplemented("ProductService.RejectProductAuth"))
+	}
+	return p.RejectProductAuthFunc(c, productRegistSeq, req)
+}
+
+func (p *ProductService) StartTransfer(c context.Context, req model.ProductAuthRequest, productRegistSeq int64) (*model.Response, error) {
+	if p.StartTransferFunc == nil {
+		panic(notImplemented("ProductService.StartTransfer"))
+	}
+	return p.StartTransferFunc(c, req, productRegistSeq)
+}
+
+func (p *ProductService) StartTransferByAdmin(c context.Context, productRegistSeq int64) (*model.Response, error) {
+	if p.StartTransferByAdminFunc == nil {
+		panic(notImplemented("ProductService.StartTransferByAdmin"))
+	}
+	return p.StartTransferByAdminFunc(c, productRegistSeq)
+}
+
+func (p *ProductService) ClaimTransfer(c context.Context, claim *model.ProductTransferClaim) (*model.Response, error) {
+	if p.ClaimTransferFunc == nil {
+		panic(notImplemented("ProductService.ClaimTransfer"))
+	}
+	return p.ClaimTransferFunc(c, claim)
+}
+
+func (p *ProductService) CheckSerial(c context.Context, serial string, productType *model.ProductType) (*model.Response, error) {
+	if p.CheckSerialFunc == nil {
+		panic(notImplemented("ProductService.CheckSerial"))
+	}
+	return p.CheckSerialFunc(c, serial, productType)
+}
+
+// AfterService 는 service.AfterService 의 fake. 호출할 메서드의 Func 필드를 설정하여 사용한다.
+type AfterService struct {
+	CreateFunc                      func(c context.Context, as *model.AfterService, files []*service.UploadFile) (*model.Response, error)
+	FindAfterServiceInfoFunc        func(c context.Context, req model.AfterServiceRequest) (*model.Response, error)
+	FindAfterServiceManagerInfoFunc func(c context.Context, req model.AfterServiceRequest) (*model.Response, error)
+	FindProductAfterServicesFunc    func(c context.Context, productSeq int64) (*model.Response, error)
+	DownloadFileFunc                func(c context.Context, afterServiceSeq, fileIdx int64, file *os.File) (*model.AfterService, error)
+}
+
+var _ service.AfterService = (*AfterService)(nil)
+
+func (a *AfterService) Create(c context.Context, as *model.AfterService, files []*service.UploadFile) (*model.Response, error) {
+	if a.CreateFunc == nil {
+		panic(notImplemented("AfterService.Create"))
+	}
+	return a.CreateFunc(c, as, files)
+}
+
+func (a *AfterService) FindAfterServiceInfo(c context.Context, req model.AfterServiceRequest) (*model.Response, error) {
+	if a.FindAfterServiceInfoFunc == nil {
+		panic(notImplemented("AfterService.FindAfterServiceInfo"))
+	}
+	return a.FindAfterServiceInfoFunc(c, req)
+}
+
+func (a *AfterService) FindAfterServiceManagerInfo(c context.Context, req model.AfterServiceRequest) (*model.Response, error) {
+	if a.FindAfterServiceManagerInfoFunc == nil {
+		panic(notImplemented("AfterService.FindAfterServiceManagerInfo"))
+	}
+	return a.FindAfterServiceManagerInfoFunc(c, req)
+}
+
+func (a *AfterService) FindProductAfterServices(c context.Context, productSeq int64) (*model.Response, error) {
+	if a.FindProductAfterServicesFunc == nil {
+		panic(notImplemented("AfterService.FindProductAfterServices"))
+	}
+	return a.FindProductAfterServicesFunc(c, productSeq)
+}
+
+func (a *AfterService) DownloadFile(c context.Context, afterServiceSeq, fileIdx int64, file *os.File) (*model.AfterService, error) {
+	if a.DownloadFileFunc == nil {
+		panic(notImplemented("AfterService.DownloadFile"))
+	}
+	return a.DownloadFileFunc(c, afterServiceSeq, fileIdx, file)
+}
+
+// UserService 는 service.UserService 의 fake. 호출할 메서드의 Func 필드를 설정하여 사용한다.
+type UserService struct {
+	SignUpFunc func(c context.Context, user *model.User) error
+	SignInFunc func(c context.Context, user *model.User) (*model.Response, error)
+}
+
+var _ service.UserService = (*UserService)(nil)
+
+func (u *UserService) SignUp(c context.Context, user *model.User) error {
+	if u.SignUpFunc == nil {
+		panic(notImplemented("UserService.SignUp"))
+	}
+	return u.SignUpFunc(c, user)
+}
+
+func (u *UserService) SignIn(c context.Context, user *model.User) (*model.Response, error) {
+	if u.SignInFunc == nil {
+		panic(notImplemented("UserService.SignIn"))
+	}
+	return u.SignInFunc(c, user)
+}
+
+// CatalogService 는 service.CatalogService 의 fake. ProductModelService, SalesChannelService 에 포함된다.
+type CatalogService struct {
+	CreateFunc func(c context.Context, entry model.CatalogEntry) error
+	UpdateFunc func(c context.Context, entry model.CatalogEntry) error
+	DeleteFunc func(c context.Context, code int) error
+}
+
+var _ service.CatalogService = (*CatalogService)(nil)
+
+func (s *CatalogService) Create(c context.Context, entry model.CatalogEntry) error {
+	if s.CreateFunc == nil {
+		panic(notImplemented("CatalogService.Create"))
+	}
+	return s.CreateFunc(c, entry)
+}
+
+func (s *CatalogService) Update(c context.Context, entry model.CatalogEntry) error {
+	if s.UpdateFunc == nil {
+		panic(notImplemented("CatalogService.Update"))
+	}
+	return s.UpdateFunc(c, entry)
+}
+
+func (s *CatalogService) Delete(c context.Context, code int) error {
+	if s.DeleteFunc == nil {
+		panic(notImplemented("CatalogService.Delete"))
+	}
+	return s.DeleteFunc(c, code)
+}
+
+// ProductModelService 는 service.ProductModelService 의 fake. 호출할 메서드의 Func 필드를 설정하여 사용한다.
+type ProductModelService struct {
+	CatalogService
+	FindProductModelsFunc func(c context.Context, activeOnly bool) (*model.Response, error)
+}
+
+var _ service.ProductModelService = (*ProductModelService)(nil)
+
+func (p *ProductModelService) FindProductModels(c context.Context, activeOnly bool) (*model.Response, error) {
+	if p.FindProductModelsFunc == nil {
+		panic(notImplemented("ProductModelService.FindProductModels"))
+	}
+	return p.FindProductModelsFunc(c, activeOnly)
+}
+
+// SalesChannelService 는 service.SalesChannelService 의 fake. 호출할 메서드의 Func 필드를 설정하여 사용한다.
+type SalesChannelService struct {
+	CatalogService
+	FindSalesChannelsFunc func(c context.Context, activeOnly bool) (*model.Response, error)
+}
+
+var _ service.SalesChannelService = (*SalesChannelService)(nil)
+
+func (s *SalesChannelService) FindSalesChannels(c context.Context, activeOnly bool) (*model.Response, error) {
+	if s.FindSalesChannelsFunc == nil {
+		panic(notImplemented("SalesChannelService.FindSalesChannels"))
+	}
+	return s.FindSalesChannelsFunc(c, activeOnly)
+}
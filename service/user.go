@@ -4,7 +4,6 @@ import (
 	"buddle-server/model"
 	"buddle-server/repository"
 	"context"
-	"fmt"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
@@ -41,7 +40,7 @@ func (u userService) SignUp(c context.Context, user *model.User) error {
 	}
 
 	if oriUser != nil && oriUser.UserSeq != 0 {
-		return fmt.Errorf("user(%d) is duplicated", oriUser.UserSeq)
+		return model.NewError(model.ResponseErrorCodeDuplUserID)
 	}
 
 	// 비밀번호를 bycrypt 라이브러리로 해싱 처리
@@ -52,6 +51,9 @@ func (u userService) SignUp(c context.Context, user *model.User) error {
 	user.Password = hashpw
 
 	if err := u.repo.User().Create(c, user); err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return model.NewError(model.ResponseErrorCodeDuplUserID).WithCause(err)
+		}
 		return errors.Wrap(err, "failed to sign up user")
 	}
 
@@ -61,9 +63,9 @@ func (u userService) SignUp(c context.Context, user *model.User) error {
 func (u userService) SignIn(c context.Context, user *model.User) (*model.Response, error) {
 	switch {
 	case c == nil:
-		return model.SimpleFail(), errors.New("nil context")
+		return nil, errors.New("nil context")
 	case user == nil:
-		return model.SimpleFail(), errors.New("user is nil")
+		return nil, errors.New("user is nil")
 	}
 
 	inputId := user.Id
@@ -72,22 +74,14 @@ func (u userService) SignIn(c context.Context, user *model.User) (*model.Respons
 	user, err := u.repo.User().GetUserByID(c, user.Id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			logrus.Errorf("invaild user [ id = %s ] err : %+v", inputId, err)
-			return &model.Response{
-				Success:   false,
-				Message:   "아이디가 존재 하지 않습니다.",
-				ErrorCode: model.ResponseErrorCodeUserIDNotExist,
-			}, nil
+			logrus.Warnf("invaild user [ id = %s ]", inputId)
+			return nil, model.NewError(model.ResponseErrorCodeUserIDNotExist)
 		}
-		return model.SimpleFail(), errors.Wrap(err, "failed to check user for sign up")
+		return nil, errors.Wrap(err, "failed to check user for sign in")
 	}
 
 	if !CheckPasswordHash(user.Password, inputPassword) {
-		return &model.Response{
-			Success:   false,
-			Message:   "비밀번호가 일지하지 않습니다.",
-			ErrorCode: model.ResponseErrorCodeInvalidUserPwd,
-		}, nil
+		return nil, model.NewError(model.ResponseErrorCodeInvalidUserPwd)
 	}
 
 	return model.SimpleSuccess(), nil
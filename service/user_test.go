@@ -0,0 +1,55 @@
+package service_test
+
+import (
+	"buddle-server/model"
+	"buddle-server/repository/repositorytest"
+	"buddle-server/service"
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+func TestUserService_SignIn(t *testing.T) {
+	hashed, err := service.HashPassword("secret")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %+v", err)
+	}
+
+	repo := repositorytest.NewRepository()
+	repo.UserRepository.GetUserByIDFunc = func(c context.Context, id string) (*model.User, error) {
+		if id != "admin" {
+			return nil, errors.Wrap(gorm.ErrRecordNotFound, "get user")
+		}
+		return &model.User{Id: "admin", Password: hashed}, nil
+	}
+	s, err := service.NewUserService(repo)
+	if err != nil {
+		t.Fatalf("NewUserService() error = %+v", err)
+	}
+	c := context.Background()
+
+	if resp, err := s.SignIn(c, &model.User{Id: "admin", Password: "secret"}); err != nil || !resp.Success {
+		t.Errorf("SignIn() = %+v, %v", resp, err)
+	}
+
+	// 비밀번호가 틀리면 응답 없이 에러를 반환해야 핸들러가 토큰을 발행하지 않는다.
+	tests := []struct {
+		name string
+		user *model.User
+		code model.ResponseErrorCode
+	}{
+		{name: "wrong password", user: &model.User{Id: "admin", Password: "wrong"}, code: model.ResponseErrorCodeInvalidUserPwd},
+		{name: "unknown id", user: &model.User{Id: "unknown", Password: "secret"}, code: model.ResponseErrorCodeUserIDNotExist},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.SignIn(c, tt.user)
+			var e *model.Error
+			if resp != nil || !errors.As(err, &e) || e.ErrorCode != tt.code || !errors.Is(err, model.ErrUnauthorized) {
+				t.Errorf("SignIn() = %+v, %v, want error code %s", resp, err, tt.code)
+			}
+		})
+	}
+}